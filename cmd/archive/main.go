@@ -0,0 +1,284 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+	"github.com/shipwright-io/build/pkg/util"
+)
+
+const (
+	// ReasonDownloadFailed is the error reason in case the archive could not be downloaded
+	ReasonDownloadFailed = "ArchiveDownloadFailed"
+
+	// ReasonChecksumMismatch is the error reason in case the checksum of the archive does not match
+	ReasonChecksumMismatch = "ArchiveChecksumMismatch"
+
+	// ReasonExtractionFailed is the error reason in case the archive could not be extracted
+	ReasonExtractionFailed = "ArchiveExtractionFailed"
+
+	// defaultTimeout is the maximum duration of the download if the timeout flag is not set
+	defaultTimeout = 10 * time.Minute
+)
+
+// ArchiveError is an error of the archive step that carries a reason to be
+// surfaced in the failure details of the BuildRun
+type ArchiveError struct {
+	Reason  string
+	Message string
+}
+
+func (e *ArchiveError) Error() string {
+	return e.Message
+}
+
+type settings struct {
+	help                      bool
+	url                       string
+	sha256                    string
+	target                    string
+	secretPath                string
+	resultFileDigest          string
+	resultFileSourceTimestamp string
+	resultFileErrorMessage    string
+	resultFileErrorReason     string
+	showListing               bool
+	timeout                   time.Duration
+}
+
+var flagValues settings
+
+// httpClient fails a download when the server does not answer in time, the duration
+// of the whole download is limited by the timeout flag
+var httpClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: 10 * time.Second}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+}
+
+func init() {
+	// Explicitly define the help flag so that --help can be invoked and returns status code 0
+	pflag.BoolVar(&flagValues.help, "help", false, "Print the help")
+
+	// Main flags of the archive step
+	pflag.StringVar(&flagValues.url, "url", "", "The HTTP(S) URL of the archive (mandatory)")
+	pflag.StringVar(&flagValues.sha256, "sha256", "", "The expected hex-encoded SHA-256 checksum of the archive (optional)")
+	pflag.StringVar(&flagValues.target, "target", "/workspace/source", "The target directory to place the code")
+	pflag.StringVar(&flagValues.resultFileDigest, "result-file-digest", "", "A file to write the archive digest")
+	pflag.StringVar(&flagValues.resultFileSourceTimestamp, "result-file-source-timestamp", "", "A file to write the source timestamp")
+
+	// Flags with paths for writing error related information
+	pflag.StringVar(&flagValues.resultFileErrorMessage, "result-file-error-message", "", "A file to write the error message to.")
+	pflag.StringVar(&flagValues.resultFileErrorReason, "result-file-error-reason", "", "A file to write the error reason to.")
+
+	pflag.StringVar(&flagValues.secretPath, "secret-path", "", "A directory that contains access credentials, either username and password, or a token (optional)")
+	pflag.BoolVar(&flagValues.showListing, "show-listing", false, "Print file listing of files extracted from the archive")
+	pflag.DurationVar(&flagValues.timeout, "timeout", defaultTimeout, "The maximum duration of the download")
+}
+
+func main() {
+	if err := Do(context.Background()); err != nil {
+		var archiveErr *ArchiveError
+		if errors.As(err, &archiveErr) {
+			if writeErr := writeErrorResults(archiveErr); writeErr != nil {
+				log.Printf("Could not write error results: %s", writeErr.Error())
+			}
+		}
+
+		log.Fatal(err.Error())
+	}
+}
+
+// Do is the main entry point of the archive command
+func Do(ctx context.Context) error {
+	flagValues = settings{}
+	pflag.Parse()
+
+	if val, ok := os.LookupEnv("ARCHIVE_SHOW_LISTING"); ok {
+		flagValues.showListing, _ = strconv.ParseBool(val)
+	}
+
+	if flagValues.help {
+		pflag.Usage()
+		return nil
+	}
+
+	if flagValues.url == "" {
+		return fmt.Errorf("mandatory flag --url is not set")
+	}
+
+	archiveURL, err := url.Parse(flagValues.url)
+	if err != nil {
+		return err
+	}
+
+	if archiveURL.Scheme != "http" && archiveURL.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q, only http and https are supported", archiveURL.Scheme)
+	}
+
+	tempDir, err := os.MkdirTemp("", "archive")
+	if err != nil {
+		return err
+	}
+
+	defer os.RemoveAll(tempDir)
+
+	archiveFile := filepath.Join(tempDir, "archive")
+
+	log.Printf("Downloading archive %q", archiveURL.Redacted())
+	digest, err := download(ctx, archiveURL, archiveFile)
+	if err != nil {
+		return &ArchiveError{Reason: ReasonDownloadFailed, Message: err.Error()}
+	}
+
+	if flagValues.sha256 != "" && !strings.EqualFold(flagValues.sha256, digest) {
+		return &ArchiveError{
+			Reason:  ReasonChecksumMismatch,
+			Message: fmt.Sprintf("checksum of the archive sha256:%s does not match the expected checksum sha256:%s", digest, strings.ToLower(flagValues.sha256)),
+		}
+	}
+
+	unpackDetails, err := bundle.UnpackArchive(archiveFile, flagValues.target)
+	if err != nil {
+		return &ArchiveError{Reason: ReasonExtractionFailed, Message: err.Error()}
+	}
+
+	log.Printf("Archive content was extracted to %s\n", flagValues.target)
+	if flagValues.showListing {
+		// ignore any errors when walking through the file system, the listing is only for informational purposes
+		_ = util.ListFiles(log.Writer(), flagValues.target)
+	}
+
+	if flagValues.resultFileDigest != "" {
+		if err = os.WriteFile(flagValues.resultFileDigest, []byte("sha256:"+digest), 0644); err != nil {
+			return err
+		}
+	}
+
+	if flagValues.resultFileSourceTimestamp != "" {
+		if unpackDetails.MostRecentFileTimestamp != nil {
+			if err = os.WriteFile(flagValues.resultFileSourceTimestamp, []byte(strconv.FormatInt(unpackDetails.MostRecentFileTimestamp.Unix(), 10)), 0644); err != nil {
+				return err
+			}
+
+		} else {
+			log.Printf("Unable to determine source timestamp of content in %s\n", flagValues.target)
+		}
+	}
+
+	return nil
+}
+
+// download stores the archive in the given file and returns the hex-encoded
+// SHA-256 checksum of its content
+func download(ctx context.Context, archiveURL *url.URL, path string) (string, error) {
+	timeout := flagValues.timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, archiveURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if flagValues.secretPath != "" {
+		if err := authenticate(req); err != nil {
+			return "", err
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download archive %q: %s", archiveURL.Redacted(), resp.Status)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), resp.Body); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// authenticate adds the credentials from the secret directory to the request,
+// either as bearer token or using basic authentication
+func authenticate(req *http.Request) error {
+	if token, err := os.ReadFile(filepath.Join(flagValues.secretPath, "token")); err == nil {
+		if req.URL.Scheme != "https" {
+			return fmt.Errorf("refusing to send a token over insecure HTTP connection")
+		}
+
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+		return nil
+	}
+
+	username, usernameErr := os.ReadFile(filepath.Join(flagValues.secretPath, "username"))
+	password, passwordErr := os.ReadFile(filepath.Join(flagValues.secretPath, "password"))
+	switch {
+	case usernameErr == nil && passwordErr == nil:
+		if req.URL.Scheme != "https" {
+			return fmt.Errorf("refusing to continue with basic authentication (username and password) over insecure HTTP connection")
+		}
+
+		req.SetBasicAuth(string(username), string(password))
+		return nil
+
+	case usernameErr == nil || passwordErr == nil:
+		return fmt.Errorf("basic auth incomplete: both username and password need to be configured")
+
+	default:
+		return fmt.Errorf("unsupported type of credentials provided, either a token or username/password is supported")
+	}
+}
+
+func writeErrorResults(failure *ArchiveError) error {
+	if flagValues.resultFileErrorReason == "" || flagValues.resultFileErrorMessage == "" {
+		return nil
+	}
+
+	if err := os.WriteFile(flagValues.resultFileErrorMessage, []byte(strings.TrimSpace(failure.Message)), 0666); err != nil {
+		return err
+	}
+
+	return os.WriteFile(flagValues.resultFileErrorReason, []byte(failure.Reason), 0666)
+}
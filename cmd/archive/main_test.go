@@ -0,0 +1,216 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	. "github.com/shipwright-io/build/cmd/archive"
+)
+
+var _ = Describe("Archive Loader", func() {
+	run := func(args ...string) error {
+		log.SetOutput(GinkgoWriter)
+
+		// discard stderr output
+		var tmp = os.Stderr
+		os.Stderr = nil
+		defer func() { os.Stderr = tmp }()
+
+		os.Args = append([]string{"tool"}, args...)
+		return Do(context.Background())
+	}
+
+	withTempDir := func(f func(target string)) {
+		path, err := os.MkdirTemp(os.TempDir(), "archive")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(path)
+
+		f(path)
+	}
+
+	tarGz := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		for name, content := range files {
+			Expect(tw.WriteHeader(&tar.Header{
+				Name:     name,
+				Typeflag: tar.TypeReg,
+				Mode:     0644,
+				Size:     int64(len(content)),
+			})).To(Succeed())
+			_, err := tw.Write([]byte(content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(tw.Close()).To(Succeed())
+		Expect(gw.Close()).To(Succeed())
+		return buf.Bytes()
+	}
+
+	checksum := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	withArchiveServer := func(data []byte, f func(url string)) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/source.tar.gz" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			_, _ = w.Write(data)
+		}))
+		defer s.Close()
+
+		f(s.URL + "/source.tar.gz")
+	}
+
+	Context("validations and error cases", func() {
+		It("should succeed in case the help flag is used", func() {
+			Expect(run("--help")).To(Succeed())
+		})
+
+		It("should fail in case the URL is not specified", func() {
+			Expect(run()).To(HaveOccurred())
+		})
+
+		It("should fail in case the URL uses an unsupported scheme", func() {
+			Expect(run("--url", "ftp://example.com/source.tar.gz")).To(HaveOccurred())
+		})
+
+		It("should fail with a reason in case the archive does not exist", func() {
+			withArchiveServer(nil, func(url string) {
+				withTempDir(func(target string) {
+					err := run("--url", url+".missing", "--target", target)
+					Expect(err).To(HaveOccurred())
+
+					var archiveErr *ArchiveError
+					Expect(errors.As(err, &archiveErr)).To(BeTrue())
+					Expect(archiveErr.Reason).To(Equal(ReasonDownloadFailed))
+				})
+			})
+		})
+
+		It("should fail with a reason in case the download stalls longer than the timeout", func() {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.(http.Flusher).Flush()
+
+				select {
+				case <-r.Context().Done():
+				case <-time.After(10 * time.Second):
+				}
+			}))
+			defer s.Close()
+
+			withTempDir(func(target string) {
+				err := run("--url", s.URL+"/source.tar.gz", "--target", target, "--timeout", "500ms")
+				Expect(err).To(HaveOccurred())
+
+				var archiveErr *ArchiveError
+				Expect(errors.As(err, &archiveErr)).To(BeTrue())
+				Expect(archiveErr.Reason).To(Equal(ReasonDownloadFailed))
+			})
+		})
+
+		It("should fail with a reason in case the checksum does not match", func() {
+			data := tarGz(map[string]string{"README.md": "# readme"})
+
+			withArchiveServer(data, func(url string) {
+				withTempDir(func(target string) {
+					err := run(
+						"--url", url,
+						"--target", target,
+						"--sha256", checksum([]byte("something else")),
+					)
+					Expect(err).To(HaveOccurred())
+
+					var archiveErr *ArchiveError
+					Expect(errors.As(err, &archiveErr)).To(BeTrue())
+					Expect(archiveErr.Reason).To(Equal(ReasonChecksumMismatch))
+					Expect(filepath.Join(target, "README.md")).ToNot(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should refuse to send credentials over an insecure connection", func() {
+			data := tarGz(map[string]string{"README.md": "# readme"})
+
+			withArchiveServer(data, func(url string) {
+				withTempDir(func(secret string) {
+					Expect(os.WriteFile(filepath.Join(secret, "token"), []byte("secret"), 0644)).To(Succeed())
+
+					withTempDir(func(target string) {
+						Expect(run(
+							"--url", url,
+							"--target", target,
+							"--secret-path", secret,
+						)).To(HaveOccurred())
+					})
+				})
+			})
+		})
+	})
+
+	Context("downloading archives", func() {
+		It("should download and unpack the archive into the target directory", func() {
+			data := tarGz(map[string]string{
+				"README.md":       "# readme",
+				"src/main/app.go": "package main",
+			})
+
+			withArchiveServer(data, func(url string) {
+				withTempDir(func(target string) {
+					Expect(run(
+						"--url", url,
+						"--target", target,
+						"--sha256", checksum(data),
+					)).To(Succeed())
+
+					Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "src", "main", "app.go")).To(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should store the archive digest into the file specified in --result-file-digest", func() {
+			data := tarGz(map[string]string{"README.md": "# readme"})
+
+			withArchiveServer(data, func(url string) {
+				withTempDir(func(target string) {
+					resultFile := filepath.Join(target, "digest")
+
+					Expect(run(
+						"--url", url,
+						"--target", filepath.Join(target, "source"),
+						"--result-file-digest", resultFile,
+					)).To(Succeed())
+
+					content, err := os.ReadFile(resultFile)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(content)).To(Equal("sha256:" + checksum(data)))
+				})
+			})
+		})
+	})
+})
@@ -15,6 +15,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	shpgit "github.com/shipwright-io/build/pkg/git"
 	"github.com/shipwright-io/build/pkg/util"
@@ -67,6 +68,8 @@ type settings struct {
 	resultFileErrorReason     string
 	verbose                   bool
 	showListing               bool
+	retries                   uint
+	retryDelay                time.Duration
 }
 
 var flagValues settings
@@ -102,6 +105,11 @@ func init() {
 	// for (in the context of Shipwright build).
 	pflag.UintVar(&flagValues.depth, "depth", 1, "Create a shallow clone based on the given depth")
 
+	// Optional flags to configure how often and with which initial delay the
+	// clone is retried in case of a transient error, e.g. a network hiccup
+	pflag.UintVar(&flagValues.retries, "retries", 2, "Number of retries of the clone operation in case of transient errors")
+	pflag.DurationVar(&flagValues.retryDelay, "retry-delay", 2*time.Second, "Initial delay before retrying the clone operation, doubled with every retry")
+
 	// Mostly internal flag
 	pflag.BoolVar(&flagValues.skipValidation, "skip-validation", false, "skip pre-requisite validation")
 	pflag.BoolVar(&flagValues.gitURLRewrite, "git-url-rewrite", false, "set Git config to use url-insteadOf setting based on Git repository URL")
@@ -128,7 +136,7 @@ func main() {
 
 // Execute performs flag parsing, input validation and the Git clone
 func Execute(ctx context.Context) error {
-	flagValues = settings{depth: 1}
+	flagValues = settings{depth: 1, retries: 2, retryDelay: 2 * time.Second}
 	pflag.Parse()
 
 	if val, ok := os.LookupEnv("GIT_SHOW_LISTING"); ok {
@@ -176,7 +184,7 @@ func runGitClone(ctx context.Context) error {
 		}
 	}
 
-	if err := clone(ctx); err != nil {
+	if err := cloneWithRetries(ctx); err != nil {
 		return err
 	}
 
@@ -268,6 +276,64 @@ func checkEnvironment(ctx context.Context) error {
 	return nil
 }
 
+// cloneWithRetries runs the clone and repeats it with an exponential backoff
+// in case it failed with an error that is classified as transient
+func cloneWithRetries(ctx context.Context) error {
+	delay := flagValues.retryDelay
+
+	for attempt := uint(0); ; attempt++ {
+		err := clone(ctx)
+		if err == nil {
+			return nil
+		}
+
+		errorResult := shpgit.NewErrorResultFromMessage(err.Error())
+		if !errorResult.Reason.IsTransient() || attempt >= flagValues.retries {
+			return err
+		}
+
+		log.Printf("Warning: clone failed with transient error (%s), retrying in %s (%d/%d)\n",
+			errorResult.Reason.String(),
+			delay,
+			attempt+1,
+			flagValues.retries,
+		)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+
+		// a failed clone might leave a partial checkout behind, which would
+		// make the next attempt fail because the target is not empty
+		if err := cleanTarget(); err != nil {
+			return err
+		}
+	}
+}
+
+func cleanTarget() error {
+	entries, err := os.ReadDir(flagValues.target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(flagValues.target, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func clone(ctx context.Context) error {
 	cloneArgs := []string{
 		"clone",
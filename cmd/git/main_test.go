@@ -11,6 +11,7 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -669,6 +670,51 @@ var _ = Describe("Git Resource", func() {
 		})
 	})
 
+	Context("retrying transient errors", func() {
+		It("should retry the clone in case the Git server responds with an internal error", func() {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/foo/bar",
+					"--target", target,
+					"--retries", "2",
+					"--retry-delay", "10ms",
+				))
+
+				Expect(err).To(HaveOccurred())
+				Expect(shpgit.NewErrorResultFromMessage(err.Error()).Reason).To(Equal(shpgit.ServerError))
+				Expect(requests).To(BeNumerically(">=", 3))
+			})
+		})
+
+		It("should not retry the clone in case of a non-transient error", func() {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				requests++
+				w.WriteHeader(http.StatusNotFound)
+			}))
+			defer server.Close()
+
+			withTempDir(func(target string) {
+				err := run(withArgs(
+					"--url", server.URL+"/foo/bar",
+					"--target", target,
+					"--retries", "2",
+					"--retry-delay", "10ms",
+				))
+
+				Expect(err).To(HaveOccurred())
+				Expect(requests).To(Equal(1))
+			})
+		})
+	})
+
 	Context("Using show listing flag", func() {
 		const exampleRepo = "https://github.com/shipwright-io/sample-go"
 
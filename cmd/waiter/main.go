@@ -15,6 +15,18 @@ import (
 type settings struct {
 	lockFile string        // path to lock file
 	timeout  time.Duration // how long wait for 'done'
+
+	uploadListenAddress    string // address of the upload endpoint, disabled when empty
+	uploadTokenFile        string // path to the file with the token for the upload endpoint
+	uploadTarget           string // directory to extract the upload into
+	uploadMaxSize          int64  // maximum size of the upload in bytes
+	resultFileUploadDigest string // path to the result file for the upload digest
+	resultFileUploadSize   string // path to the result file for the upload size
+
+	sourceDirectory           string // directory to inspect for the source results, disabled when empty
+	resultFileSourceDigest    string // path to the result file for the source digest
+	resultFileSourceFileCount string // path to the result file for the source file count
+	resultFileSourceSize      string // path to the result file for the source size
 }
 
 const longDesc = `
@@ -38,6 +50,17 @@ Or, alternatively:
 
 	$ rm -f <lock-file>
 
+Alternatively, the source code can be uploaded to an authenticated upload endpoint,
+which is started with --upload-listen-address. A successful upload signals "done":
+
+	$ waiter start --upload-listen-address=:8710 --upload-token-file=<file> --upload-target=<dir>
+
+When --source-directory is set, "done" (or a successful upload) calculates the digest,
+the number of files and the total size of the source directory, and writes them to
+the --result-file-source-* files. The flags default to the WAITER_SOURCE_DIRECTORY and
+WAITER_RESULT_FILE_SOURCE_* environment variables, so that "waiter done" can be run
+without flags in the container that runs "waiter start".
+
 ## Return-Code
 
 In the case of timeout, the waiter will return error, it only exits gracefully via
@@ -56,6 +79,9 @@ var defaultTimeout = 60 * time.Second
 // defaultLockFile default location of the lock-file.
 var defaultLockFile = "/tmp/waiter.lock"
 
+// defaultUploadMaxSize default maximum size of an upload, 1 GiB.
+var defaultUploadMaxSize int64 = 1 << 30
+
 // flagValues receives the command-line flag values.
 var flagValues = settings{}
 
@@ -65,6 +91,19 @@ func init() {
 
 	flags.StringVar(&flagValues.lockFile, "lock-file", defaultLockFile, "lock file full path")
 	flags.DurationVar(&flagValues.timeout, "timeout", defaultTimeout, "how long to wait until 'done'")
+	flags.StringVar(&flagValues.sourceDirectory, "source-directory", os.Getenv("WAITER_SOURCE_DIRECTORY"), "directory to calculate the source results of, disabled if not set")
+	flags.StringVar(&flagValues.resultFileSourceDigest, "result-file-source-digest", os.Getenv("WAITER_RESULT_FILE_SOURCE_DIGEST"), "file to write the digest of the source directory to")
+	flags.StringVar(&flagValues.resultFileSourceFileCount, "result-file-source-file-count", os.Getenv("WAITER_RESULT_FILE_SOURCE_FILE_COUNT"), "file to write the number of files in the source directory to")
+	flags.StringVar(&flagValues.resultFileSourceSize, "result-file-source-size", os.Getenv("WAITER_RESULT_FILE_SOURCE_SIZE"), "file to write the total size of the source directory to")
+
+	startFlags := startCmd.Flags()
+
+	startFlags.StringVar(&flagValues.uploadListenAddress, "upload-listen-address", "", "address of the upload endpoint, disabled if not set")
+	startFlags.StringVar(&flagValues.uploadTokenFile, "upload-token-file", "", "file with the token that clients must present to the upload endpoint")
+	startFlags.StringVar(&flagValues.uploadTarget, "upload-target", "/workspace/source", "directory to extract the uploaded source code into")
+	startFlags.Int64Var(&flagValues.uploadMaxSize, "upload-max-size", defaultUploadMaxSize, "maximum size of the upload in bytes")
+	startFlags.StringVar(&flagValues.resultFileUploadDigest, "result-file-upload-digest", "", "file to write the digest of the upload to")
+	startFlags.StringVar(&flagValues.resultFileUploadSize, "result-file-upload-size", "", "file to write the size of the upload to")
 
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(doneCmd)
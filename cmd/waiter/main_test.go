@@ -5,14 +5,22 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/compression"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 	"github.com/onsi/gomega/types"
 
+	"github.com/shipwright-io/build/pkg/bundle"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -113,6 +121,188 @@ var _ = Describe("Waiter", func() {
 		})
 	})
 
+	Describe("expect to write the source results when `done` is issued", func() {
+		var (
+			startCh chan interface{}
+			tmpDir  string
+		)
+
+		BeforeEach(func() {
+			startCh = make(chan interface{})
+
+			var err error
+			tmpDir, err = os.MkdirTemp("", "waiter")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.MkdirAll(filepath.Join(tmpDir, "source", "cmd"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "source", "go.mod"), []byte("module example\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "source", "cmd", "main.go"), []byte("package main\n"), 0644)).To(Succeed())
+
+			session := run("start")
+
+			go inspectSession(session, startCh, gexec.Exit(0))
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(tmpDir)
+		})
+
+		It("writes digest, file count and size of the source directory", func() {
+			session := run("done",
+				"--source-directory", filepath.Join(tmpDir, "source"),
+				"--result-file-source-digest", filepath.Join(tmpDir, "source-digest"),
+				"--result-file-source-file-count", filepath.Join(tmpDir, "source-file-count"),
+				"--result-file-source-size", filepath.Join(tmpDir, "source-size"),
+			)
+
+			Eventually(session, defaultTimeout).Should(gexec.Exit(0))
+			Eventually(startCh, defaultTimeout).Should(BeClosed())
+
+			digest, err := os.ReadFile(filepath.Join(tmpDir, "source-digest"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(digest)).To(MatchRegexp(`^sha256:[a-f0-9]{64}$`))
+
+			fileCount, err := os.ReadFile(filepath.Join(tmpDir, "source-file-count"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(fileCount)).To(Equal("2"))
+
+			size, err := os.ReadFile(filepath.Join(tmpDir, "source-size"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(size)).To(Equal("28"))
+		})
+
+		It("calculates the same digest for the same content", func() {
+			details, err := inspectSource(filepath.Join(tmpDir, "source"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.Chtimes(filepath.Join(tmpDir, "source", "go.mod"), time.Unix(0, 0), time.Unix(0, 0))).To(Succeed())
+
+			again, err := inspectSource(filepath.Join(tmpDir, "source"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(again).To(Equal(details))
+
+			Expect(os.WriteFile(filepath.Join(tmpDir, "source", "go.mod"), []byte("module changed\n"), 0644)).To(Succeed())
+
+			changed, err := inspectSource(filepath.Join(tmpDir, "source"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed.Digest).ToNot(Equal(details.Digest))
+
+			Expect(os.RemoveAll(defaultLockFile)).To(Succeed())
+			Eventually(startCh, defaultTimeout).Should(BeClosed())
+		})
+	})
+
+	Describe("expect to succeed when the source code is uploaded", func() {
+		var (
+			startCh   chan interface{}
+			endpoint  string
+			tmpDir    string
+			extraArgs []string
+		)
+
+		BeforeEach(func() {
+			extraArgs = nil
+		})
+
+		JustBeforeEach(func() {
+			startCh = make(chan interface{})
+
+			var err error
+			tmpDir, err = os.MkdirTemp("", "waiter")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.WriteFile(filepath.Join(tmpDir, "token"), []byte("secret-token\n"), 0600)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(tmpDir, "source"), 0755)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(tmpDir, "target"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "source", "main.go"), []byte("package main\n"), 0644)).To(Succeed())
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+			address := listener.Addr().String()
+			Expect(listener.Close()).To(Succeed())
+
+			endpoint = "http://" + address
+			session := run(append([]string{"start",
+				"--upload-listen-address", address,
+				"--upload-token-file", filepath.Join(tmpDir, "token"),
+				"--upload-target", filepath.Join(tmpDir, "target"),
+				"--result-file-upload-digest", filepath.Join(tmpDir, "upload-digest"),
+				"--result-file-upload-size", filepath.Join(tmpDir, "upload-size"),
+			}, extraArgs...)...)
+
+			go inspectSession(session, startCh, gexec.Exit(0))
+		})
+
+		AfterEach(func() {
+			_ = os.RemoveAll(tmpDir)
+		})
+
+		It("rejects an upload with a wrong token", func() {
+			_, err := bundle.Upload(context.TODO(), endpoint, "wrong-token", filepath.Join(tmpDir, "source"), bundle.PackOptions{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("401"))
+
+			Expect(os.RemoveAll(defaultLockFile)).To(Succeed())
+			Eventually(startCh, defaultTimeout).Should(BeClosed())
+		})
+
+		It("rejects an upload with the token but without the Bearer scheme", func() {
+			var statusCode int
+			Eventually(func() error {
+				request, err := http.NewRequest(http.MethodPost, endpoint+bundle.UploadPath, bytes.NewReader(nil))
+				if err != nil {
+					return err
+				}
+				request.Header.Set("Authorization", "secret-token")
+
+				response, err := http.DefaultClient.Do(request)
+				if err != nil {
+					return err
+				}
+				defer response.Body.Close()
+
+				statusCode = response.StatusCode
+				return nil
+			}, defaultTimeout).Should(Succeed())
+			Expect(statusCode).To(Equal(http.StatusUnauthorized))
+
+			Expect(os.RemoveAll(defaultLockFile)).To(Succeed())
+			Eventually(startCh, defaultTimeout).Should(BeClosed())
+		})
+
+		It("extracts the upload and stops", func() {
+			details, err := bundle.Upload(context.TODO(), endpoint, "secret-token", filepath.Join(tmpDir, "source"), bundle.PackOptions{Compression: compression.ZStd})
+			Expect(err).ToNot(HaveOccurred())
+
+			Eventually(startCh, defaultTimeout).Should(BeClosed())
+
+			Expect(filepath.Join(tmpDir, "target", "main.go")).To(BeAnExistingFile())
+
+			digest, err := os.ReadFile(filepath.Join(tmpDir, "upload-digest"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(digest)).To(Equal(details.Digest))
+
+			size, err := os.ReadFile(filepath.Join(tmpDir, "upload-size"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(size)).To(Equal(strconv.FormatInt(details.Size, 10)))
+		})
+
+		Context("with a maximum upload size", func() {
+			BeforeEach(func() {
+				extraArgs = []string{"--upload-max-size", "16"}
+			})
+
+			It("rejects an upload above the maximum size", func() {
+				_, err := bundle.Upload(context.TODO(), endpoint, "secret-token", filepath.Join(tmpDir, "source"), bundle.PackOptions{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("413"))
+
+				Expect(os.RemoveAll(defaultLockFile)).To(Succeed())
+				Eventually(startCh, defaultTimeout).Should(BeClosed())
+			})
+		})
+	})
+
 	Describe("expect to fail when timeout is reached", func() {
 		var startCh = make(chan interface{})
 
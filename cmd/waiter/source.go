@@ -0,0 +1,119 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// sourceDetails describes the content of the source directory
+type sourceDetails struct {
+	Digest    string
+	FileCount int64
+	Size      int64
+}
+
+// inspectSource walks the source directory in lexical order and calculates a
+// digest over the relative paths and checksums of all files, symbolic links
+// contribute their target instead of a checksum. Directories themselves are
+// not part of the digest, so that only the content matters.
+func inspectSource(directory string) (*sourceDetails, error) {
+	var details sourceDetails
+
+	hash := sha256.New()
+	err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		name, err := filepath.Rel(directory, path)
+		if err != nil {
+			return err
+		}
+
+		var checksum string
+		switch {
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			sum := sha256.Sum256([]byte(target))
+			checksum = hex.EncodeToString(sum[:])
+
+		case d.Type().IsRegular():
+			file, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+
+			fileHash := sha256.New()
+			size, err := io.Copy(fileHash, file)
+			file.Close()
+			if err != nil {
+				return err
+			}
+
+			checksum = hex.EncodeToString(fileHash.Sum(nil))
+			details.Size += size
+
+		default:
+			// sockets, devices and the like are not source code
+			return nil
+		}
+
+		details.FileCount++
+		_, err = fmt.Fprintf(hash, "%s  %s\n", checksum, filepath.ToSlash(name))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details.Digest = "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	return &details, nil
+}
+
+// writeSourceResults inspects the source directory and writes the details to
+// the configured result files, nothing happens if no source directory is set
+func (w *Waiter) writeSourceResults() error {
+	if w.flagValues.sourceDirectory == "" {
+		return nil
+	}
+
+	details, err := inspectSource(w.flagValues.sourceDirectory)
+	if err != nil {
+		return fmt.Errorf("failed to inspect the source directory: %w", err)
+	}
+
+	for file, value := range map[string]string{
+		w.flagValues.resultFileSourceDigest:    details.Digest,
+		w.flagValues.resultFileSourceFileCount: strconv.FormatInt(details.FileCount, 10),
+		w.flagValues.resultFileSourceSize:      strconv.FormatInt(details.Size, 10),
+	} {
+		if file == "" {
+			continue
+		}
+
+		if err := os.WriteFile(file, []byte(value), 0644); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("Source directory %s contains %d files with %d bytes, digest %s\n", w.flagValues.sourceDirectory, details.FileCount, details.Size, details.Digest)
+	return nil
+}
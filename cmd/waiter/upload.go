@@ -0,0 +1,267 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/shipwright-io/build/pkg/bundle"
+)
+
+var checksumRegEx = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+
+// uploadServer accepts exactly one successful upload of a tar stream, which is
+// extracted into the target directory. Afterwards the lock-file is removed, so
+// that the waiter ends. Uploads arriving while another one is processed are
+// rejected.
+type uploadServer struct {
+	waiter     *Waiter
+	flagValues *settings
+	token      string
+	server     *http.Server
+
+	mutex    sync.Mutex
+	uploaded bool
+}
+
+// uploadResponse is the body of a successful upload response
+type uploadResponse struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
+
+// startUploadServer starts to listen for uploads in the background.
+func (w *Waiter) startUploadServer() (*uploadServer, error) {
+	token, err := os.ReadFile(w.flagValues.uploadTokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the upload token: %w", err)
+	}
+
+	if len(strings.TrimSpace(string(token))) == 0 {
+		return nil, errors.New("the upload token must not be empty")
+	}
+
+	listener, err := net.Listen("tcp", w.flagValues.uploadListenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &uploadServer{
+		waiter:     w,
+		flagValues: w.flagValues,
+		token:      strings.TrimSpace(string(token)),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(bundle.UploadPath, s.handleUpload)
+	s.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[ERROR] Upload server stopped: %v\n", err)
+		}
+	}()
+
+	log.Printf("Listening for uploads on %s\n", listener.Addr().String())
+	return s, nil
+}
+
+// stop gracefully shuts down the server, so that the response of a finished
+// upload still reaches the client.
+func (s *uploadServer) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_ = s.server.Shutdown(ctx)
+}
+
+func (s *uploadServer) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		http.Error(w, "only POST and PUT are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	checksum := r.Header.Get(bundle.UploadChecksumHeader)
+	if !checksumRegEx.MatchString(checksum) {
+		http.Error(w, fmt.Sprintf("header %s must contain the hex-encoded SHA-256 checksum of the body", bundle.UploadChecksumHeader), http.StatusBadRequest)
+		return
+	}
+
+	var decompress func(io.Reader) (io.ReadCloser, error)
+	switch r.Header.Get("Content-Encoding") {
+	case "", "identity":
+		decompress = func(in io.Reader) (io.ReadCloser, error) { return io.NopCloser(in), nil }
+
+	case "zstd":
+		decompress = func(in io.Reader) (io.ReadCloser, error) {
+			decoder, err := zstd.NewReader(in)
+			if err != nil {
+				return nil, err
+			}
+
+			return decoder.IOReadCloser(), nil
+		}
+
+	default:
+		http.Error(w, "supported content encodings are identity and zstd", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if r.ContentLength > s.flagValues.uploadMaxSize {
+		http.Error(w, fmt.Sprintf("the upload exceeds the maximum size of %d bytes", s.flagValues.uploadMaxSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// only one upload is processed, concurrent or later uploads are rejected
+	if !s.mutex.TryLock() {
+		http.Error(w, "another upload is in progress", http.StatusConflict)
+		return
+	}
+
+	defer s.mutex.Unlock()
+	if s.uploaded {
+		http.Error(w, "source code was already uploaded", http.StatusConflict)
+		return
+	}
+
+	file, err := os.CreateTemp("", "upload")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	// store the stream first, it is only extracted once its checksum is verified
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), http.MaxBytesReader(w, r.Body, s.flagValues.uploadMaxSize))
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			http.Error(w, fmt.Sprintf("the upload exceeds the maximum size of %d bytes", maxBytesError.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		http.Error(w, fmt.Sprintf("failed to receive the upload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if !strings.EqualFold(digest, checksum) {
+		http.Error(w, fmt.Sprintf("checksum sha256:%s of the upload does not match the provided checksum sha256:%s", digest, strings.ToLower(checksum)), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	tarStream, err := decompress(file)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to decompress the upload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	defer tarStream.Close()
+
+	if _, err := bundle.Unpack(tarStream, s.flagValues.uploadTarget); err != nil {
+		// remove what was extracted so far, so that a retry starts with an empty target
+		if cleanupErr := cleanDirectory(s.flagValues.uploadTarget); cleanupErr != nil {
+			log.Printf("[ERROR] Failed to clean up the upload target: %v\n", cleanupErr)
+		}
+
+		http.Error(w, fmt.Sprintf("failed to extract the upload: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	result := uploadResponse{Digest: "sha256:" + digest, Size: size}
+	if err := s.writeResults(result); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := s.waiter.writeSourceResults(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.uploaded = true
+	log.Printf("Received upload of %d bytes with digest %s into %s\n", result.Size, result.Digest, s.flagValues.uploadTarget)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(result)
+
+	// signal the waiter that the upload is done
+	if err := os.Remove(s.flagValues.lockFile); err != nil {
+		log.Printf("[ERROR] Failed to remove lock-file: %v\n", err)
+	}
+}
+
+func (s *uploadServer) writeResults(result uploadResponse) error {
+	if s.flagValues.resultFileUploadDigest != "" {
+		if err := os.WriteFile(s.flagValues.resultFileUploadDigest, []byte(result.Digest), 0644); err != nil {
+			return err
+		}
+	}
+
+	if s.flagValues.resultFileUploadSize != "" {
+		if err := os.WriteFile(s.flagValues.resultFileUploadSize, []byte(strconv.FormatInt(result.Size, 10)), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cleanDirectory removes the content of a directory, but keeps the directory
+// itself, which usually is a volume mount
+func cleanDirectory(directory string) error {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(directory, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -69,6 +69,15 @@ func (w *Waiter) Wait() error {
 		return err
 	}
 
+	if w.flagValues.uploadListenAddress != "" {
+		server, err := w.startUploadServer()
+		if err != nil {
+			_ = os.RemoveAll(w.flagValues.lockFile)
+			return err
+		}
+		defer server.stop()
+	}
+
 	// waiting for the lock-file removal...
 	err := w.retry()
 	if err != nil {
@@ -77,12 +86,17 @@ func (w *Waiter) Wait() error {
 	return err
 }
 
-// Done removes the lock-file.
+// Done writes the source results and removes the lock-file.
 func (w *Waiter) Done() error {
 	pid, err := w.read()
 	if err != nil {
 		return err
 	}
+
+	if err := w.writeSourceResults(); err != nil {
+		return err
+	}
+
 	log.Printf("Removing lock-file at '%s' (%d PID)", w.flagValues.lockFile, pid)
 	return os.Remove(w.flagValues.lockFile)
 }
@@ -22,12 +22,26 @@ const (
 	BuildStrategyNotFound BuildReason = "BuildStrategyNotFound"
 	// ClusterBuildStrategyNotFound indicates that a cluster-scope strategy was not found
 	ClusterBuildStrategyNotFound BuildReason = "ClusterBuildStrategyNotFound"
+	// BuildStrategyRevisionNotFound indicates that the pinned version of a namespaced-scope strategy has no revision
+	BuildStrategyRevisionNotFound BuildReason = "BuildStrategyRevisionNotFound"
+	// ClusterBuildStrategyRevisionNotFound indicates that the pinned version of a cluster-scope strategy has no revision
+	ClusterBuildStrategyRevisionNotFound BuildReason = "ClusterBuildStrategyRevisionNotFound"
+	// StrategyFragmentNotFound indicates that a ClusterStrategyFragment referenced by the strategy does not exist
+	StrategyFragmentNotFound BuildReason = "StrategyFragmentNotFound"
+	// StrategyFragmentConflict indicates that the ClusterStrategyFragments of the strategy cannot be merged into it
+	StrategyFragmentConflict BuildReason = "StrategyFragmentConflict"
+	// ClusterBuildStrategyNotAllowed indicates that the namespace of the Build is not allowed to use the cluster-scope strategy
+	ClusterBuildStrategyNotAllowed BuildReason = "ClusterBuildStrategyNotAllowed"
 	// SetOwnerReferenceFailed indicates that setting ownerReferences between a Build and a BuildRun failed
 	SetOwnerReferenceFailed BuildReason = "SetOwnerReferenceFailed"
 	// SpecSourceSecretRefNotFound indicates the referenced secret in source is missing
 	SpecSourceSecretRefNotFound BuildReason = "SpecSourceSecretRefNotFound"
 	// SpecOutputSecretRefNotFound indicates the referenced secret in output is missing
 	SpecOutputSecretRefNotFound BuildReason = "SpecOutputSecretRefNotFound"
+	// SpecCacheSecretRefNotFound indicates the referenced secret in cache is missing
+	SpecCacheSecretRefNotFound BuildReason = "SpecCacheSecretRefNotFound"
+	// SpecCommitStatusSecretRefNotFound indicates the referenced secret for the commit status reporting is missing
+	SpecCommitStatusSecretRefNotFound BuildReason = "SpecCommitStatusSecretRefNotFound"
 	// SpecBuilderSecretRefNotFound indicates the referenced secret in builder is missing
 	SpecBuilderSecretRefNotFound BuildReason = "SpecBuilderSecretRefNotFound"
 	// MultipleSecretRefNotFound indicates that multiple secrets are missing
@@ -52,6 +66,18 @@ const (
 	IncompleteConfigMapValueParameterValues BuildReason = "IncompleteConfigMapValueParameterValues"
 	// IncompleteSecretValueParameterValues indicates that a secretValue is specified where the name or the key is empty
 	IncompleteSecretValueParameterValues BuildReason = "IncompleteSecretValueParameterValues"
+	// MalformedParameterValues indicates that a value of a boolean or integer parameter cannot be parsed as such
+	MalformedParameterValues BuildReason = "MalformedParameterValues"
+	// DisallowedParameterValues indicates that a parameter value is not part of the enum of the parameter
+	DisallowedParameterValues BuildReason = "DisallowedParameterValues"
+	// PatternMismatchParameterValues indicates that a parameter value does not match the pattern of the parameter
+	PatternMismatchParameterValues BuildReason = "PatternMismatchParameterValues"
+	// OutOfRangeParameterValues indicates that an integer parameter value is below the minimum or above the maximum of the parameter
+	OutOfRangeParameterValues BuildReason = "OutOfRangeParameterValues"
+	// MissingObjectPropertyParameterValues indicates that a property that the strategy requires for an object parameter has no value
+	MissingObjectPropertyParameterValues BuildReason = "MissingObjectPropertyParameterValues"
+	// InvalidObjectPropertyParameterValues indicates that an object parameter property has a name that is not valid or no value
+	InvalidObjectPropertyParameterValues BuildReason = "InvalidObjectPropertyParameterValues"
 	// RemoteRepositoryUnreachable indicates the referenced repository is unreachable
 	RemoteRepositoryUnreachable BuildReason = "RemoteRepositoryUnreachable"
 	// BuildNameInvalid indicates the build name is invalid
@@ -62,6 +88,12 @@ const (
 	VolumeNotOverridable BuildReason = "VolumeNotOverridable"
 	// UndefinedVolume indicates that volume defined by build is not found in the strategy
 	UndefinedVolume BuildReason = "UndefinedVolume"
+	// StepResourcesNotValid indicates that the resources for a step are defined more than once, for a step
+	// that is not defined in the strategy or has no maxResources, or exceed the maxResources of the step
+	StepResourcesNotValid BuildReason = "StepResourcesNotValid"
+	// StepConditionValueNotSupported indicates that a parameter that a when expression of a strategy step
+	// references gets its value from a ConfigMap or Secret, which cannot be evaluated when the TaskRun is created
+	StepConditionValueNotSupported BuildReason = "StepConditionValueNotSupported"
 	// TriggerNameCanNotBeBlank indicates the trigger condition does not have a name
 	TriggerNameCanNotBeBlank BuildReason = "TriggerNameCanNotBeBlank"
 	// TriggerInvalidType indicates the trigger type is invalid
@@ -82,6 +114,16 @@ const (
 	TolerationNotValid BuildReason = "TolerationNotValid"
 	// SchedulerNameNotValid indicates that the Scheduler name is not valid
 	SchedulerNameNotValid BuildReason = "SchedulerNameNotValid"
+	// ArchiveSourceNotValid indicates that the URL or the checksum of an archive source is not valid
+	ArchiveSourceNotValid BuildReason = "ArchiveSourceNotValid"
+	// LocalSourceNotValid indicates that the settings of a local source are not valid
+	LocalSourceNotValid BuildReason = "LocalSourceNotValid"
+	// AdditionalSourceNotValid indicates that one of the additional sources is not valid
+	AdditionalSourceNotValid BuildReason = "AdditionalSourceNotValid"
+	// NotificationNotValid indicates that one of the notifications is not valid
+	NotificationNotValid BuildReason = "NotificationNotValid"
+	// CommitStatusNotValid indicates that the commit status reporting of the Git source is not valid
+	CommitStatusNotValid BuildReason = "CommitStatusNotValid"
 	// AllValidationsSucceeded indicates a Build was successfully validated
 	AllValidationsSucceeded = "all validations succeeded"
 )
@@ -118,6 +160,30 @@ const (
 	// or has a value of 'true', the controller triggers the validation. A value of 'false' means the controller
 	// will bypass checking the remote repository.
 	AnnotationBuildVerifyRepository = BuildDomain + "/verify.repository"
+
+	// LabelCacheVolume is a label key for defining the name of the strategy volume whose cache
+	// is held by a PersistentVolumeClaim
+	LabelCacheVolume = BuildDomain + "/cache-volume"
+
+	// AnnotationCacheLockedBy is an annotation that holds the name of the BuildRun that currently
+	// mounts a cache PersistentVolumeClaim
+	AnnotationCacheLockedBy = BuildDomain + "/cache-locked-by"
+
+	// AnnotationCacheLastUsed is an annotation that holds the time when a cache PersistentVolumeClaim
+	// was last released by a BuildRun
+	AnnotationCacheLastUsed = BuildDomain + "/cache-last-used"
+
+	// AnnotationCacheTTL is an annotation that holds the duration after which an unused cache
+	// PersistentVolumeClaim is deleted
+	AnnotationCacheTTL = BuildDomain + "/cache-ttl"
+
+	// LabelPrivilegedStrategies is a namespace label key that allows Builds in the namespace to use
+	// ClusterBuildStrategies with privileged steps if it has a value of 'true'
+	LabelPrivilegedStrategies = BuildDomain + "/privileged-strategies"
+
+	// AnnotationPrivilegedStrategy is a ClusterBuildStrategy annotation key that marks the strategy as privileged with
+	// a value of 'true', or as not privileged with a value of 'false', instead of deriving it from the steps
+	AnnotationPrivilegedStrategy = BuildDomain + "/privileged"
 )
 
 const (
@@ -140,6 +206,14 @@ type BuildSpec struct {
 	// +optional
 	Source *Source `json:"source"`
 
+	// AdditionalSources is a list of sources that are fetched in addition to the
+	// source, each of them into its own subdirectory of the source directory
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AdditionalSources []AdditionalSource `json:"additionalSources,omitempty"`
+
 	// Trigger defines the scenarios where a new build should be triggered.
 	//
 	// +optional
@@ -158,6 +232,12 @@ type BuildSpec struct {
 	// Output refers to the location where the built image would be pushed.
 	Output Image `json:"output"`
 
+	// Cache refers to the location of a registry-backed layer cache that the
+	// strategy can read from and write to
+	//
+	// +optional
+	Cache *ImageCache `json:"cache,omitempty"`
+
 	// Timeout defines the maximum amount of time the Build should take to execute.
 	//
 	// +optional
@@ -180,6 +260,12 @@ type BuildSpec struct {
 	// +optional
 	Volumes []BuildVolume `json:"volumes,omitempty"`
 
+	// StepResources contains resource overrides of the BuildStrategy steps, bounded by
+	// the maxResources of the steps. Must only contain steps that define maxResources
+	//
+	// +optional
+	StepResources []StepResources `json:"stepResources,omitempty"`
+
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
@@ -196,6 +282,14 @@ type BuildSpec struct {
 	// SchedulerName specifies the scheduler to be used to dispatch the Pod
 	// +optional
 	SchedulerName *string `json:"schedulerName,omitempty"`
+
+	// Notifications is a list of HTTP endpoints that are notified about the
+	// lifecycle events of the BuildRuns of this Build
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Notifications []Notification `json:"notifications,omitempty"`
 }
 
 // BuildVolume is a volume that will be mounted in build pod during build step
@@ -209,6 +303,17 @@ type BuildVolume struct {
 	corev1.VolumeSource `json:",inline"`
 }
 
+// StepResources overrides the requests and limits of the resources of a build strategy step
+type StepResources struct {
+	// Name of the step of the build strategy
+	// +required
+	Name string `json:"name"`
+
+	// Resources whose requests and limits replace those of the step
+	// +required
+	Resources corev1.ResourceRequirements `json:"resources"`
+}
+
 // StrategyName returns the name of the configured strategy, or 'undefined' in
 // case the strategy is nil (not set)
 func (buildSpec *BuildSpec) StrategyName() string {
@@ -262,6 +367,22 @@ type VulnerabilityScanOptions struct {
 	Ignore *VulnerabilityIgnoreOptions `json:"ignore,omitempty"`
 }
 
+// ImageCache refers to a container image that is used as a layer cache with credentials
+type ImageCache struct {
+	// Image is the reference of the cache image.
+	Image string `json:"image"`
+
+	// Insecure defines whether the registry is not secure
+	//
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+
+	// Describes the secret name for pulling and pushing the cache image.
+	//
+	// +optional
+	PushSecret *string `json:"pushSecret,omitempty"`
+}
+
 // Image refers to an container image with credentials
 type Image struct {
 	// Image is the reference of the image.
@@ -396,6 +517,14 @@ func (b Build) GetSourceCredentials() *string {
 		if b.Spec.Source.OCIArtifact != nil && b.Spec.Source.OCIArtifact.PullSecret != nil {
 			return b.Spec.Source.OCIArtifact.PullSecret
 		}
+	case ArchiveType:
+		if b.Spec.Source.Archive != nil && b.Spec.Source.Archive.Secret != nil {
+			return b.Spec.Source.Archive.Secret
+		}
+	case LocalType:
+		if b.Spec.Source.Local != nil && b.Spec.Source.Local.UploadSecret != nil {
+			return b.Spec.Source.Local.UploadSecret
+		}
 	default:
 		if b.Spec.Source.Git != nil && b.Spec.Source.Git.CloneSecret != nil {
 			return b.Spec.Source.Git.CloneSecret
@@ -0,0 +1,86 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BuildQuotaSpec defines the limits for the BuildRuns of a namespace
+type BuildQuotaSpec struct {
+	// MaxConcurrentBuildRuns is the maximum number of BuildRuns of the namespace
+	// that run at the same time. Further BuildRuns wait until a running one
+	// completes.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentBuildRuns *int32 `json:"maxConcurrentBuildRuns,omitempty"`
+
+	// MaxResources is the maximum total of the resource requests, for example
+	// cpu and memory, of the BuildRuns of the namespace that run at the same
+	// time. Further BuildRuns wait until enough resources are released.
+	// +optional
+	MaxResources corev1.ResourceList `json:"maxResources,omitempty"`
+
+	// MaxTimeout is the maximum timeout of a BuildRun. BuildRuns without a
+	// timeout get the maximum timeout.
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	MaxTimeout *metav1.Duration `json:"maxTimeout,omitempty"`
+
+	// MaxRetainedBuildRuns is the maximum number of BuildRuns that are kept in
+	// the namespace. The oldest completed BuildRuns are deleted when a BuildRun
+	// starts and the maximum is exceeded.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRetainedBuildRuns *int32 `json:"maxRetainedBuildRuns,omitempty"`
+}
+
+// BuildQuotaStatus defines the current usage of the BuildRuns of a namespace
+type BuildQuotaStatus struct {
+	// ConcurrentBuildRuns is the number of BuildRuns that run
+	// +optional
+	ConcurrentBuildRuns int32 `json:"concurrentBuildRuns"`
+
+	// Resources is the total of the resource requests of the BuildRuns that run
+	// +optional
+	Resources corev1.ResourceList `json:"resources,omitempty"`
+
+	// RetainedBuildRuns is the number of BuildRuns in the namespace
+	// +optional
+	RetainedBuildRuns int32 `json:"retainedBuildRuns"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:path=buildquotas,scope=Namespaced,shortName=bq
+// +kubebuilder:printcolumn:name="Concurrent",type="integer",JSONPath=".status.concurrentBuildRuns",description="The number of BuildRuns that run"
+// +kubebuilder:printcolumn:name="Retained",type="integer",JSONPath=".status.retainedBuildRuns",description="The number of BuildRuns in the namespace"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BuildQuota limits the number, the resources, and the timeout of the BuildRuns of a namespace
+type BuildQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BuildQuotaSpec   `json:"spec"`
+	Status BuildQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// BuildQuotaList contains a list of BuildQuota
+type BuildQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BuildQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BuildQuota{}, &BuildQuotaList{})
+}
@@ -18,6 +18,10 @@ const (
 
 	// LabelBuildRunGeneration is a label key for BuildRuns to define the generation
 	LabelBuildRunGeneration = BuildRunDomain + "/generation"
+
+	// AnnotationBuildQuotaReservation is an annotation key for BuildRuns that holds the resource requests for which
+	// the BuildRun was admitted by the BuildQuotas of its namespace before its TaskRun is created
+	AnnotationBuildQuotaReservation = BuildRunDomain + "/build-quota-reservation"
 )
 
 // VulnerabilitySeverity is an enum for the possible values for severity of a vulnerability
@@ -91,6 +95,12 @@ type BuildRunSpec struct {
 	// +optional
 	Output *Image `json:"output,omitempty"`
 
+	// Cache refers to the location of a registry-backed layer cache. It will
+	// overwrite the cache in build spec
+	//
+	// +optional
+	Cache *ImageCache `json:"cache,omitempty"`
+
 	// State is used for canceling a buildrun (and maybe more later on).
 	//
 	// +optional
@@ -110,6 +120,11 @@ type BuildRunSpec struct {
 	// +optional
 	Volumes []BuildVolume `json:"volumes,omitempty"`
 
+	// StepResources contains resource overrides of the BuildStrategy steps, bounded by
+	// the maxResources of the steps. They replace the stepResources of the Build for the same step
+	// +optional
+	StepResources []StepResources `json:"stepResources,omitempty"`
+
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
@@ -155,6 +170,12 @@ const (
 // SourceResult holds the results emitted from the different sources
 type SourceResult struct {
 
+	// Name is the name of the additional source the results belong to,
+	// it is empty for the results of the source
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Git holds the results emitted from the
 	// source step of type git
 	//
@@ -167,6 +188,18 @@ type SourceResult struct {
 	// +optional
 	OciArtifact *OciArtifactSourceResult `json:"ociArtifact,omitempty"`
 
+	// Archive holds the results emitted from
+	// the source step of type archive
+	//
+	// +optional
+	Archive *ArchiveSourceResult `json:"archive,omitempty"`
+
+	// Local holds the results emitted from
+	// the source step of type local
+	//
+	// +optional
+	Local *LocalSourceResult `json:"local,omitempty"`
+
 	// Timestamp holds the timestamp of the source, which
 	// depends on the actual source type and could range from
 	// being the commit timestamp or the fileystem timestamp
@@ -182,6 +215,41 @@ type OciArtifactSourceResult struct {
 	Digest string `json:"digest,omitempty"`
 }
 
+// ArchiveSourceResult holds the results emitted from the archive source
+type ArchiveSourceResult struct {
+	// Digest holds the SHA-256 digest of the downloaded archive
+	Digest string `json:"digest,omitempty"`
+}
+
+// LocalSourceResult holds the results emitted from the local source
+type LocalSourceResult struct {
+	// UploadDigest holds the SHA-256 digest of the uploaded stream
+	//
+	// +optional
+	UploadDigest string `json:"uploadDigest,omitempty"`
+
+	// UploadSize holds the size of the uploaded stream in bytes
+	//
+	// +optional
+	UploadSize int64 `json:"uploadSize,omitempty"`
+
+	// Digest holds the SHA-256 digest of the content of the source directory,
+	// calculated from the paths and checksums of all files
+	//
+	// +optional
+	Digest string `json:"digest,omitempty"`
+
+	// FileCount holds the number of files in the source directory
+	//
+	// +optional
+	FileCount int64 `json:"fileCount,omitempty"`
+
+	// Size holds the total size of all files in the source directory in bytes
+	//
+	// +optional
+	Size int64 `json:"size,omitempty"`
+}
+
 // GitSourceResult holds the results emitted from the git source
 type GitSourceResult struct {
 	// CommitSha holds the commit sha of git source
@@ -228,6 +296,12 @@ type BuildRunStatus struct {
 	// +optional
 	Source *SourceResult `json:"source,omitempty"`
 
+	// AdditionalSources holds the results emitted from the steps
+	// of the additional sources
+	//
+	// +optional
+	AdditionalSources []SourceResult `json:"additionalSources,omitempty"`
+
 	// Output holds the results emitted from step definition of an output
 	//
 	// +optional
@@ -247,6 +321,11 @@ type BuildRunStatus struct {
 	// +optional
 	Executor *BuildExecutor `json:"executor,omitempty"`
 
+	// Strategy records the strategy that was used to execute this BuildRun
+	//
+	// +optional
+	Strategy *StrategyResult `json:"strategy,omitempty"`
+
 	// StartTime is the time the build is actually started.
 	// +optional
 	StartTime *metav1.Time `json:"startTime,omitempty"`
@@ -262,6 +341,128 @@ type BuildRunStatus struct {
 	// FailureDetails contains error details that are collected and surfaced from TaskRun
 	// +optional
 	FailureDetails *FailureDetails `json:"failureDetails,omitempty"`
+
+	// Steps contains the timing and termination status of the steps that
+	// were run for this BuildRun, in the order in which they were run
+	// +optional
+	Steps []StepStatus `json:"steps,omitempty"`
+
+	// Notifications records the delivery of the lifecycle events to the
+	// notifications of the BuildRun, with one entry per notification and event
+	// +optional
+	Notifications []NotificationDelivery `json:"notifications,omitempty"`
+
+	// CommitStatus records the last commit status that was reported for the
+	// Git source of the BuildRun
+	// +optional
+	CommitStatus *CommitStatusResult `json:"commitStatus,omitempty"`
+
+	// LogArchive describes where the logs of the steps were archived after
+	// the BuildRun completed
+	// +optional
+	LogArchive *LogArchiveResult `json:"logArchive,omitempty"`
+}
+
+// LogArchiveResult describes where the logs of the steps of a BuildRun were archived
+type LogArchiveResult struct {
+	// Image is the digest reference of the OCI artifact that contains the logs
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Directory is the directory in the log archive volume that contains the logs
+	// +optional
+	Directory string `json:"directory,omitempty"`
+
+	// Time when the logs were archived
+	Time metav1.Time `json:"time"`
+
+	// Error describes why the logs could not be archived
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Attempts is the number of times that the archival of the logs was attempted
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// CommitState is the state of a commit status
+type CommitState string
+
+const (
+	// CommitStatePending is reported while the BuildRun is running
+	CommitStatePending CommitState = "Pending"
+
+	// CommitStateSuccess is reported when the BuildRun succeeded
+	CommitStateSuccess CommitState = "Success"
+
+	// CommitStateFailure is reported when the BuildRun failed or was canceled
+	CommitStateFailure CommitState = "Failure"
+)
+
+// CommitStatusResult describes a commit status that was reported to the forge
+type CommitStatusResult struct {
+	// CommitSha is the commit that the status was reported for
+	CommitSha string `json:"commitSha"`
+
+	// State is the reported state
+	State CommitState `json:"state"`
+
+	// Time of the last report
+	Time metav1.Time `json:"time"`
+
+	// Error describes why the last report failed
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// Attempts is the number of times that the state was reported for the commit
+	// +optional
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// StepOrigin describes which part of the BuildRun a step belongs to
+type StepOrigin string
+
+const (
+	// StepOriginSource is the origin of the step that obtains the default source
+	StepOriginSource StepOrigin = "source-default"
+
+	// StepOriginAdditionalSource is the origin of the steps that obtain the additional sources
+	StepOriginAdditionalSource StepOrigin = "source-additional"
+
+	// StepOriginStrategy is the origin of steps that are defined in the build strategy
+	StepOriginStrategy StepOrigin = "strategy"
+
+	// StepOriginImageProcessing is the origin of the step that mutates and pushes the output image
+	StepOriginImageProcessing StepOrigin = "image-processing"
+)
+
+// StepStatus describes the timing and termination status of a step
+type StepStatus struct {
+	// Name is the name of the step
+	Name string `json:"name"`
+
+	// Origin describes which part of the BuildRun the step belongs to
+	Origin StepOrigin `json:"origin"`
+
+	// ImageID is the image including its digest that was run for the step
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+
+	// StartTime is the time the step started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the step terminated
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// ExitCode is the exit code of the terminated step
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Reason is the reason of the termination of the step
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // Location describes the location where the failure happened
@@ -339,6 +540,10 @@ const (
 	// Succeeded specifies that the resource has finished.
 	// For resources that run to completion.
 	Succeeded Type = "Succeeded"
+
+	// Ready specifies that the resource is valid and can be used.
+	// For resources that are referenced by other resources.
+	Ready Type = "Ready"
 )
 
 // Condition defines the required fields for populating
@@ -463,3 +668,25 @@ type BuildExecutor struct {
 	// Kind is the kind of the object that was created to execute the BuildRun (e.g., "TaskRun", "PipelineRun")
 	Kind string `json:"kind"`
 }
+
+// StrategyResult identifies the strategy that a BuildRun used
+type StrategyResult struct {
+	// Kind of the strategy
+	Kind BuildStrategyKind `json:"kind"`
+
+	// Name of the strategy
+	Name string `json:"name"`
+
+	// Generation of the strategy that was used
+	Generation int64 `json:"generation"`
+
+	// Version of the strategy, if the Build pins a version
+	//
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Revision is the name of the revision that was used, if the Build pins a version
+	//
+	// +optional
+	Revision string `json:"revision,omitempty"`
+}
@@ -6,6 +6,8 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -33,6 +35,23 @@ type BuildStrategySpec struct {
 	// Volumes defines the volumes of the strategy
 	// +optional
 	Volumes []BuildStrategyVolume `json:"volumes,omitempty"`
+
+	// Fragments references ClusterStrategyFragments whose steps, parameters, and
+	// volumes are merged into the strategy
+	// +optional
+	Fragments []StrategyFragmentReference `json:"fragments,omitempty"`
+
+	// NamespaceSelector restricts the namespaces whose Builds can use a
+	// ClusterBuildStrategy to those with matching labels. It is ignored for
+	// BuildStrategies.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Version of the strategy. For every version, an immutable revision of the
+	// strategy is created that Builds can pin. The version must be changed for
+	// every change that should reach the Builds which pin a version.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // ParameterType indicates the type of a parameter
@@ -40,8 +59,11 @@ type ParameterType string
 
 // Valid ParamTypes:
 const (
-	ParameterTypeString ParameterType = "string"
-	ParameterTypeArray  ParameterType = "array"
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeArray   ParameterType = "array"
+	ParameterTypeBoolean ParameterType = "boolean"
+	ParameterTypeInteger ParameterType = "integer"
+	ParameterTypeObject  ParameterType = "object"
 )
 
 // Parameter holds a name-description with a default value
@@ -58,18 +80,69 @@ type Parameter struct {
 	// +required
 	Description string `json:"description"`
 
-	// Type of the parameter. The possible types are "string" and "array",
-	// and "string" is the default.
+	// Type of the parameter. The possible types are "string", "array",
+	// "boolean", "integer" and "object", and "string" is the default. Boolean
+	// and integer parameters are passed to the steps as strings.
+	// +kubebuilder:validation:Enum=string;array;boolean;integer;object
 	// +optional
 	Type ParameterType `json:"type,omitempty"`
 
-	// Default value for a string parameter
+	// Default value for a string, boolean, or integer parameter
 	// +optional
 	Default *string `json:"default,omitempty"`
 
 	// Default values for an array parameter
 	// +optional
 	Defaults *[]string `json:"defaults"`
+
+	// Properties that an object parameter must provide. Steps can only
+	// reference the properties that are listed here.
+	// +optional
+	Properties []string `json:"properties,omitempty"`
+
+	// Default properties for an object parameter
+	// +optional
+	DefaultProperties *map[string]string `json:"defaultProperties,omitempty"`
+
+	// Required marks the parameter as required even if it has a default.
+	// A parameter without a default is always required.
+	// +optional
+	Required *bool `json:"required,omitempty"`
+
+	// Enum restricts the values of the parameter to the given list. For an
+	// array parameter, the restriction applies to every item.
+	// +optional
+	Enum []string `json:"enum,omitempty"`
+
+	// Pattern is a regular expression that values of a string parameter, or
+	// the items of an array parameter, must match.
+	// +optional
+	Pattern *string `json:"pattern,omitempty"`
+
+	// Minimum is the smallest value allowed for an integer parameter
+	// +optional
+	Minimum *int64 `json:"minimum,omitempty"`
+
+	// Maximum is the largest value allowed for an integer parameter
+	// +optional
+	Maximum *int64 `json:"maximum,omitempty"`
+}
+
+// IsRequired returns whether a value must be provided for the parameter
+// in the Build or BuildRun
+func (p *Parameter) IsRequired() bool {
+	if p.Required != nil && *p.Required {
+		return true
+	}
+
+	switch p.Type {
+	case ParameterTypeArray:
+		return p.Defaults == nil
+	case ParameterTypeObject:
+		return p.DefaultProperties == nil
+	}
+
+	return p.Default == nil
 }
 
 // BuildStrategySecurityContext defines a UID and GID for the build that is to be used for the build strategy steps as
@@ -108,6 +181,31 @@ type BuildStrategyVolume struct {
 	// Represents the source of a volume to mount
 	// +optional
 	corev1.VolumeSource `json:",inline"`
+
+	// Cache declares that the volume holds a cache that is retained between the BuildRuns
+	// of a Build. Shipwright provisions a PersistentVolumeClaim per Build that is mounted
+	// by one BuildRun at a time. Concurrent BuildRuns, and BuildRuns of embedded Builds,
+	// use the volume source instead, which should be an emptyDir.
+	// +optional
+	Cache *BuildStrategyVolumeCache `json:"cache,omitempty"`
+}
+
+// BuildStrategyVolumeCache defines the PersistentVolumeClaim that holds the cache of a volume
+type BuildStrategyVolumeCache struct {
+	// Size is the requested storage size of the PersistentVolumeClaim. Defaults to 1Gi
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// StorageClassName is the storage class of the PersistentVolumeClaim. The default
+	// storage class of the cluster is used if it is not set
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// TTL is the duration after which a cache that is not used by any BuildRun is deleted.
+	// Defaults to 168h
+	// +optional
+	// +kubebuilder:validation:Format=duration
+	TTL *metav1.Duration `json:"ttl,omitempty"`
 }
 
 // BuildStep defines a partial step that needs to run in container for building the image.
@@ -181,10 +279,122 @@ type Step struct {
 	// More info: https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
 	// +optional
 	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty" protobuf:"bytes,15,opt,name=securityContext"`
+	// When is a list of conditions on the parameter values of the Build and BuildRun. The
+	// step only runs if all conditions are met, otherwise it is removed from the build.
+	// +optional
+	When []WhenExpression `json:"when,omitempty"`
+	// MaxResources are the highest requests and limits that Builds and BuildRuns can set
+	// for the step in their stepResources. A step without maxResources keeps its resources.
+	// +optional
+	MaxResources corev1.ResourceList `json:"maxResources,omitempty"`
 }
 
+// WhenOperator is the operator of a WhenExpression
+type WhenOperator string
+
+// Valid WhenOperators:
+const (
+	WhenOperatorEquals    WhenOperator = "equals"
+	WhenOperatorNotEquals WhenOperator = "notEquals"
+	WhenOperatorIn        WhenOperator = "in"
+	WhenOperatorNotIn     WhenOperator = "notIn"
+)
+
+// WhenExpression is a condition on the value of a strategy parameter
+type WhenExpression struct {
+	// Parameter is the name of a string, boolean, or integer parameter of the strategy
+	// +required
+	Parameter string `json:"parameter"`
+
+	// Operator that compares the parameter value with the values
+	// +kubebuilder:validation:Enum=equals;notEquals;in;notIn
+	// +required
+	Operator WhenOperator `json:"operator"`
+
+	// Values to compare the parameter value with. The operators equals and notEquals
+	// take exactly one value.
+	// +required
+	Values []string `json:"values"`
+}
+
+// reasons of the Ready condition of build strategies
+const (
+	// BuildStrategyValid indicates that the strategy passed all validations
+	BuildStrategyValid = "Valid"
+
+	// BuildStrategyParameterNotValid indicates that a parameter is defined more than once,
+	// or uses a name that is reserved for system parameters
+	BuildStrategyParameterNotValid = "ParameterNotValid"
+
+	// BuildStrategyParameterReferenceNotValid indicates that a step references an undefined
+	// parameter, or uses an array parameter where a string is expected
+	BuildStrategyParameterReferenceNotValid = "ParameterReferenceNotValid"
+
+	// BuildStrategyVolumeMountNotValid indicates that a step mounts a volume that is not
+	// defined by the strategy
+	BuildStrategyVolumeMountNotValid = "VolumeMountNotValid"
+
+	// BuildStrategyStepNameNotValid indicates that a step name is used more than once, or
+	// collides with the name of a step that Shipwright adds
+	BuildStrategyStepNameNotValid = "StepNameNotValid"
+
+	// BuildStrategyVersionNotValid indicates that the version cannot be used in the name
+	// of a revision
+	BuildStrategyVersionNotValid = "VersionNotValid"
+
+	// BuildStrategyStepConditionNotValid indicates that a when expression of a step references
+	// a parameter that is not defined or not a scalar, or has values that do not fit its operator
+	BuildStrategyStepConditionNotValid = "StepConditionNotValid"
+
+	// BuildStrategyFragmentNotFound indicates that a referenced ClusterStrategyFragment does not exist
+	BuildStrategyFragmentNotFound = "FragmentNotFound"
+
+	// BuildStrategyFragmentConflict indicates that a ClusterStrategyFragment defines a step, parameter,
+	// or volume differently than the strategy or another of its fragments
+	BuildStrategyFragmentConflict = "FragmentConflict"
+)
+
 // BuildStrategyStatus defines the observed state of BuildStrategy
 type BuildStrategyStatus struct {
+	// ObservedGeneration is the generation of the strategy that the conditions refer to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions holds the latest available observations of the strategy, the Ready
+	// condition tells whether the strategy passed the validations
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+
+	// Revision is the name of the revision that was created for the version of the strategy
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// RevisionDigest is the digest of the snapshot of the revision, a deleted revision
+	// is only created again if the snapshot did not change
+	// +optional
+	RevisionDigest string `json:"revisionDigest,omitempty"`
+}
+
+// GetCondition returns a condition based on a type from a list of Conditions
+func (s *BuildStrategyStatus) GetCondition(t Type) *Condition {
+	for _, c := range s.Conditions {
+		if c.Type == t {
+			return &c
+		}
+	}
+	return nil
+}
+
+// SetCondition updates a list of conditions with the provided condition
+func (s *BuildStrategyStatus) SetCondition(condition *Condition) {
+	for i, c := range s.Conditions {
+		if c.Type == condition.Type {
+			s.Conditions[i] = *condition
+			return
+		}
+	}
+
+	s.Conditions = append(s.Conditions, *condition)
 }
 
 // BuildStrategyKind defines the type of BuildStrategy used by the build.
@@ -198,6 +408,11 @@ type Strategy struct {
 
 	// BuildStrategyKind indicates the kind of the buildstrategy, namespaced or cluster scoped.
 	Kind *BuildStrategyKind `json:"kind,omitempty"`
+
+	// Version pins the version of the strategy, the revision of the strategy with
+	// this version is used instead of the current strategy.
+	// +optional
+	Version *string `json:"version,omitempty"`
 }
 
 // BuilderStrategy defines the common elements of build strategies
@@ -210,4 +425,5 @@ type BuilderStrategy interface {
 	GetParameters() []Parameter
 	GetSecurityContext() *BuildStrategySecurityContext
 	GetVolumes() []BuildStrategyVolume
+	GetFragments() []StrategyFragmentReference
 }
@@ -55,10 +55,16 @@ func (src *BuildStrategySpec) ConvertTo(bs *v1alpha1.BuildStrategySpec) {
 			continue
 		}
 
+		// v1alpha1 knows no boolean and integer parameters, they are strings there
+		paramType := v1alpha1.ParameterType(param.Type)
+		if param.Type == ParameterTypeBoolean || param.Type == ParameterTypeInteger {
+			paramType = v1alpha1.ParameterTypeString
+		}
+
 		bs.Parameters = append(bs.Parameters, v1alpha1.Parameter{
 			Name:        param.Name,
 			Description: param.Description,
-			Type:        v1alpha1.ParameterType(param.Type),
+			Type:        paramType,
 			Default:     param.Default,
 			Defaults:    param.Defaults,
 		})
@@ -27,6 +27,9 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:resource:path=buildstrategies,scope=Namespaced,shortName=bs;bss
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the BuildStrategy is valid"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="The reason of the Ready condition of the BuildStrategy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // BuildStrategy is the Schema representing a strategy in the namespace scope to build images from source code.
 type BuildStrategy struct {
@@ -92,6 +95,11 @@ func (s BuildStrategy) GetVolumes() []BuildStrategyVolume {
 	return s.Spec.Volumes
 }
 
+// GetFragments returns the fragments referenced by the build strategy
+func (s BuildStrategy) GetFragments() []StrategyFragmentReference {
+	return s.Spec.Fragments
+}
+
 func init() {
 	SchemeBuilder.Register(&BuildStrategy{}, &BuildStrategyList{})
 }
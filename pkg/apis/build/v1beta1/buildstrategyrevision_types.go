@@ -0,0 +1,124 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelBuildStrategyVersion is a label key for defining the version of a strategy revision
+	LabelBuildStrategyVersion = BuildStrategyDomain + "/version"
+)
+
+// BuildStrategyRevisionSpec is an immutable snapshot of a version of a strategy
+type BuildStrategyRevisionSpec struct {
+	// Strategy is the name of the strategy
+	Strategy string `json:"strategy"`
+
+	// Version is the version of the strategy
+	Version string `json:"version"`
+
+	// Generation is the generation of the strategy that was captured
+	Generation int64 `json:"generation"`
+
+	// Snapshot is the spec of the strategy at the time the version was created
+	Snapshot BuildStrategySpec `json:"snapshot"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:path=buildstrategyrevisions,scope=Namespaced,shortName=bsr
+// +kubebuilder:printcolumn:name="Strategy",type="string",JSONPath=".spec.strategy",description="The name of the BuildStrategy"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="The version of the BuildStrategy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// BuildStrategyRevision is an immutable snapshot of a version of a BuildStrategy.
+type BuildStrategyRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the spec of a revision is immutable"
+	Spec BuildStrategyRevisionSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// BuildStrategyRevisionList contains a list of BuildStrategyRevision
+type BuildStrategyRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BuildStrategyRevision `json:"items"`
+}
+
+// RevisionName returns the name of the revision of a version of a strategy
+func RevisionName(strategyName string, version string) string {
+	return fmt.Sprintf("%s-%s", strategyName, version)
+}
+
+// IsRevisionOf returns whether this is the revision of the version of the strategy,
+// the name of a revision alone is ambiguous if names or versions contain dashes
+func (s BuildStrategyRevision) IsRevisionOf(strategyName string, version string) bool {
+	return s.Spec.Strategy == strategyName && s.Spec.Version == version
+}
+
+// GetAnnotations returns the annotations of the build strategy revision
+func (s BuildStrategyRevision) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetName returns the name of the build strategy revision
+func (s BuildStrategyRevision) GetName() string {
+	return s.Name
+}
+
+// GetGeneration returns the generation of the build strategy that was captured
+func (s BuildStrategyRevision) GetGeneration() int64 {
+	return s.Spec.Generation
+}
+
+// GetResourceLabels returns labels that define the build strategy name,
+// generation, and version to be used in labels map of a resource
+func (s BuildStrategyRevision) GetResourceLabels() map[string]string {
+	return map[string]string{
+		LabelBuildStrategyName:       s.Spec.Strategy,
+		LabelBuildStrategyGeneration: strconv.FormatInt(s.Spec.Generation, 10),
+		LabelBuildStrategyVersion:    s.Spec.Version,
+	}
+}
+
+// GetBuildSteps returns the spec build steps of the build strategy revision
+func (s BuildStrategyRevision) GetBuildSteps() []Step {
+	return s.Spec.Snapshot.Steps
+}
+
+// GetParameters returns the parameters defined by the build strategy revision
+func (s BuildStrategyRevision) GetParameters() []Parameter {
+	return s.Spec.Snapshot.Parameters
+}
+
+// GetSecurityContext returns the security context defined by the build strategy revision
+func (s BuildStrategyRevision) GetSecurityContext() *BuildStrategySecurityContext {
+	return s.Spec.Snapshot.SecurityContext
+}
+
+// GetVolumes returns the volumes defined by the build strategy revision
+func (s BuildStrategyRevision) GetVolumes() []BuildStrategyVolume {
+	return s.Spec.Snapshot.Volumes
+}
+
+// GetFragments returns the fragments referenced by the build strategy revision
+func (s BuildStrategyRevision) GetFragments() []StrategyFragmentReference {
+	return s.Spec.Snapshot.Fragments
+}
+
+func init() {
+	SchemeBuilder.Register(&BuildStrategyRevision{}, &BuildStrategyRevisionList{})
+}
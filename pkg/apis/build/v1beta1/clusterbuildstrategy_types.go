@@ -28,6 +28,9 @@ const (
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:resource:path=clusterbuildstrategies,scope=Cluster,shortName=cbs;cbss
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Whether the ClusterBuildStrategy is valid"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason",description="The reason of the Ready condition of the ClusterBuildStrategy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ClusterBuildStrategy is the Schema representing a strategy in the cluster scope to build images from source code.
 type ClusterBuildStrategy struct {
@@ -93,6 +96,11 @@ func (s ClusterBuildStrategy) GetVolumes() []BuildStrategyVolume {
 	return s.Spec.Volumes
 }
 
+// GetFragments returns the fragments referenced by the build strategy
+func (s ClusterBuildStrategy) GetFragments() []StrategyFragmentReference {
+	return s.Spec.Fragments
+}
+
 func init() {
 	SchemeBuilder.Register(&ClusterBuildStrategy{}, &ClusterBuildStrategyList{})
 }
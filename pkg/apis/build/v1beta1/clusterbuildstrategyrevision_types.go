@@ -0,0 +1,103 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelClusterBuildStrategyVersion is a label key for defining the version of a cluster strategy revision
+	LabelClusterBuildStrategyVersion = ClusterBuildStrategyDomain + "/version"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:path=clusterbuildstrategyrevisions,scope=Cluster,shortName=cbsr
+// +kubebuilder:printcolumn:name="Strategy",type="string",JSONPath=".spec.strategy",description="The name of the ClusterBuildStrategy"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="The version of the ClusterBuildStrategy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterBuildStrategyRevision is an immutable snapshot of a version of a ClusterBuildStrategy.
+type ClusterBuildStrategyRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the spec of a revision is immutable"
+	Spec BuildStrategyRevisionSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// ClusterBuildStrategyRevisionList contains a list of ClusterBuildStrategyRevision
+type ClusterBuildStrategyRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBuildStrategyRevision `json:"items"`
+}
+
+// IsRevisionOf returns whether this is the revision of the version of the strategy,
+// the name of a revision alone is ambiguous if names or versions contain dashes
+func (s ClusterBuildStrategyRevision) IsRevisionOf(strategyName string, version string) bool {
+	return s.Spec.Strategy == strategyName && s.Spec.Version == version
+}
+
+// GetAnnotations returns the annotations of the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetName returns the name of the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetName() string {
+	return s.Name
+}
+
+// GetGeneration returns the generation of the cluster build strategy that was captured
+func (s ClusterBuildStrategyRevision) GetGeneration() int64 {
+	return s.Spec.Generation
+}
+
+// GetResourceLabels returns labels that define the cluster build strategy name,
+// generation, and version to be used in labels map of a resource
+func (s ClusterBuildStrategyRevision) GetResourceLabels() map[string]string {
+	return map[string]string{
+		LabelClusterBuildStrategyName:       s.Spec.Strategy,
+		LabelClusterBuildStrategyGeneration: strconv.FormatInt(s.Spec.Generation, 10),
+		LabelClusterBuildStrategyVersion:    s.Spec.Version,
+	}
+}
+
+// GetBuildSteps returns the spec build steps of the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetBuildSteps() []Step {
+	return s.Spec.Snapshot.Steps
+}
+
+// GetParameters returns the parameters defined by the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetParameters() []Parameter {
+	return s.Spec.Snapshot.Parameters
+}
+
+// GetSecurityContext returns the security context defined by the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetSecurityContext() *BuildStrategySecurityContext {
+	return s.Spec.Snapshot.SecurityContext
+}
+
+// GetVolumes returns the volumes defined by the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetVolumes() []BuildStrategyVolume {
+	return s.Spec.Snapshot.Volumes
+}
+
+// GetFragments returns the fragments referenced by the cluster build strategy revision
+func (s ClusterBuildStrategyRevision) GetFragments() []StrategyFragmentReference {
+	return s.Spec.Snapshot.Fragments
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBuildStrategyRevision{}, &ClusterBuildStrategyRevisionList{})
+}
@@ -0,0 +1,74 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FragmentPosition defines where the steps of a fragment are placed relative to the steps of the strategy
+type FragmentPosition string
+
+// Valid FragmentPositions:
+const (
+	FragmentPositionBefore FragmentPosition = "before"
+	FragmentPositionAfter  FragmentPosition = "after"
+)
+
+// StrategyFragmentSpec defines the steps, parameters, and volumes that a fragment adds to the strategies that use it
+type StrategyFragmentSpec struct {
+	// Steps that the fragment adds to the strategy
+	// +optional
+	Steps []Step `json:"steps,omitempty"`
+
+	// Parameters that the fragment adds to the strategy
+	// +optional
+	Parameters []Parameter `json:"parameters,omitempty"`
+
+	// Volumes that the fragment adds to the strategy
+	// +optional
+	Volumes []BuildStrategyVolume `json:"volumes,omitempty"`
+}
+
+// StrategyFragmentReference references a ClusterStrategyFragment that is merged into a strategy
+type StrategyFragmentReference struct {
+	// Name of the ClusterStrategyFragment
+	// +required
+	Name string `json:"name"`
+
+	// Position of the steps of the fragment, "before" or "after" the steps of
+	// the strategy. The default is "before".
+	// +kubebuilder:validation:Enum=before;after
+	// +optional
+	Position FragmentPosition `json:"position,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:resource:path=clusterstrategyfragments,scope=Cluster,shortName=csf
+
+// ClusterStrategyFragment holds steps, parameters, and volumes that BuildStrategies and
+// ClusterBuildStrategies share.
+type ClusterStrategyFragment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StrategyFragmentSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+
+// ClusterStrategyFragmentList contains a list of ClusterStrategyFragment
+type ClusterStrategyFragmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterStrategyFragment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterStrategyFragment{}, &ClusterStrategyFragmentList{})
+}
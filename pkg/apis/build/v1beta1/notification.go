@@ -0,0 +1,103 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationEvent is a lifecycle event of a BuildRun that can be notified
+// +kubebuilder:validation:Enum=Started;Succeeded;Failed;Canceled
+type NotificationEvent string
+
+const (
+	// NotificationEventStarted is sent when the TaskRun of a BuildRun started
+	NotificationEventStarted NotificationEvent = "Started"
+
+	// NotificationEventSucceeded is sent when a BuildRun completed successfully
+	NotificationEventSucceeded NotificationEvent = "Succeeded"
+
+	// NotificationEventFailed is sent when a BuildRun failed
+	NotificationEventFailed NotificationEvent = "Failed"
+
+	// NotificationEventCanceled is sent when a BuildRun was canceled
+	NotificationEventCanceled NotificationEvent = "Canceled"
+)
+
+// Notification describes an HTTP endpoint that receives the lifecycle events
+// of the BuildRuns of a Build as CloudEvents
+type Notification struct {
+	// Name of the notification, used to report failed deliveries
+	Name string `json:"name"`
+
+	// URL of the endpoint that the events are posted to, must be HTTP or HTTPS
+	URL string `json:"url"`
+
+	// Events is the list of events that are sent, all events are sent if empty
+	//
+	// +optional
+	Events []NotificationEvent `json:"events,omitempty"`
+
+	// Secret references a Secret with a `hmacKey` key. The payload of every event is
+	// signed with the key, and the signature is sent in the X-Shipwright-Signature header.
+	//
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+}
+
+// Subscribed returns whether the notification is sent for the given event
+func (n Notification) Subscribed(event NotificationEvent) bool {
+	if len(n.Events) == 0 {
+		return true
+	}
+
+	for _, candidate := range n.Events {
+		if candidate == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NotificationDeliveryState is the state of the delivery of an event to a notification
+// +kubebuilder:validation:Enum=Pending;Delivered;Failed
+type NotificationDeliveryState string
+
+const (
+	// NotificationDeliveryPending is the state of an event that is not yet delivered, but will be attempted (again)
+	NotificationDeliveryPending NotificationDeliveryState = "Pending"
+
+	// NotificationDeliveryDelivered is the state of an event that was delivered
+	NotificationDeliveryDelivered NotificationDeliveryState = "Delivered"
+
+	// NotificationDeliveryFailed is the state of an event that could not be delivered after all attempts
+	NotificationDeliveryFailed NotificationDeliveryState = "Failed"
+)
+
+// NotificationDelivery records the delivery of an event to a notification
+type NotificationDelivery struct {
+	// Name of the notification
+	Name string `json:"name"`
+
+	// Event that is delivered
+	Event NotificationEvent `json:"event"`
+
+	// State of the delivery
+	State NotificationDeliveryState `json:"state"`
+
+	// Attempts is the number of delivery attempts
+	Attempts int `json:"attempts"`
+
+	// Time of the last delivery attempt
+	//
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Message describes why the last delivery attempt failed
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
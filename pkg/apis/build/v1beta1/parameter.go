@@ -51,4 +51,8 @@ type ParamValue struct {
 	// Values of an array parameter
 	// +optional
 	Values []SingleValue `json:"values,omitempty"`
+
+	// Properties of an object parameter, the keys are the property names
+	// +optional
+	Properties map[string]SingleValue `json:"properties,omitempty"`
 }
@@ -23,6 +23,23 @@ const GitType BuildSourceType = "Git"
 // OCIArtifactType represents a build whose source code is in a "scratch" container image, also known as an OCI artifact.
 const OCIArtifactType BuildSourceType = "OCI"
 
+// ArchiveType represents a build whose source code is an archive (tar.gz, tar, or zip) that is
+// downloaded from a HTTP(S) URL.
+const ArchiveType BuildSourceType = "Archive"
+
+// LocalUploadMethod defines how local source code is streamed into the build
+type LocalUploadMethod string
+
+const (
+	// LocalUploadMethodExec is the default method where the client copies the source code into
+	// the waiter container of the build POD using an exec session
+	LocalUploadMethodExec LocalUploadMethod = "Exec"
+
+	// LocalUploadMethodHTTP is the method where the waiter container of the build POD runs an
+	// authenticated upload endpoint that accepts the source code as tar stream
+	LocalUploadMethodHTTP LocalUploadMethod = "HTTP"
+)
+
 const (
 	// Do not delete image after it was pulled
 	PruneNever PruneOption = "Never"
@@ -42,6 +59,25 @@ type Local struct {
 
 	// Name of the local step
 	Name string `json:"name,omitempty"`
+
+	// Method defines how the source code is streamed in. Allowed values are `Exec` and `HTTP`.
+	// If not defined, it defaults to `Exec`.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Exec;HTTP
+	Method *LocalUploadMethod `json:"method,omitempty"`
+
+	// UploadSecret references a Secret with a `token` key. The client must present the token
+	// as bearer token to the upload endpoint. Required for the `HTTP` method.
+	//
+	// +optional
+	UploadSecret *string `json:"uploadSecret,omitempty"`
+}
+
+// UsesUploadEndpoint returns whether the source code is streamed in using the upload
+// endpoint of the waiter container
+func (l *Local) UsesUploadEndpoint() bool {
+	return l != nil && l.Method != nil && *l.Method == LocalUploadMethodHTTP
 }
 
 // Git describes how to obtain source code from a git repository.
@@ -70,6 +106,53 @@ type Git struct {
 	//
 	// +optional
 	Depth *int `json:"depth,omitempty"`
+
+	// CommitStatus configures the reporting of the state of the BuildRuns as
+	// commit status of the built commit to the forge that hosts the repository.
+	//
+	// +optional
+	CommitStatus *GitCommitStatus `json:"commitStatus,omitempty"`
+}
+
+// GitForge is the type of the server that hosts a Git repository
+type GitForge string
+
+const (
+	// GitForgeGitHub is GitHub or GitHub Enterprise
+	GitForgeGitHub GitForge = "GitHub"
+
+	// GitForgeGitLab is GitLab
+	GitForgeGitLab GitForge = "GitLab"
+
+	// GitForgeGitea is Gitea or Forgejo
+	GitForgeGitea GitForge = "Gitea"
+)
+
+// GitCommitStatus describes how the state of a BuildRun is reported as commit status.
+type GitCommitStatus struct {
+	// Secret references a Secret with a `token` key that is used to authenticate
+	// against the API of the forge.
+	Secret string `json:"secret"`
+
+	// Forge is the type of the server that hosts the repository. Allowed values are
+	// `GitHub`, `GitLab`, and `Gitea`. If not defined, it is detected from the URL.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=GitHub;GitLab;Gitea
+	Forge *GitForge `json:"forge,omitempty"`
+
+	// Context is the name under which the status is shown on the forge. If not
+	// defined, it defaults to `shipwright/<build name>`.
+	//
+	// +optional
+	Context *string `json:"context,omitempty"`
+
+	// TargetURL is the link that is shown with the status, for example a dashboard
+	// that shows the BuildRun. The placeholders `$(namespace)` and `$(buildrun)` are
+	// replaced with the namespace and the name of the BuildRun.
+	//
+	// +optional
+	TargetURL *string `json:"targetURL,omitempty"`
 }
 
 // OCIArtifact describes how to obtain source code from a container image, also known as an OCI
@@ -95,10 +178,32 @@ type OCIArtifact struct {
 	PullSecret *string `json:"pullSecret,omitempty"`
 }
 
+// Archive describes how to obtain source code from an archive that is downloaded from a HTTP(S)
+// server.
+type Archive struct {
+	// URL describes the HTTP(S) URL of the archive. Supported archive formats are gzip compressed
+	// tar, plain tar, and zip, which are detected based on the downloaded content.
+	URL string `json:"url"`
+
+	// SHA256 is the expected hex-encoded SHA-256 checksum of the archive. If defined, the
+	// downloaded archive is verified against it before it is extracted.
+	//
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-fA-F0-9]{64}$`
+	SHA256 *string `json:"sha256,omitempty"`
+
+	// Secret references a Secret that contains credentials to download the archive. Supported
+	// are `username` and `password` keys for basic authentication, or a `token` key for bearer
+	// token authentication.
+	//
+	// +optional
+	Secret *string `json:"secret,omitempty"`
+}
+
 // Source describes the source code to fetch for the build.
 type Source struct {
 	// Type is the type of source code used as input for the build. Allowed values are
-	// `Git`, `OCI`, and `Local`.
+	// `Git`, `OCI`, `Archive`, and `Local`.
 	Type BuildSourceType `json:"type"`
 
 	// ContextDir is a path to a subdirectory within the source code that should be used as the
@@ -123,6 +228,79 @@ type Source struct {
 	//
 	// +optional
 	Local *Local `json:"local,omitempty"`
+
+	// Archive contains the details for obtaining source code from an archive that is downloaded
+	// from a HTTP(S) server.
+	//
+	// +optional
+	Archive *Archive `json:"archive,omitempty"`
+}
+
+// AdditionalSource describes a source that is fetched in addition to the main source of the
+// build. Its content is placed in a subdirectory of the source directory.
+type AdditionalSource struct {
+	// Name is the name of the source, it must be unique within the Build. The results of the
+	// source are reported under this name.
+	//
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+
+	// Type is the type of the source. Allowed values are `Git`, `OCI`, and `Archive`.
+	Type BuildSourceType `json:"type"`
+
+	// TargetDir is the path of the subdirectory relative to the source directory where the
+	// content of the source is placed. If not defined, it defaults to the name of the source.
+	//
+	// +optional
+	TargetDir *string `json:"targetDir,omitempty"`
+
+	// OCIArtifact contains the details for obtaining source code from a container image, also
+	// known as an OCI artifact.
+	//
+	// +optional
+	OCIArtifact *OCIArtifact `json:"ociArtifact,omitempty"`
+
+	// Git contains the details for obtaining source code from a git repository.
+	//
+	// +optional
+	Git *Git `json:"git,omitempty"`
+
+	// Archive contains the details for obtaining source code from an archive that is downloaded
+	// from a HTTP(S) server.
+	//
+	// +optional
+	Archive *Archive `json:"archive,omitempty"`
+}
+
+// GetTargetDir returns the subdirectory of the source directory where the
+// content of the additional source is placed
+func (s AdditionalSource) GetTargetDir() string {
+	if s.TargetDir != nil && *s.TargetDir != "" {
+		return *s.TargetDir
+	}
+
+	return s.Name
+}
+
+// GetCredentials returns the secret name for the additional source
+func (s AdditionalSource) GetCredentials() *string {
+	switch s.Type {
+	case OCIArtifactType:
+		if s.OCIArtifact != nil {
+			return s.OCIArtifact.PullSecret
+		}
+	case ArchiveType:
+		if s.Archive != nil {
+			return s.Archive.Secret
+		}
+	case GitType:
+		if s.Git != nil {
+			return s.Git.CloneSecret
+		}
+	}
+
+	return nil
 }
 
 // BuildRunSource describes the source to use in a BuildRun, overriding the value of the parent
@@ -0,0 +1,142 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte{'P', 'K', 0x03, 0x04}
+)
+
+// UnpackArchive extracts the archive file at the given path into the target
+// directory. Supported formats are gzip compressed tar, plain tar, and zip,
+// which are detected based on the file content. The same path validations as
+// in Unpack apply to all formats.
+func UnpackArchive(path string, targetPath string) (*UnpackDetails, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(len(zipMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		defer gzipReader.Close()
+
+		return Unpack(gzipReader, targetPath)
+
+	case bytes.HasPrefix(magic, zipMagic):
+		info, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+
+		return UnpackZip(file, info.Size(), targetPath)
+
+	default:
+		return Unpack(reader, targetPath)
+	}
+}
+
+// UnpackZip reads a zip archive and writes the content into the local file
+// system with all files and directories.
+func UnpackZip(in io.ReaderAt, size int64, targetPath string) (*UnpackDetails, error) {
+	zipReader, err := zip.NewReader(in, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the target path exists and is a directory
+	if stat, err := os.Stat(targetPath); err != nil {
+		if err := os.MkdirAll(targetPath, os.FileMode(0755)); err != nil {
+			return nil, err
+		}
+	} else if !stat.IsDir() {
+		return nil, fmt.Errorf("target %q exists, but it's not a directory", targetPath)
+	}
+
+	var details = UnpackDetails{}
+	for _, entry := range zipReader.File {
+		target, err := targetFor(targetPath, entry.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		mode := entry.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, os.FileMode(0755)); err != nil {
+				return nil, err
+			}
+
+		case mode.IsRegular():
+			if err := unzipFile(entry, target); err != nil {
+				return nil, err
+			}
+
+			modTime := entry.Modified
+			if details.MostRecentFileTimestamp == nil || details.MostRecentFileTimestamp.Before(modTime) {
+				details.MostRecentFileTimestamp = &modTime
+			}
+
+		default:
+			return nil, fmt.Errorf("provided archive contains unsupported file type, only directories and regular files are supported")
+		}
+	}
+
+	return &details, nil
+}
+
+func unzipFile(entry *zip.File, target string) error {
+	// Edge case in which that archive did not have a directory entry
+	if err := os.MkdirAll(filepath.Dir(target), os.FileMode(0755)); err != nil {
+		return err
+	}
+
+	src, err := entry.Open()
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_RDWR|os.O_TRUNC, entry.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, src); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Chtimes(target, entry.Modified, entry.Modified)
+}
@@ -0,0 +1,130 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/shipwright-io/build/pkg/bundle"
+)
+
+var _ = Describe("Archive", func() {
+	withTempDir := func(f func(tempDir string)) {
+		tempDir, err := os.MkdirTemp("", "archive")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tempDir)
+		f(tempDir)
+	}
+
+	writeZip := func(path string, files map[string]string) {
+		file, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+
+		zw := zip.NewWriter(file)
+		for name, content := range files {
+			w, err := zw.Create(name)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = w.Write([]byte(content))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(zw.Close()).To(Succeed())
+	}
+
+	writeTarGz := func(path string, files map[string]string) {
+		file, err := os.Create(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+
+		gw := gzip.NewWriter(file)
+		tw := tar.NewWriter(gw)
+		for name, content := range files {
+			Expect(tw.WriteHeader(&tar.Header{
+				Name:     name,
+				Typeflag: tar.TypeReg,
+				Mode:     0644,
+				Size:     int64(len(content)),
+			})).To(Succeed())
+			_, err := io.WriteString(tw, content)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		Expect(tw.Close()).To(Succeed())
+		Expect(gw.Close()).To(Succeed())
+	}
+
+	Context("unpacking archives", func() {
+		It("should unpack a zip archive", func() {
+			withTempDir(func(tempDir string) {
+				archive := filepath.Join(tempDir, "source.zip")
+				writeZip(archive, map[string]string{
+					"README.md":       "# readme",
+					"src/main/app.go": "package main",
+				})
+
+				target := filepath.Join(tempDir, "target")
+				details, err := UnpackArchive(archive, target)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(details).ToNot(BeNil())
+				Expect(details.MostRecentFileTimestamp).ToNot(BeNil())
+
+				Expect(filepath.Join(target, "README.md")).To(BeAnExistingFile())
+				Expect(filepath.Join(target, "src", "main", "app.go")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should unpack a gzip compressed tar archive", func() {
+			withTempDir(func(tempDir string) {
+				archive := filepath.Join(tempDir, "source.tar.gz")
+				writeTarGz(archive, map[string]string{
+					"README.md":       "# readme",
+					"src/main/app.go": "package main",
+				})
+
+				target := filepath.Join(tempDir, "target")
+				_, err := UnpackArchive(archive, target)
+				Expect(err).ToNot(HaveOccurred())
+
+				data, err := os.ReadFile(filepath.Join(target, "src", "main", "app.go"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(data)).To(Equal("package main"))
+			})
+		})
+
+		It("should refuse to unpack zip entries that point outside of the target directory", func() {
+			withTempDir(func(tempDir string) {
+				archive := filepath.Join(tempDir, "evil.zip")
+				writeZip(archive, map[string]string{
+					"../../evil": "boom",
+				})
+
+				_, err := UnpackArchive(archive, filepath.Join(tempDir, "target"))
+				Expect(err).To(HaveOccurred())
+				Expect(filepath.Join(tempDir, "evil")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		It("should refuse to unpack tar entries that point outside of the target directory", func() {
+			withTempDir(func(tempDir string) {
+				archive := filepath.Join(tempDir, "evil.tar.gz")
+				writeTarGz(archive, map[string]string{
+					"../evil": "boom",
+				})
+
+				_, err := UnpackArchive(archive, filepath.Join(tempDir, "target"))
+				Expect(err).To(HaveOccurred())
+				Expect(filepath.Join(tempDir, "evil")).ToNot(BeAnExistingFile())
+			})
+		})
+	})
+})
@@ -17,56 +17,129 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/google/go-containerregistry/pkg/compression"
 	"github.com/google/go-containerregistry/pkg/name"
 	containerreg "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 )
 
 const shpIgnoreFilename = ".shpignore"
 
+// normalisedModTime is the modification time of all entries of a reproducible bundle
+var normalisedModTime = time.Unix(0, 0)
+
 // UnpackDetails contains details about the files that were unpacked
 type UnpackDetails struct {
 	MostRecentFileTimestamp *time.Time
 }
 
+// PackOptions contains the settings how a directory is packed into a bundle
+// image. The zero value creates a gzip compressed bundle with the file
+// metadata as found on disk.
+type PackOptions struct {
+	// Compression is the compression used for the bundle layer. Supported are
+	// gzip (default) and zstd.
+	Compression compression.Compression
+
+	// Reproducible normalises the file timestamps and the ownership of all
+	// entries, so that identical directories result in identical bundle
+	// digests regardless of when and by whom they were checked out. As the
+	// bundle does not contain the original file timestamps anymore, no source
+	// timestamp is reported when such a bundle is unpacked.
+	Reproducible bool
+
+	// SkipExisting skips the upload of the bundle in case the image reference
+	// already points to an image with the same digest in the registry.
+	SkipExisting bool
+
+	// LayerSplit defines how the content is split into multiple layers. Layers
+	// that are already present in the registry are not uploaded again, which
+	// works best in combination with reproducible bundles.
+	LayerSplit LayerSplit
+}
+
 // PackAndPush a local directory as-is into a container image. See
 // remote.Option for optional options to the image push to the registry, for
 // example to provide the appropriate access credentials.
 func PackAndPush(ref name.Reference, directory string, options ...remote.Option) (name.Digest, error) {
-	bundleLayer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) { return Pack(directory) })
+	return PackAndPushWithOptions(ref, directory, PackOptions{}, options...)
+}
+
+// PackAndPushWithOptions packs a local directory into a container image using
+// the provided pack options and pushes it to the registry. See remote.Option
+// for optional options to the image push to the registry.
+func PackAndPushWithOptions(ref name.Reference, directory string, packOptions PackOptions, options ...remote.Option) (name.Digest, error) {
+	layerOptions, err := layerOptionsFor(packOptions)
 	if err != nil {
 		return name.Digest{}, err
 	}
 
-	image, err := mutate.Time(empty.Image, time.Unix(0, 0))
+	bundleLayers, err := bundleLayers(directory, packOptions, layerOptions)
 	if err != nil {
 		return name.Digest{}, err
 	}
 
-	image, err = mutate.AppendLayers(image, bundleLayer)
+	image, err := mutate.Time(empty.Image, time.Unix(0, 0))
 	if err != nil {
 		return name.Digest{}, err
 	}
 
-	hash, err := image.Digest()
+	image, err = mutate.AppendLayers(image, bundleLayers...)
 	if err != nil {
 		return name.Digest{}, err
 	}
 
-	if err := remote.Write(ref, image, options...); err != nil {
+	hash, err := image.Digest()
+	if err != nil {
 		return name.Digest{}, err
 	}
 
-	return name.NewDigest(fmt.Sprintf("%s@%v",
+	digest, err := name.NewDigest(fmt.Sprintf("%s@%v",
 		ref.Name(),
 		hash.String(),
 	))
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	if packOptions.SkipExisting {
+		// errors are ignored on purpose, the image is pushed in case the
+		// existing image cannot be determined, e.g. if it does not exist yet
+		if desc, err := remote.Head(ref, options...); err == nil && desc.Digest == hash {
+			return digest, nil
+		}
+	}
+
+	if err := remote.Write(ref, image, options...); err != nil {
+		return name.Digest{}, err
+	}
+
+	return digest, nil
+}
+
+// layerOptionsFor returns the options to create the bundle layer with the
+// configured compression
+func layerOptionsFor(packOptions PackOptions) ([]tarball.LayerOption, error) {
+	switch packOptions.Compression {
+	case "", compression.GZip:
+		return nil, nil
+
+	case compression.ZStd:
+		return []tarball.LayerOption{
+			tarball.WithCompression(compression.ZStd),
+			tarball.WithMediaType(types.OCILayerZStd),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported compression %q, supported are %q and %q", packOptions.Compression, compression.GZip, compression.ZStd)
+	}
 }
 
-// PullAndUnpack a container image layer content into a local directory. Analog
+// PullAndUnpack a container image layers content into a local directory. Analog
 // to the bundle.PackAndPush function, optional remote.Option can be used to
 // configure settings for the image pull, i.e. access credentials.
 func PullAndUnpack(ref name.Reference, targetPath string, options ...remote.Option) (containerreg.Image, error) {
@@ -95,8 +168,21 @@ func PullAndUnpack(ref name.Reference, targetPath string, options ...remote.Opti
 // - dereferencing all symlinks and storing the respective target,
 // - ignoring all files configured in .shpignore
 func Pack(directory string) (io.ReadCloser, error) {
-	var split = func(path string) []string { return strings.Split(path, string(filepath.Separator)) }
+	return PackWithOptions(directory, PackOptions{})
+}
+
+// PackWithOptions reads a directory and creates a tar stream with its content
+// like Pack does. In case reproducible output is requested, the entries are
+// written with normalised timestamps and ownership.
+func PackWithOptions(directory string, packOptions PackOptions) (io.ReadCloser, error) {
+	return pack(directory, packOptions, "", nil)
+}
 
+// pack creates a tar stream with the entries of the directory that belong to
+// the layer of the given layer path. An empty layer path refers to the common
+// layer, which contains all entries that are not covered by any of the layer
+// paths.
+func pack(directory string, packOptions PackOptions, layerPath string, layerPaths []string) (io.ReadCloser, error) {
 	var write = func(w io.Writer, path string) error {
 		file, err := os.Open(path)
 		if err != nil {
@@ -126,106 +212,149 @@ func Pack(directory string) (io.ReadCloser, error) {
 		return deref, info, err
 	}
 
-	var patterns []gitignore.Pattern
-	if file, err := os.Open(filepath.Join(directory, shpIgnoreFilename)); err == nil {
-		defer file.Close()
-
-		domain := split(directory)
-		scanner := bufio.NewScanner(file)
-		for scanner.Scan() {
-			line := strings.TrimSpace(scanner.Text())
-			if len(line) != 0 && !strings.HasPrefix(line, "#") {
-				patterns = append(patterns, gitignore.ParsePattern(line, domain))
-			}
-		}
-
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
-	}
-
-	matcher := gitignore.NewMatcher(patterns)
-
-	r, w, err := os.Pipe()
+	matcher, err := ignoreMatcher(directory)
 	if err != nil {
 		return nil, err
 	}
 
-	var tw = tar.NewWriter(w)
-	defer func() {
-		_ = tw.Close()
-		_ = w.Close()
-	}()
+	r, w := io.Pipe()
 
-	err = filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
-		// Bail out on path errors
-		if err != nil {
-			return err
-		}
+	// the tar stream is written concurrently, so that the reader can consume
+	// it while the directory is walked
+	go func() {
+		var tw = tar.NewWriter(w)
 
-		// Skip files on the ignore list
-		if matcher.Match(split(path), d.IsDir()) {
-			if d.IsDir() {
-				return filepath.SkipDir
+		err := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+			// Bail out on path errors
+			if err != nil {
+				return err
 			}
 
-			return nil
-		}
-
-		info, err := d.Info()
-		if err != nil {
-			return err
-		}
-
-		header, err := tar.FileInfoHeader(info, path)
-		if err != nil {
-			return err
-		}
-
-		header.Name, err = filepath.Rel(directory, path)
-		if err != nil {
-			return err
-		}
+			// Skip files on the ignore list
+			if matcher.Match(split(path), d.IsDir()) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
 
-		switch {
-		case info.Mode().IsDir():
-			return tw.WriteHeader(header)
+				return nil
+			}
 
-		case info.Mode().IsRegular():
-			if err := tw.WriteHeader(header); err != nil {
+			name, err := filepath.Rel(directory, path)
+			if err != nil {
 				return err
 			}
 
-			return write(tw, path)
+			// Skip entries that belong to another layer, but walk into directories
+			// that contain the layer path
+			if layerFor(filepath.ToSlash(name), layerPaths) != layerPath {
+				if d.IsDir() && !containsLayer(filepath.ToSlash(name), layerPath) {
+					return filepath.SkipDir
+				}
 
-		case info.Mode()&os.ModeSymlink == os.ModeSymlink:
-			deref, info, err := followSymLink(path)
-			if err != nil {
-				return err
+				return nil
 			}
 
-			header, err = tar.FileInfoHeader(info, deref)
+			info, err := d.Info()
 			if err != nil {
 				return err
 			}
 
-			header.Name, err = filepath.Rel(directory, path)
+			header, err := tar.FileInfoHeader(info, path)
 			if err != nil {
 				return err
 			}
 
-			if err := tw.WriteHeader(header); err != nil {
-				return err
+			header.Name = name
+
+			if packOptions.Reproducible {
+				normalise(header)
 			}
 
-			return write(tw, deref)
+			switch {
+			case info.Mode().IsDir():
+				return tw.WriteHeader(header)
 
-		default:
-			return fmt.Errorf("unsupported file type: %s", path)
+			case info.Mode().IsRegular():
+				if err := tw.WriteHeader(header); err != nil {
+					return err
+				}
+
+				return write(tw, path)
+
+			case info.Mode()&os.ModeSymlink == os.ModeSymlink:
+				deref, info, err := followSymLink(path)
+				if err != nil {
+					return err
+				}
+
+				header, err = tar.FileInfoHeader(info, deref)
+				if err != nil {
+					return err
+				}
+
+				header.Name = name
+
+				if packOptions.Reproducible {
+					normalise(header)
+				}
+
+				if err := tw.WriteHeader(header); err != nil {
+					return err
+				}
+
+				return write(tw, deref)
+
+			default:
+				return fmt.Errorf("unsupported file type: %s", path)
+			}
+		})
+
+		if err == nil {
+			err = tw.Close()
+		}
+
+		_ = w.CloseWithError(err)
+	}()
+
+	return r, nil
+}
+
+// ignoreMatcher returns the matcher for the files configured in .shpignore
+func ignoreMatcher(directory string) (gitignore.Matcher, error) {
+	var patterns []gitignore.Pattern
+	if file, err := os.Open(filepath.Join(directory, shpIgnoreFilename)); err == nil {
+		defer file.Close()
+
+		domain := split(directory)
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) != 0 && !strings.HasPrefix(line, "#") {
+				patterns = append(patterns, gitignore.ParsePattern(line, domain))
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
 		}
-	})
+	}
+
+	return gitignore.NewMatcher(patterns), nil
+}
+
+func split(path string) []string {
+	return strings.Split(path, string(filepath.Separator))
+}
 
-	return r, err
+// normalise removes all metadata from a tar header that depends on the time
+// or the user that created the file. The entries are already written in
+// lexical order by the directory walk.
+func normalise(header *tar.Header) {
+	header.ModTime = normalisedModTime
+	header.AccessTime = time.Time{}
+	header.ChangeTime = time.Time{}
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
 }
 
 // Unpack reads a tar stream and writes the content into the local file system
@@ -268,9 +397,9 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 			continue
 		}
 
-		var target = filepath.Join(targetPath, header.Name)
-		if strings.Contains(target, "/../") {
-			return nil, fmt.Errorf("targetPath validation failed, path contains unexpected special elements")
+		target, err := targetFor(targetPath, header.Name)
+		if err != nil {
+			return nil, err
 		}
 
 		switch header.Typeflag {
@@ -311,6 +440,11 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 				return nil, err
 			}
 
+			// normalised timestamps of reproducible bundles do not say anything about the source
+			if header.ModTime.Equal(normalisedModTime) {
+				continue
+			}
+
 			if details.MostRecentFileTimestamp == nil || details.MostRecentFileTimestamp.Before(header.ModTime) {
 				details.MostRecentFileTimestamp = &header.ModTime
 			}
@@ -321,6 +455,21 @@ func Unpack(in io.Reader, targetPath string) (*UnpackDetails, error) {
 	}
 }
 
+// targetFor returns the path in the target directory for an entry of an
+// archive, it fails for entry names that would point outside of it
+func targetFor(targetPath string, name string) (string, error) {
+	var target = filepath.Join(targetPath, name)
+	if strings.Contains(target, "/../") {
+		return "", fmt.Errorf("targetPath validation failed, path contains unexpected special elements")
+	}
+
+	if rel, err := filepath.Rel(targetPath, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("targetPath validation failed, path points outside of the target directory")
+	}
+
+	return target, nil
+}
+
 func fileMode(tarHeader *tar.Header) os.FileMode {
 	mode := tarHeader.Mode
 	if mode < 0 || mode > math.MaxUint32 {
@@ -7,17 +7,22 @@ package bundle_test
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/shipwright-io/build/pkg/bundle"
 
+	"github.com/google/go-containerregistry/pkg/compression"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	"k8s.io/apimachinery/pkg/util/rand"
 )
 
@@ -87,6 +92,27 @@ var _ = Describe("Bundle", func() {
 		})
 	})
 
+	Context("packing and unpacking large content", func() {
+		It("should pack and unpack files that exceed the size of a pipe buffer", func() {
+			withTempDir(func(source string) {
+				content := []byte(strings.Repeat("shipwright", 200*1024))
+				Expect(os.WriteFile(filepath.Join(source, "large-file"), content, os.FileMode(0644))).To(Succeed())
+
+				r, err := Pack(source)
+				Expect(err).ToNot(HaveOccurred())
+
+				withTempDir(func(target string) {
+					_, err := Unpack(r, target)
+					Expect(err).ToNot(HaveOccurred())
+
+					data, err := os.ReadFile(filepath.Join(target, "large-file"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(data).To(Equal(content))
+				})
+			})
+		})
+	})
+
 	Context("packing/pushing and pulling/unpacking", func() {
 		It("should pull and unpack an image", func() {
 			withTempRegistry(func(endpoint string) {
@@ -112,5 +138,114 @@ var _ = Describe("Bundle", func() {
 				})
 			})
 		})
+
+		It("should pull and unpack a zstd compressed image", func() {
+			withTempRegistry(func(endpoint string) {
+				ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = PackAndPushWithOptions(ref, filepath.Join("..", "..", "test", "bundle"), PackOptions{Compression: compression.ZStd})
+				Expect(err).ToNot(HaveOccurred())
+
+				withTempDir(func(tempDir string) {
+					image, err := PullAndUnpack(ref, tempDir)
+					Expect(err).ToNot(HaveOccurred())
+
+					layers, err := image.Layers()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(layers).To(HaveLen(1))
+
+					mediaType, err := layers[0].MediaType()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(mediaType).To(Equal(types.OCILayerZStd))
+
+					Expect(filepath.Join(tempDir, "README.md")).To(BeAnExistingFile())
+				})
+			})
+		})
+
+		It("should fail for an unsupported compression", func() {
+			ref, err := name.ParseReference("registry.example.com/namespace/unit-test-pkg-bundle:latest")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = PackAndPushWithOptions(ref, filepath.Join("..", "..", "test", "bundle"), PackOptions{Compression: "brotli"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not report a source timestamp for a reproducible bundle", func() {
+			withTempDir(func(tempDir string) {
+				r, err := PackWithOptions(filepath.Join("..", "..", "test", "bundle"), PackOptions{Reproducible: true})
+				Expect(err).ToNot(HaveOccurred())
+
+				details, err := Unpack(r, tempDir)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(details.MostRecentFileTimestamp).To(BeNil())
+				Expect(filepath.Join(tempDir, "README.md")).To(BeAnExistingFile())
+			})
+		})
+
+		It("should create identical digests for identical directories in reproducible mode", func() {
+			createSource := func(dir string, modTime time.Time) {
+				Expect(os.MkdirAll(filepath.Join(dir, "src"), os.FileMode(0755))).To(Succeed())
+				for _, file := range []string{"README.md", filepath.Join("src", "main.go")} {
+					Expect(os.WriteFile(filepath.Join(dir, file), []byte(file), os.FileMode(0644))).To(Succeed())
+					Expect(os.Chtimes(filepath.Join(dir, file), modTime, modTime)).To(Succeed())
+				}
+			}
+
+			withTempRegistry(func(endpoint string) {
+				withTempDir(func(first string) {
+					withTempDir(func(second string) {
+						createSource(first, time.Now().Add(-24*time.Hour))
+						createSource(second, time.Now())
+
+						ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+						Expect(err).ToNot(HaveOccurred())
+
+						firstDigest, err := PackAndPushWithOptions(ref, first, PackOptions{Reproducible: true})
+						Expect(err).ToNot(HaveOccurred())
+
+						secondDigest, err := PackAndPushWithOptions(ref, second, PackOptions{Reproducible: true})
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(secondDigest.DigestStr()).To(Equal(firstDigest.DigestStr()))
+					})
+				})
+			})
+		})
+
+		It("should skip the upload in case the image already exists", func() {
+			var manifestUploads int
+
+			logLogger := log.Logger{}
+			logLogger.SetOutput(GinkgoWriter)
+
+			registryHandler := registry.New(registry.Logger(&logLogger))
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") {
+					manifestUploads++
+				}
+
+				registryHandler.ServeHTTP(w, r)
+			}))
+			defer s.Close()
+
+			u, err := url.Parse(s.URL)
+			Expect(err).ToNot(HaveOccurred())
+
+			ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", u.Host, rand.String(5)))
+			Expect(err).ToNot(HaveOccurred())
+
+			packOptions := PackOptions{Reproducible: true, SkipExisting: true}
+
+			firstDigest, err := PackAndPushWithOptions(ref, filepath.Join("..", "..", "test", "bundle"), packOptions)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manifestUploads).To(Equal(1))
+
+			secondDigest, err := PackAndPushWithOptions(ref, filepath.Join("..", "..", "test", "bundle"), packOptions)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manifestUploads).To(Equal(1))
+			Expect(secondDigest).To(Equal(firstDigest))
+		})
 	})
 })
@@ -0,0 +1,167 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	containerreg "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+const shpLayersFilename = ".shplayers"
+
+// LayerSplit defines how the content of a directory is split into the layers
+// of the bundle image
+type LayerSplit string
+
+const (
+	// LayerSplitNone packs the whole directory into a single layer
+	LayerSplitNone LayerSplit = ""
+
+	// LayerSplitTopLevel packs every top-level directory into a layer of its
+	// own, all top-level files are packed into a common layer
+	LayerSplitTopLevel LayerSplit = "TopLevel"
+
+	// LayerSplitManifest packs every path listed in the .shplayers file of the
+	// directory into a layer of its own, everything else is packed into a
+	// common layer
+	LayerSplitManifest LayerSplit = "Manifest"
+)
+
+// bundleLayers creates the layers of the bundle image, the common layer comes
+// first followed by one layer per layer path in lexical order
+func bundleLayers(directory string, packOptions PackOptions, layerOptions []tarball.LayerOption) ([]containerreg.Layer, error) {
+	layerPaths, err := layerPathsFor(directory, packOptions.LayerSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []containerreg.Layer
+	for _, layerPath := range append([]string{""}, layerPaths...) {
+		layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return pack(directory, packOptions, layerPath, layerPaths)
+		}, layerOptions...)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, layer)
+	}
+
+	return layers, nil
+}
+
+// layerPathsFor returns the slash separated paths relative to the directory
+// that are packed into layers of their own
+func layerPathsFor(directory string, layerSplit LayerSplit) ([]string, error) {
+	var layerPaths []string
+
+	switch layerSplit {
+	case LayerSplitNone:
+		return nil, nil
+
+	case LayerSplitTopLevel:
+		matcher, err := ignoreMatcher(directory)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := os.ReadDir(directory)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() && !matcher.Match(split(filepath.Join(directory, entry.Name())), true) {
+				layerPaths = append(layerPaths, entry.Name())
+			}
+		}
+
+	case LayerSplitManifest:
+		file, err := os.Open(filepath.Join(directory, shpLayersFilename))
+		if err != nil {
+			return nil, err
+		}
+
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			layerPath := path.Clean(strings.TrimPrefix(line, "/"))
+			if layerPath == "." || layerPath == ".." || strings.HasPrefix(layerPath, "../") {
+				return nil, fmt.Errorf("layer path %q in %s must point to a path inside of the directory", line, shpLayersFilename)
+			}
+
+			// paths that do not exist would result in empty layers
+			if _, err := os.Stat(filepath.Join(directory, filepath.FromSlash(layerPath))); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+
+				return nil, err
+			}
+
+			layerPaths = append(layerPaths, layerPath)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported layer split %q, supported are %q and %q", layerSplit, LayerSplitTopLevel, LayerSplitManifest)
+	}
+
+	sort.Strings(layerPaths)
+	return compact(layerPaths), nil
+}
+
+// layerFor returns the most specific layer path that covers the given name, or
+// an empty string if the name belongs to the common layer
+func layerFor(name string, layerPaths []string) string {
+	var result string
+	for _, layerPath := range layerPaths {
+		if (name == layerPath || strings.HasPrefix(name, layerPath+"/")) && len(layerPath) > len(result) {
+			result = layerPath
+		}
+	}
+
+	return result
+}
+
+// containsLayer returns whether the directory with the given name contains
+// the given layer path
+func containsLayer(name string, layerPath string) bool {
+	if layerPath == "" {
+		return false
+	}
+
+	return name == "." || strings.HasPrefix(layerPath, name+"/")
+}
+
+// compact removes consecutive duplicates from a sorted list
+func compact(list []string) []string {
+	var result []string
+	for i, item := range list {
+		if i == 0 || list[i-1] != item {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
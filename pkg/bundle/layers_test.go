@@ -0,0 +1,174 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle_test
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/shipwright-io/build/pkg/bundle"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+var _ = Describe("Bundle layers", func() {
+	var blobUploads int
+
+	withTempDir := func(f func(tempDir string)) {
+		tempDir, err := os.MkdirTemp("", "bundle")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(tempDir)
+		f(tempDir)
+	}
+
+	withTempRegistry := func(f func(endpoint string)) {
+		logLogger := log.Logger{}
+		logLogger.SetOutput(GinkgoWriter)
+
+		registryHandler := registry.New(registry.Logger(&logLogger))
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/blobs/uploads/") {
+				blobUploads++
+			}
+
+			registryHandler.ServeHTTP(w, r)
+		}))
+		defer s.Close()
+
+		u, err := url.Parse(s.URL)
+		Expect(err).ToNot(HaveOccurred())
+
+		f(u.Host)
+	}
+
+	writeFile := func(dir string, file string, content string) {
+		GinkgoHelper()
+
+		Expect(os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), os.FileMode(0755))).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, file), []byte(content), os.FileMode(0644))).To(Succeed())
+	}
+
+	createSource := func(dir string) {
+		GinkgoHelper()
+
+		writeFile(dir, "main.go", "package main")
+		writeFile(dir, filepath.Join("cmd", "tool", "main.go"), "package main")
+		writeFile(dir, filepath.Join("vendor", "modules.txt"), "# modules")
+		writeFile(dir, filepath.Join("vendor", "github.com", "lib", "lib.go"), "package lib")
+		writeFile(dir, filepath.Join("node_modules", "left-pad", "index.js"), "module.exports = {}")
+	}
+
+	BeforeEach(func() {
+		blobUploads = 0
+	})
+
+	It("should split the content by top-level directory and reassemble it", func() {
+		withTempRegistry(func(endpoint string) {
+			withTempDir(func(source string) {
+				createSource(source)
+
+				ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = PackAndPushWithOptions(ref, source, PackOptions{LayerSplit: LayerSplitTopLevel})
+				Expect(err).ToNot(HaveOccurred())
+
+				withTempDir(func(target string) {
+					image, err := PullAndUnpack(ref, target)
+					Expect(err).ToNot(HaveOccurred())
+
+					layers, err := image.Layers()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(layers).To(HaveLen(4))
+
+					Expect(filepath.Join(target, "main.go")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "cmd", "tool", "main.go")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "vendor", "github.com", "lib", "lib.go")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "node_modules", "left-pad", "index.js")).To(BeAnExistingFile())
+				})
+			})
+		})
+	})
+
+	It("should split the content by the paths listed in the layers manifest", func() {
+		withTempRegistry(func(endpoint string) {
+			withTempDir(func(source string) {
+				createSource(source)
+				writeFile(source, ".shplayers", "# dependencies\nvendor/\nnode_modules\ndoes-not-exist\n")
+
+				ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = PackAndPushWithOptions(ref, source, PackOptions{LayerSplit: LayerSplitManifest})
+				Expect(err).ToNot(HaveOccurred())
+
+				withTempDir(func(target string) {
+					image, err := PullAndUnpack(ref, target)
+					Expect(err).ToNot(HaveOccurred())
+
+					layers, err := image.Layers()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(layers).To(HaveLen(3))
+
+					Expect(filepath.Join(target, "main.go")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "cmd", "tool", "main.go")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "vendor", "modules.txt")).To(BeAnExistingFile())
+					Expect(filepath.Join(target, "node_modules", "left-pad", "index.js")).To(BeAnExistingFile())
+				})
+			})
+		})
+	})
+
+	It("should fail for a layers manifest that points outside of the directory", func() {
+		withTempDir(func(source string) {
+			createSource(source)
+			writeFile(source, ".shplayers", "../other\n")
+
+			ref, err := name.ParseReference("registry.example.com/namespace/unit-test-pkg-bundle:latest")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = PackAndPushWithOptions(ref, source, PackOptions{LayerSplit: LayerSplitManifest})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("should only upload the layers that changed", func() {
+		withTempRegistry(func(endpoint string) {
+			withTempDir(func(source string) {
+				createSource(source)
+
+				ref, err := name.ParseReference(fmt.Sprintf("%s/namespace/unit-test-pkg-bundle-%s:latest", endpoint, rand.String(5)))
+				Expect(err).ToNot(HaveOccurred())
+
+				packOptions := PackOptions{LayerSplit: LayerSplitTopLevel, Reproducible: true}
+
+				_, err = PackAndPushWithOptions(ref, source, packOptions)
+				Expect(err).ToNot(HaveOccurred())
+
+				// four layers and the image configuration
+				Expect(blobUploads).To(Equal(5))
+
+				writeFile(source, "main.go", "package main // changed")
+
+				blobUploads = 0
+				_, err = PackAndPushWithOptions(ref, source, packOptions)
+				Expect(err).ToNot(HaveOccurred())
+
+				// the changed common layer and the image configuration
+				Expect(blobUploads).To(Equal(2))
+			})
+		})
+	})
+})
@@ -0,0 +1,146 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package bundle
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/compression"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// UploadPath is the path of the upload endpoint
+	UploadPath = "/upload"
+
+	// UploadChecksumHeader is the request header that contains the hex-encoded
+	// SHA-256 checksum of the request body
+	UploadChecksumHeader = "Shp-Content-Sha256"
+)
+
+// UploadDetails contains details about the uploaded stream
+type UploadDetails struct {
+	// Digest is the SHA-256 digest of the uploaded stream
+	Digest string
+
+	// Size is the size of the uploaded stream in bytes
+	Size int64
+}
+
+// Upload packs a local directory as tar stream and uploads it to the upload
+// endpoint of a build. The files configured in .shpignore are not uploaded.
+// The stream is zstd compressed in case the pack options request it.
+func Upload(ctx context.Context, endpoint string, token string, directory string, packOptions PackOptions) (*UploadDetails, error) {
+	var contentEncoding string
+	switch packOptions.Compression {
+	case "", compression.None:
+		// the tar stream is uploaded as-is
+
+	case compression.ZStd:
+		contentEncoding = "zstd"
+
+	default:
+		return nil, fmt.Errorf("unsupported compression %q for upload, supported is %q", packOptions.Compression, compression.ZStd)
+	}
+
+	file, err := os.CreateTemp("", "upload")
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	details, err := writeUploadStream(file, directory, packOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+UploadPath, file)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = details.Size
+	req.Header.Set("Content-Type", "application/x-tar")
+	req.Header.Set(UploadChecksumHeader, strings.TrimPrefix(details.Digest, "sha256:"))
+	req.Header.Set("Authorization", "Bearer "+token)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("upload failed with %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return details, nil
+}
+
+// writeUploadStream writes the (compressed) tar stream of the directory and
+// returns the digest and size of what was written
+func writeUploadStream(w io.Writer, directory string, packOptions PackOptions) (*UploadDetails, error) {
+	hash := sha256.New()
+	counter := &countingWriter{}
+	out := io.MultiWriter(w, hash, counter)
+
+	tarStream, err := PackWithOptions(directory, packOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tarStream.Close()
+
+	if packOptions.Compression == compression.ZStd {
+		encoder, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := io.Copy(encoder, tarStream); err != nil {
+			encoder.Close()
+			return nil, err
+		}
+
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+
+	} else if _, err := io.Copy(out, tarStream); err != nil {
+		return nil, err
+	}
+
+	return &UploadDetails{
+		Digest: "sha256:" + hex.EncodeToString(hash.Sum(nil)),
+		Size:   counter.n,
+	}, nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
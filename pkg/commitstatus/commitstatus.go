@@ -0,0 +1,323 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commitstatus reports the state of BuildRuns as commit status of the
+// built commit to GitHub, GitLab, or Gitea.
+package commitstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+const (
+	// SecretKeyToken is the key of the commit status Secret that holds the API token
+	SecretKeyToken = "token"
+
+	// maxDescriptionLength is the maximum length of a description that GitHub accepts
+	maxDescriptionLength = 140
+)
+
+var (
+	// maxAttempts is the number of times that a state is reported for a commit before giving up
+	maxAttempts = 10
+
+	// retryDelay is the delay before a failed report is retried, it doubles for every further attempt
+	retryDelay = 10 * time.Second
+
+	// maxRetryDelay is the maximum delay before a failed report is retried
+	maxRetryDelay = 10 * time.Minute
+
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// Repository identifies a repository on a forge
+type Repository struct {
+	Forge buildv1beta1.GitForge
+
+	// BaseURL is the scheme and host of the forge, for example https://github.com
+	BaseURL string
+
+	// Path is the path of the repository, for example shipwright-io/build
+	Path string
+}
+
+// ParseRepository determines the repository of a Git URL. The forge is detected
+// from the host name, unless it is provided.
+func ParseRepository(gitURL string, forge *buildv1beta1.GitForge) (*Repository, error) {
+	endpoint, err := transport.NewEndpoint(gitURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var baseURL string
+	switch endpoint.Protocol {
+	case "https", "http":
+		baseURL = fmt.Sprintf("%s://%s", endpoint.Protocol, endpoint.Host)
+		if endpoint.Port != 0 {
+			baseURL = fmt.Sprintf("%s:%d", baseURL, endpoint.Port)
+		}
+
+	case "ssh", "git":
+		// the API of the forge is served via HTTPS on the same host
+		baseURL = fmt.Sprintf("https://%s", endpoint.Host)
+
+	default:
+		return nil, fmt.Errorf("unsupported protocol %s", endpoint.Protocol)
+	}
+
+	path := strings.TrimSuffix(strings.Trim(endpoint.Path, "/"), ".git")
+	if !strings.Contains(path, "/") {
+		return nil, fmt.Errorf("URL %s does not contain an owner and a repository", gitURL)
+	}
+
+	repository := &Repository{BaseURL: baseURL, Path: path}
+	if forge != nil {
+		repository.Forge = *forge
+		return repository, nil
+	}
+
+	host := strings.ToLower(endpoint.Host)
+	switch {
+	case host == "github.com" || strings.Contains(host, "github"):
+		repository.Forge = buildv1beta1.GitForgeGitHub
+
+	case host == "gitlab.com" || strings.Contains(host, "gitlab"):
+		repository.Forge = buildv1beta1.GitForgeGitLab
+
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		repository.Forge = buildv1beta1.GitForgeGitea
+
+	default:
+		return nil, fmt.Errorf("the forge of host %s cannot be detected, it must be configured", endpoint.Host)
+	}
+
+	return repository, nil
+}
+
+// Report reports the state of the BuildRun as commit status, if the Git source of
+// the BuildRun is configured for it, and the state or the commit changed since the
+// last report, or the last report failed. It returns the result of the report, or
+// nil if nothing was reported.
+func Report(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun) *buildv1beta1.CommitStatusResult {
+	if buildRun.Status.BuildSpec == nil || buildRun.Status.BuildSpec.Source == nil {
+		return nil
+	}
+
+	git := buildRun.Status.BuildSpec.Source.Git
+	if git == nil || git.CommitStatus == nil {
+		return nil
+	}
+
+	// the commit is only known once the source step reported it
+	if buildRun.Status.Source == nil || buildRun.Status.Source.Git == nil || buildRun.Status.Source.Git.CommitSha == "" {
+		return nil
+	}
+
+	commitSha := buildRun.Status.Source.Git.CommitSha
+	state, description := stateOf(buildRun)
+
+	if last := buildRun.Status.CommitStatus; last != nil && last.CommitSha == commitSha && last.State == state && (last.Error == "" || last.Attempts >= maxAttempts) {
+		return nil
+	}
+
+	result := &buildv1beta1.CommitStatusResult{
+		CommitSha: commitSha,
+		State:     state,
+		Time:      metav1.Now(),
+		Attempts:  1,
+	}
+
+	if last := buildRun.Status.CommitStatus; last != nil && last.CommitSha == commitSha && last.State == state {
+		result.Attempts = last.Attempts + 1
+	}
+
+	if err := report(ctx, reader, buildRun, git, commitSha, state, description); err != nil {
+		ctxlog.Info(ctx, "failed to report commit status", "namespace", buildRun.Namespace, "name", buildRun.Name, "commit", commitSha, "state", state, "error", err)
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// RetryIn returns whether the last report of the BuildRun failed and is retried, and
+// the duration after which the retry is due. The delay doubles for every attempt, and
+// a report is not retried anymore after the maximum number of attempts.
+func RetryIn(buildRun *buildv1beta1.BuildRun) (time.Duration, bool) {
+	last := buildRun.Status.CommitStatus
+	if last == nil || last.Error == "" || last.Attempts >= maxAttempts {
+		return 0, false
+	}
+
+	delay := maxRetryDelay
+	if last.Attempts < 16 {
+		delay = min(retryDelay<<max(last.Attempts-1, 0), maxRetryDelay)
+	}
+
+	return time.Until(last.Time.Add(delay)), true
+}
+
+// stateOf returns the commit state and a description of the state of the BuildRun
+func stateOf(buildRun *buildv1beta1.BuildRun) (buildv1beta1.CommitState, string) {
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+
+	switch {
+	case condition == nil || condition.Status == corev1.ConditionUnknown:
+		return buildv1beta1.CommitStatePending, fmt.Sprintf("BuildRun %s is running", buildRun.Name)
+
+	case condition.Status == corev1.ConditionTrue:
+		return buildv1beta1.CommitStateSuccess, fmt.Sprintf("BuildRun %s succeeded", buildRun.Name)
+
+	default:
+		description := condition.Reason
+		if condition.Message != "" {
+			description = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+		}
+
+		return buildv1beta1.CommitStateFailure, truncate(description)
+	}
+}
+
+func report(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun, git *buildv1beta1.Git, commitSha string, state buildv1beta1.CommitState, description string) error {
+	repository, err := ParseRepository(git.URL, git.CommitStatus.Forge)
+	if err != nil {
+		return err
+	}
+
+	var secret corev1.Secret
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: git.CommitStatus.Secret}, &secret); err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", git.CommitStatus.Secret, err)
+	}
+
+	token := strings.TrimSpace(string(secret.Data[SecretKeyToken]))
+	if token == "" {
+		return fmt.Errorf("secret %s does not contain the %s key", git.CommitStatus.Secret, SecretKeyToken)
+	}
+
+	statusContext := fmt.Sprintf("shipwright/%s", buildRun.Spec.BuildName())
+	if buildRun.Spec.BuildName() == "" {
+		statusContext = fmt.Sprintf("shipwright/%s", buildRun.Name)
+	}
+	if git.CommitStatus.Context != nil && *git.CommitStatus.Context != "" {
+		statusContext = *git.CommitStatus.Context
+	}
+
+	var targetURL string
+	if git.CommitStatus.TargetURL != nil {
+		targetURL = strings.NewReplacer(
+			"$(namespace)", buildRun.Namespace,
+			"$(buildrun)", buildRun.Name,
+		).Replace(*git.CommitStatus.TargetURL)
+	}
+
+	request, err := repository.newRequest(ctx, token, commitSha, state, statusContext, description, targetURL)
+	if err != nil {
+		return err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%s API responded with %s", repository.Forge, response.Status)
+	}
+
+	return nil
+}
+
+// newRequest creates the request that creates a commit status using the API of the forge
+func (r *Repository) newRequest(ctx context.Context, token string, commitSha string, state buildv1beta1.CommitState, statusContext string, description string, targetURL string) (*http.Request, error) {
+	var (
+		endpoint string
+		payload  map[string]string
+		header   string
+		value    string
+	)
+
+	switch r.Forge {
+	case buildv1beta1.GitForgeGitHub:
+		apiURL := r.BaseURL + "/api/v3"
+		if r.BaseURL == "https://github.com" {
+			apiURL = "https://api.github.com"
+		}
+
+		endpoint = fmt.Sprintf("%s/repos/%s/statuses/%s", apiURL, r.Path, commitSha)
+		payload = map[string]string{"state": gitHubStates[state], "context": statusContext}
+		header, value = "Authorization", "Bearer "+token
+
+	case buildv1beta1.GitForgeGitLab:
+		endpoint = fmt.Sprintf("%s/api/v4/projects/%s/statuses/%s", r.BaseURL, url.PathEscape(r.Path), commitSha)
+		payload = map[string]string{"state": gitLabStates[state], "name": statusContext}
+		header, value = "PRIVATE-TOKEN", token
+
+	case buildv1beta1.GitForgeGitea:
+		endpoint = fmt.Sprintf("%s/api/v1/repos/%s/statuses/%s", r.BaseURL, r.Path, commitSha)
+		payload = map[string]string{"state": gitHubStates[state], "context": statusContext}
+		header, value = "Authorization", "token "+token
+
+	default:
+		return nil, fmt.Errorf("unsupported forge %s", r.Forge)
+	}
+
+	payload["description"] = description
+	if targetURL != "" {
+		payload["target_url"] = targetURL
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(header, value)
+	return request, nil
+}
+
+// gitHubStates maps the commit states to the states of GitHub and Gitea
+var gitHubStates = map[buildv1beta1.CommitState]string{
+	buildv1beta1.CommitStatePending: "pending",
+	buildv1beta1.CommitStateSuccess: "success",
+	buildv1beta1.CommitStateFailure: "failure",
+}
+
+// gitLabStates maps the commit states to the states of GitLab
+var gitLabStates = map[buildv1beta1.CommitState]string{
+	buildv1beta1.CommitStatePending: "running",
+	buildv1beta1.CommitStateSuccess: "success",
+	buildv1beta1.CommitStateFailure: "failed",
+}
+
+func truncate(description string) string {
+	if runes := []rune(description); len(runes) > maxDescriptionLength {
+		return string(runes[:maxDescriptionLength-3]) + "..."
+	}
+
+	return description
+}
@@ -0,0 +1,215 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package commitstatus_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/commitstatus"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+)
+
+var _ = Describe("Commit status", func() {
+	Context("ParseRepository", func() {
+		DescribeTable("detects the forge and the repository",
+			func(gitURL string, forge buildv1beta1.GitForge, baseURL string, path string) {
+				repository, err := commitstatus.ParseRepository(gitURL, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(repository.Forge).To(Equal(forge))
+				Expect(repository.BaseURL).To(Equal(baseURL))
+				Expect(repository.Path).To(Equal(path))
+			},
+			Entry("GitHub HTTPS URL", "https://github.com/shipwright-io/sample-go", buildv1beta1.GitForgeGitHub, "https://github.com", "shipwright-io/sample-go"),
+			Entry("GitHub SSH URL", "git@github.com:shipwright-io/sample-go.git", buildv1beta1.GitForgeGitHub, "https://github.com", "shipwright-io/sample-go"),
+			Entry("GitLab URL with subgroup", "https://gitlab.com/shipwright-io/samples/sample-go.git", buildv1beta1.GitForgeGitLab, "https://gitlab.com", "shipwright-io/samples/sample-go"),
+			Entry("Gitea URL with port", "https://gitea.example.com:3000/shipwright-io/sample-go", buildv1beta1.GitForgeGitea, "https://gitea.example.com:3000", "shipwright-io/sample-go"),
+		)
+
+		It("uses the configured forge", func() {
+			repository, err := commitstatus.ParseRepository("https://git.example.com/shipwright-io/sample-go", ptr.To(buildv1beta1.GitForgeGitLab))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(repository.Forge).To(Equal(buildv1beta1.GitForgeGitLab))
+		})
+
+		It("fails if the forge cannot be detected", func() {
+			_, err := commitstatus.ParseRepository("https://git.example.com/shipwright-io/sample-go", nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("fails if the URL does not contain an owner", func() {
+			_, err := commitstatus.ParseRepository("https://github.com/sample-go", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Report", func() {
+		var (
+			ctx      context.Context
+			client   *fakes.FakeClient
+			buildRun *buildv1beta1.BuildRun
+
+			requests []*http.Request
+			payloads []map[string]string
+			server   *httptest.Server
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			requests, payloads = nil, nil
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				payload := map[string]string{}
+				_ = json.Unmarshal(body, &payload)
+
+				requests = append(requests, r)
+				payloads = append(payloads, payload)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			DeferCleanup(server.Close)
+
+			client = &fakes.FakeClient{}
+			client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+				if secret, ok := object.(*corev1.Secret); ok {
+					secret.Data = map[string][]byte{commitstatus.SecretKeyToken: []byte("s3cr3t")}
+				}
+				return nil
+			})
+
+			buildRun = &buildv1beta1.BuildRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "builds", Name: "sample-go-abcde"},
+				Spec: buildv1beta1.BuildRunSpec{
+					Build: buildv1beta1.ReferencedBuild{Name: ptr.To("sample-go")},
+				},
+				Status: buildv1beta1.BuildRunStatus{
+					BuildSpec: &buildv1beta1.BuildSpec{
+						Source: &buildv1beta1.Source{
+							Type: buildv1beta1.GitType,
+							Git: &buildv1beta1.Git{
+								URL: server.URL + "/shipwright-io/sample-go",
+								CommitStatus: &buildv1beta1.GitCommitStatus{
+									Secret:    "forge-token",
+									Forge:     ptr.To(buildv1beta1.GitForgeGitHub),
+									TargetURL: ptr.To("https://console.example.com/$(namespace)/$(buildrun)"),
+								},
+							},
+						},
+					},
+					Source: &buildv1beta1.SourceResult{
+						Git: &buildv1beta1.GitSourceResult{CommitSha: "0123456789abcdef"},
+					},
+				},
+			}
+		})
+
+		It("reports a running BuildRun as pending", func() {
+			result := commitstatus.Report(ctx, client, buildRun)
+			Expect(result).ToNot(BeNil())
+			Expect(result.State).To(Equal(buildv1beta1.CommitStatePending))
+			Expect(result.CommitSha).To(Equal("0123456789abcdef"))
+			Expect(result.Error).To(BeEmpty())
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].URL.Path).To(Equal("/api/v3/repos/shipwright-io/sample-go/statuses/0123456789abcdef"))
+			Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer s3cr3t"))
+			Expect(payloads[0]).To(HaveKeyWithValue("state", "pending"))
+			Expect(payloads[0]).To(HaveKeyWithValue("context", "shipwright/sample-go"))
+			Expect(payloads[0]).To(HaveKeyWithValue("target_url", "https://console.example.com/builds/sample-go-abcde"))
+		})
+
+		It("reports a failed BuildRun with the reason to GitLab", func() {
+			buildRun.Status.BuildSpec.Source.Git.CommitStatus.Forge = ptr.To(buildv1beta1.GitForgeGitLab)
+			buildRun.Status.SetCondition(&buildv1beta1.Condition{
+				Type:    buildv1beta1.Succeeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "Failed",
+				Message: "step build-and-push failed",
+			})
+
+			result := commitstatus.Report(ctx, client, buildRun)
+			Expect(result.State).To(Equal(buildv1beta1.CommitStateFailure))
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].URL.EscapedPath()).To(Equal("/api/v4/projects/shipwright-io%2Fsample-go/statuses/0123456789abcdef"))
+			Expect(requests[0].Header.Get("PRIVATE-TOKEN")).To(Equal("s3cr3t"))
+			Expect(payloads[0]).To(HaveKeyWithValue("state", "failed"))
+			Expect(payloads[0]).To(HaveKeyWithValue("name", "shipwright/sample-go"))
+			Expect(payloads[0]).To(HaveKeyWithValue("description", "Failed: step build-and-push failed"))
+		})
+
+		It("does not report the same state twice", func() {
+			buildRun.Status.CommitStatus = commitstatus.Report(ctx, client, buildRun)
+			Expect(commitstatus.Report(ctx, client, buildRun)).To(BeNil())
+			Expect(requests).To(HaveLen(1))
+		})
+
+		It("does not report before the commit is known", func() {
+			buildRun.Status.Source = nil
+			Expect(commitstatus.Report(ctx, client, buildRun)).To(BeNil())
+			Expect(requests).To(BeEmpty())
+		})
+
+		It("records why a report failed", func() {
+			server.Close()
+
+			result := commitstatus.Report(ctx, client, buildRun)
+			Expect(result).ToNot(BeNil())
+			Expect(result.Error).ToNot(BeEmpty())
+			Expect(result.Attempts).To(Equal(1))
+		})
+
+		It("retries a failed report with a growing delay", func() {
+			buildRun.Status.CommitStatus = &buildv1beta1.CommitStatusResult{
+				CommitSha: "0123456789abcdef",
+				State:     buildv1beta1.CommitStatePending,
+				Time:      metav1.NewTime(time.Now().Add(-time.Minute)),
+				Error:     "GitHub API responded with 502 Bad Gateway",
+				Attempts:  2,
+			}
+
+			wait, retry := commitstatus.RetryIn(buildRun)
+			Expect(retry).To(BeTrue())
+			Expect(wait).To(BeNumerically("~", -40*time.Second, time.Second))
+
+			buildRun.Status.CommitStatus = commitstatus.Report(ctx, client, buildRun)
+			Expect(requests).To(HaveLen(1))
+			Expect(buildRun.Status.CommitStatus.Error).To(BeEmpty())
+			Expect(buildRun.Status.CommitStatus.Attempts).To(Equal(3))
+
+			_, retry = commitstatus.RetryIn(buildRun)
+			Expect(retry).To(BeFalse())
+		})
+
+		It("stops retrying a failed report after the last attempt", func() {
+			buildRun.Status.CommitStatus = &buildv1beta1.CommitStatusResult{
+				CommitSha: "0123456789abcdef",
+				State:     buildv1beta1.CommitStatePending,
+				Time:      metav1.NewTime(time.Now().Add(-time.Hour)),
+				Error:     "GitHub API responded with 401 Unauthorized",
+				Attempts:  10,
+			}
+
+			_, retry := commitstatus.RetryIn(buildRun)
+			Expect(retry).To(BeFalse())
+			Expect(commitstatus.Report(ctx, client, buildRun)).To(BeNil())
+			Expect(requests).To(BeEmpty())
+		})
+	})
+})
@@ -41,6 +41,11 @@ const (
 	bundleImageEnvVar             = "BUNDLE_CONTAINER_IMAGE"
 	bundleContainerTemplateEnvVar = "BUNDLE_CONTAINER_TEMPLATE"
 
+	// Analog to the Git image, the archive image is also created by ko
+	archiveDefaultImage            = "ghcr.io/shipwright-io/build/archive:latest"
+	archiveImageEnvVar             = "ARCHIVE_CONTAINER_IMAGE"
+	archiveContainerTemplateEnvVar = "ARCHIVE_CONTAINER_TEMPLATE"
+
 	// environment variable to hold waiter's container image, created by ko
 	waiterDefaultImage            = "ghcr.io/shipwright-io/build/waiter:latest"
 	waiterImageEnvVar             = "WAITER_CONTAINER_IMAGE"
@@ -50,6 +55,7 @@ const (
 	metricBuildRunCompletionDurationBucketsEnvVar = "PROMETHEUS_BR_COMP_DUR_BUCKETS"
 	metricBuildRunEstablishDurationBucketsEnvVar  = "PROMETHEUS_BR_EST_DUR_BUCKETS"
 	metricBuildRunRampUpDurationBucketsEnvVar     = "PROMETHEUS_BR_RAMPUP_DUR_BUCKETS"
+	metricBuildRunStepDurationBucketsEnvVar       = "PROMETHEUS_BR_STEP_DUR_BUCKETS"
 
 	// environment variable to enable prometheus metric labels
 	prometheusEnabledLabelsEnvVar = "PROMETHEUS_ENABLED_LABELS"
@@ -57,6 +63,8 @@ const (
 	leaderElectionNamespaceDefault = "default"
 	leaderElectionNamespaceEnvVar  = "BUILD_CONTROLLER_LEADER_ELECTION_NAMESPACE"
 
+	configMapNamespaceEnvVar = "BUILD_CONTROLLER_CONFIGMAP_NAMESPACE"
+
 	leaseDurationEnvVar = "BUILD_CONTROLLER_LEASE_DURATION"
 	renewDeadlineEnvVar = "BUILD_CONTROLLER_RENEW_DEADLINE"
 	retryPeriodEnvVar   = "BUILD_CONTROLLER_RETRY_PERIOD"
@@ -66,6 +74,8 @@ const (
 	controllerBuildRunMaxConcurrentReconciles             = "BUILDRUN_MAX_CONCURRENT_RECONCILES"
 	controllerBuildStrategyMaxConcurrentReconciles        = "BUILDSTRATEGY_MAX_CONCURRENT_RECONCILES"
 	controllerClusterBuildStrategyMaxConcurrentReconciles = "CLUSTERBUILDSTRATEGY_MAX_CONCURRENT_RECONCILES"
+	controllerBuildCacheCleanupMaxConcurrentReconciles    = "BUILDCACHECLEANUP_MAX_CONCURRENT_RECONCILES"
+	controllerBuildQuotaMaxConcurrentReconciles           = "BUILDQUOTA_MAX_CONCURRENT_RECONCILES"
 
 	// environment variables for the kube API
 	kubeAPIBurst = "KUBE_API_BURST"
@@ -79,6 +89,12 @@ const (
 
 	// environment variable to hold vulnerability count limit
 	VulnerabilityCountLimitEnvVar = "VULNERABILITY_COUNT_LIMIT"
+
+	// environment variables for the archival of build logs
+	logArchiveRepositoryEnvVar       = "BUILD_LOG_ARCHIVE_REPOSITORY"
+	logArchiveDockerConfigPathEnvVar = "BUILD_LOG_ARCHIVE_DOCKERCONFIG"
+	logArchiveInsecureEnvVar         = "BUILD_LOG_ARCHIVE_INSECURE"
+	logArchiveDirectoryEnvVar        = "BUILD_LOG_ARCHIVE_DIRECTORY"
 )
 
 var (
@@ -86,6 +102,7 @@ var (
 	metricBuildRunCompletionDurationBuckets = prometheus.LinearBuckets(50, 50, 10)
 	metricBuildRunEstablishDurationBuckets  = []float64{0, 1, 2, 3, 5, 7, 10, 15, 20, 30}
 	metricBuildRunRampUpDurationBuckets     = prometheus.LinearBuckets(0, 1, 10)
+	metricBuildRunStepDurationBuckets       = prometheus.ExponentialBuckets(1, 2, 12)
 
 	root    = ptr.To[int64](0)
 	nonRoot = ptr.To[int64](1000)
@@ -98,6 +115,7 @@ type Config struct {
 	GitContainerTemplate             Step
 	ImageProcessingContainerTemplate Step
 	BundleContainerTemplate          Step
+	ArchiveContainerTemplate         Step
 	WaiterContainerTemplate          Step
 	RemoteArtifactsContainerImage    string
 	TerminationLogPath               string
@@ -107,6 +125,32 @@ type Config struct {
 	KubeAPIOptions                   KubeAPIOptions
 	GitRewriteRule                   bool
 	VulnerabilityCountLimit          int
+	ConfigMapNamespace               string
+	LogArchive                       LogArchiveConfig
+
+	live *liveConfig
+}
+
+// LogArchiveConfig contains the configuration for the archival of the step logs of
+// completed BuildRuns, archival is disabled if neither a repository nor a directory
+// is configured
+type LogArchiveConfig struct {
+	// Repository is the container image repository that the logs are pushed to as OCI artifact
+	Repository string
+
+	// DockerConfigPath is the path of a mounted Docker config with the credentials for the repository
+	DockerConfigPath string
+
+	// Insecure allows to push to a registry that uses HTTP or a self-signed certificate
+	Insecure bool
+
+	// Directory is the path of a directory, usually a mounted volume, that the logs are written to
+	Directory string
+}
+
+// Enabled returns whether the logs of BuildRuns are archived
+func (l LogArchiveConfig) Enabled() bool {
+	return l.Repository != "" || l.Directory != ""
 }
 
 // PrometheusConfig contains the specific configuration for the
@@ -114,6 +158,7 @@ type PrometheusConfig struct {
 	BuildRunCompletionDurationBuckets []float64
 	BuildRunEstablishDurationBuckets  []float64
 	BuildRunRampUpDurationBuckets     []float64
+	BuildRunStepDurationBuckets       []float64
 	EnabledLabels                     []string
 }
 
@@ -131,6 +176,8 @@ type Controllers struct {
 	BuildRun             ControllerOptions
 	BuildStrategy        ControllerOptions
 	ClusterBuildStrategy ControllerOptions
+	BuildCacheCleanup    ControllerOptions
+	BuildQuota           ControllerOptions
 }
 
 // ControllerOptions contains configurable options for a controller
@@ -164,6 +211,8 @@ func NewDefaultConfig() *Config {
 		GitRewriteRule:                false,
 		VulnerabilityCountLimit:       50,
 
+		live: &liveConfig{},
+
 		GitContainerTemplate: Step{
 			Image: gitDefaultImage,
 			Command: []string{
@@ -220,6 +269,34 @@ func NewDefaultConfig() *Config {
 			},
 		},
 
+		ArchiveContainerTemplate: Step{
+			Image: archiveDefaultImage,
+			Command: []string{
+				"/ko-app/archive",
+			},
+			// This directory is created in the base image as writable for everybody
+			Env: []corev1.EnvVar{
+				{
+					Name:  "HOME",
+					Value: "/shared-home",
+				},
+				{
+					Name:  "ARCHIVE_SHOW_LISTING",
+					Value: "false",
+				},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities: &corev1.Capabilities{
+					Drop: []corev1.Capability{
+						"ALL",
+					},
+				},
+				RunAsUser:  nonRoot,
+				RunAsGroup: nonRoot,
+			},
+		},
+
 		ImageProcessingContainerTemplate: Step{
 			Image: imageProcessingDefaultImage,
 			Command: []string{
@@ -283,6 +360,7 @@ func NewDefaultConfig() *Config {
 			BuildRunCompletionDurationBuckets: metricBuildRunCompletionDurationBuckets,
 			BuildRunEstablishDurationBuckets:  metricBuildRunEstablishDurationBuckets,
 			BuildRunRampUpDurationBuckets:     metricBuildRunRampUpDurationBuckets,
+			BuildRunStepDurationBuckets:       metricBuildRunStepDurationBuckets,
 		},
 
 		ManagerOptions: ManagerOptions{
@@ -302,6 +380,12 @@ func NewDefaultConfig() *Config {
 			ClusterBuildStrategy: ControllerOptions{
 				MaxConcurrentReconciles: 0,
 			},
+			BuildCacheCleanup: ControllerOptions{
+				MaxConcurrentReconciles: 0,
+			},
+			BuildQuota: ControllerOptions{
+				MaxConcurrentReconciles: 0,
+			},
 		},
 
 		KubeAPIOptions: KubeAPIOptions{
@@ -381,6 +465,21 @@ func (c *Config) SetConfigFromEnv() error {
 		c.BundleContainerTemplate.Image = bundleImage
 	}
 
+	if archiveContainerTemplate := os.Getenv(archiveContainerTemplateEnvVar); archiveContainerTemplate != "" {
+		c.ArchiveContainerTemplate = Step{}
+		if err := json.Unmarshal([]byte(archiveContainerTemplate), &c.ArchiveContainerTemplate); err != nil {
+			return err
+		}
+		if c.ArchiveContainerTemplate.Image == "" {
+			c.ArchiveContainerTemplate.Image = archiveDefaultImage
+		}
+	}
+
+	// the dedicated environment variable for the image overwrites what is defined in the archive container template
+	if archiveImage := os.Getenv(archiveImageEnvVar); archiveImage != "" {
+		c.ArchiveContainerTemplate.Image = archiveImage
+	}
+
 	if waiterContainerTemplate := os.Getenv(waiterContainerTemplateEnvVar); waiterContainerTemplate != "" {
 		c.WaiterContainerTemplate = Step{}
 		if err := json.Unmarshal([]byte(waiterContainerTemplate), &c.WaiterContainerTemplate); err != nil {
@@ -411,12 +510,22 @@ func (c *Config) SetConfigFromEnv() error {
 		return err
 	}
 
+	if err := updateBucketsConfig(&c.Prometheus.BuildRunStepDurationBuckets, metricBuildRunStepDurationBucketsEnvVar); err != nil {
+		return err
+	}
+
 	c.Prometheus.EnabledLabels = strings.Split(os.Getenv(prometheusEnabledLabelsEnvVar), ",")
 
 	if leaderElectionNamespace := os.Getenv(leaderElectionNamespaceEnvVar); leaderElectionNamespace != "" {
 		c.ManagerOptions.LeaderElectionNamespace = leaderElectionNamespace
 	}
 
+	// the ConfigMap is expected in the namespace of the controller, which is also the leader election namespace
+	c.ConfigMapNamespace = c.ManagerOptions.LeaderElectionNamespace
+	if configMapNamespace := os.Getenv(configMapNamespaceEnvVar); configMapNamespace != "" {
+		c.ConfigMapNamespace = configMapNamespace
+	}
+
 	if err := updateBuildControllerDurationOption(&c.ManagerOptions.LeaseDuration, leaseDurationEnvVar); err != nil {
 		return err
 	}
@@ -442,6 +551,12 @@ func (c *Config) SetConfigFromEnv() error {
 	if err := updateIntOption(&c.Controllers.ClusterBuildStrategy.MaxConcurrentReconciles, controllerClusterBuildStrategyMaxConcurrentReconciles); err != nil {
 		return err
 	}
+	if err := updateIntOption(&c.Controllers.BuildCacheCleanup.MaxConcurrentReconciles, controllerBuildCacheCleanupMaxConcurrentReconciles); err != nil {
+		return err
+	}
+	if err := updateIntOption(&c.Controllers.BuildQuota.MaxConcurrentReconciles, controllerBuildQuotaMaxConcurrentReconciles); err != nil {
+		return err
+	}
 
 	// kube API settings
 	if err := updateIntOption(&c.KubeAPIOptions.Burst, kubeAPIBurst); err != nil {
@@ -455,6 +570,26 @@ func (c *Config) SetConfigFromEnv() error {
 		c.TerminationLogPath = terminationLogPath
 	}
 
+	// log archive settings
+	if repository := os.Getenv(logArchiveRepositoryEnvVar); repository != "" {
+		c.LogArchive.Repository = repository
+	}
+
+	if dockerConfigPath := os.Getenv(logArchiveDockerConfigPathEnvVar); dockerConfigPath != "" {
+		c.LogArchive.DockerConfigPath = dockerConfigPath
+	}
+
+	if insecure := os.Getenv(logArchiveInsecureEnvVar); insecure != "" {
+		var err error
+		if c.LogArchive.Insecure, err = strconv.ParseBool(insecure); err != nil {
+			return err
+		}
+	}
+
+	if directory := os.Getenv(logArchiveDirectoryEnvVar); directory != "" {
+		c.LogArchive.Directory = directory
+	}
+
 	return nil
 }
 
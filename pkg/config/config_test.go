@@ -36,12 +36,14 @@ var _ = Describe("Config", func() {
 				"PROMETHEUS_BR_COMP_DUR_BUCKETS":   "1,2,3,4",
 				"PROMETHEUS_BR_EST_DUR_BUCKETS":    "10,20,30,40",
 				"PROMETHEUS_BR_RAMPUP_DUR_BUCKETS": "1,2,3,5,8,12,20",
+				"PROMETHEUS_BR_STEP_DUR_BUCKETS":   "5,10,60",
 			}
 
 			configWithEnvVariableOverrides(overrides, func(config *Config) {
 				Expect(config.Prometheus.BuildRunCompletionDurationBuckets).To(Equal([]float64{1, 2, 3, 4}))
 				Expect(config.Prometheus.BuildRunEstablishDurationBuckets).To(Equal([]float64{10, 20, 30, 40}))
 				Expect(config.Prometheus.BuildRunRampUpDurationBuckets).To(Equal([]float64{1, 2, 3, 5, 8, 12, 20}))
+				Expect(config.Prometheus.BuildRunStepDurationBuckets).To(Equal([]float64{5, 10, 60}))
 			})
 		})
 
@@ -79,6 +81,8 @@ var _ = Describe("Config", func() {
 				"BUILDRUN_MAX_CONCURRENT_RECONCILES":             "3",
 				"BUILDSTRATEGY_MAX_CONCURRENT_RECONCILES":        "4",
 				"CLUSTERBUILDSTRATEGY_MAX_CONCURRENT_RECONCILES": "5",
+				"BUILDCACHECLEANUP_MAX_CONCURRENT_RECONCILES":    "6",
+				"BUILDQUOTA_MAX_CONCURRENT_RECONCILES":           "7",
 			}
 
 			configWithEnvVariableOverrides(overrides, func(config *Config) {
@@ -86,6 +90,8 @@ var _ = Describe("Config", func() {
 				Expect(config.Controllers.BuildRun.MaxConcurrentReconciles).To(Equal(3))
 				Expect(config.Controllers.BuildStrategy.MaxConcurrentReconciles).To(Equal(4))
 				Expect(config.Controllers.ClusterBuildStrategy.MaxConcurrentReconciles).To(Equal(5))
+				Expect(config.Controllers.BuildCacheCleanup.MaxConcurrentReconciles).To(Equal(6))
+				Expect(config.Controllers.BuildQuota.MaxConcurrentReconciles).To(Equal(7))
 			})
 		})
 
@@ -168,6 +174,25 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		It("should allow for an override of the Archive container template and image", func() {
+			var overrides = map[string]string{
+				"ARCHIVE_CONTAINER_TEMPLATE": `{"image":"myregistry/custom/archive-image","resources":{"requests":{"cpu":"0.5","memory":"128Mi"}}}`,
+				"ARCHIVE_CONTAINER_IMAGE":    "myregistry/custom/archive-image:override",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.ArchiveContainerTemplate).To(Equal(Step{
+					Image: "myregistry/custom/archive-image:override",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("0.5"),
+							corev1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+					},
+				}))
+			})
+		})
+
 		It("should allow for an override of the image processing container template", func() {
 			overrides := map[string]string{
 				"IMAGE_PROCESSING_CONTAINER_TEMPLATE": `{"image":"myregistry/custom/image-processing","resources":{"requests":{"cpu":"0.5","memory":"128Mi"}}}`,
@@ -268,6 +293,25 @@ var _ = Describe("Config", func() {
 				}))
 			})
 		})
+
+		It("should allow to configure the archival of build logs", func() {
+			var overrides = map[string]string{
+				"BUILD_LOG_ARCHIVE_REPOSITORY":   "registry.example.com/build-logs",
+				"BUILD_LOG_ARCHIVE_DOCKERCONFIG": "/etc/build-logs/credentials",
+				"BUILD_LOG_ARCHIVE_INSECURE":     "true",
+				"BUILD_LOG_ARCHIVE_DIRECTORY":    "/var/build-logs",
+			}
+
+			configWithEnvVariableOverrides(overrides, func(config *Config) {
+				Expect(config.LogArchive).To(Equal(LogArchiveConfig{
+					Repository:       "registry.example.com/build-logs",
+					DockerConfigPath: "/etc/build-logs/credentials",
+					Insecure:         true,
+					Directory:        "/var/build-logs",
+				}))
+				Expect(config.LogArchive.Enabled()).To(BeTrue())
+			})
+		})
 	})
 })
 
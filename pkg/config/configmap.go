@@ -0,0 +1,232 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the namespace of the controller from
+	// which the configuration is reloaded while the controller is running
+	ConfigMapName = "shipwright-build-config"
+
+	configMapKeyContextTimeout                    = "contextTimeout"
+	configMapKeyGitContainerTemplate              = "gitContainerTemplate"
+	configMapKeyGitRewriteRule                    = "gitRewriteRule"
+	configMapKeyImageProcessingContainerTemplate  = "imageProcessingContainerTemplate"
+	configMapKeyBundleContainerTemplate           = "bundleContainerTemplate"
+	configMapKeyArchiveContainerTemplate          = "archiveContainerTemplate"
+	configMapKeyWaiterContainerTemplate           = "waiterContainerTemplate"
+	configMapKeyRemoteArtifactsContainerImage     = "remoteArtifactsContainerImage"
+	configMapKeyVulnerabilityCountLimit           = "vulnerabilityCountLimit"
+	configMapKeyBuildRunCompletionDurationBuckets = "buildRunCompletionDurationBuckets"
+	configMapKeyBuildRunEstablishDurationBuckets  = "buildRunEstablishDurationBuckets"
+	configMapKeyBuildRunRampUpDurationBuckets     = "buildRunRampUpDurationBuckets"
+	configMapKeyBuildRunStepDurationBuckets       = "buildRunStepDurationBuckets"
+	configMapKeyLogArchiveRepository              = "logArchiveRepository"
+)
+
+// liveConfig holds the configuration that was last applied from the ConfigMap
+type liveConfig struct {
+	current    atomic.Pointer[Config]
+	generation atomic.Int64
+}
+
+// Active returns the configuration that should be used for new work. This is the
+// configuration that was last applied from the ConfigMap, or the configuration
+// itself if nothing was applied yet.
+func (c *Config) Active() *Config {
+	if c.live == nil {
+		return c
+	}
+
+	if current := c.live.current.Load(); current != nil {
+		return current
+	}
+
+	return c
+}
+
+// Generation returns the generation of the active configuration, it is increased
+// every time a configuration is applied
+func (c *Config) Generation() int64 {
+	if c.live == nil {
+		return 0
+	}
+
+	return c.live.generation.Load()
+}
+
+// Apply validates the data of the ConfigMap and activates the resulting configuration.
+// The settings of the ConfigMap are applied on top of the configuration itself, so that
+// removing a key restores the value from the environment. In case of an invalid
+// setting, an error is returned and the active configuration remains unchanged.
+func (c *Config) Apply(data map[string]string) (*Config, error) {
+	if c.live == nil {
+		return nil, errors.New("the configuration does not support to be reloaded")
+	}
+
+	active, err := c.fromConfigMapData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	c.live.current.Store(active)
+	c.live.generation.Add(1)
+
+	return active, nil
+}
+
+// fromConfigMapData returns a copy of the configuration with the settings of the
+// ConfigMap data applied. Settings that are not part of the ConfigMap data are
+// taken over unchanged.
+func (c *Config) fromConfigMapData(data map[string]string) (*Config, error) {
+	result := *c
+
+	var errs []error
+	for _, key := range sortedKeys(data) {
+		value := strings.TrimSpace(data[key])
+
+		var err error
+		switch key {
+		case configMapKeyContextTimeout:
+			result.CtxTimeOut, err = parsePositiveDuration(value)
+
+		case configMapKeyGitContainerTemplate:
+			result.GitContainerTemplate, err = parseStep(value, c.GitContainerTemplate.Image)
+
+		case configMapKeyGitRewriteRule:
+			result.GitRewriteRule, err = strconv.ParseBool(value)
+
+		case configMapKeyImageProcessingContainerTemplate:
+			result.ImageProcessingContainerTemplate, err = parseStep(value, c.ImageProcessingContainerTemplate.Image)
+
+		case configMapKeyBundleContainerTemplate:
+			result.BundleContainerTemplate, err = parseStep(value, c.BundleContainerTemplate.Image)
+
+		case configMapKeyArchiveContainerTemplate:
+			result.ArchiveContainerTemplate, err = parseStep(value, c.ArchiveContainerTemplate.Image)
+
+		case configMapKeyWaiterContainerTemplate:
+			result.WaiterContainerTemplate, err = parseStep(value, c.WaiterContainerTemplate.Image)
+
+		case configMapKeyRemoteArtifactsContainerImage:
+			if value == "" {
+				err = errors.New("must not be empty")
+			}
+			result.RemoteArtifactsContainerImage = value
+
+		case configMapKeyVulnerabilityCountLimit:
+			result.VulnerabilityCountLimit, err = strconv.Atoi(value)
+			if err == nil && result.VulnerabilityCountLimit < 0 {
+				err = errors.New("must not be negative")
+			}
+
+		case configMapKeyBuildRunCompletionDurationBuckets:
+			result.Prometheus.BuildRunCompletionDurationBuckets, err = parseBuckets(value)
+
+		case configMapKeyBuildRunEstablishDurationBuckets:
+			result.Prometheus.BuildRunEstablishDurationBuckets, err = parseBuckets(value)
+
+		case configMapKeyBuildRunRampUpDurationBuckets:
+			result.Prometheus.BuildRunRampUpDurationBuckets, err = parseBuckets(value)
+
+		case configMapKeyBuildRunStepDurationBuckets:
+			result.Prometheus.BuildRunStepDurationBuckets, err = parseBuckets(value)
+
+		case configMapKeyLogArchiveRepository:
+			result.LogArchive.Repository = value
+
+		default:
+			err = errors.New("unknown setting")
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid configuration in ConfigMap %s: %w", ConfigMapName, errors.Join(errs...))
+	}
+
+	return &result, nil
+}
+
+// parseStep parses a container template in YAML or JSON format, the image is taken
+// over from the current template if the new one does not define it
+func parseStep(value string, image string) (Step, error) {
+	var step Step
+	if err := yaml.UnmarshalStrict([]byte(value), &step); err != nil {
+		return Step{}, err
+	}
+
+	switch step.ImagePullPolicy {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return Step{}, fmt.Errorf("unsupported imagePullPolicy %q", step.ImagePullPolicy)
+	}
+
+	if step.Image == "" {
+		step.Image = image
+	}
+
+	return step, nil
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	if duration <= 0 {
+		return 0, errors.New("must be positive")
+	}
+
+	return duration, nil
+}
+
+// parseBuckets parses comma separated bucket boundaries, Prometheus requires them to
+// be in strictly increasing order
+func parseBuckets(value string) ([]float64, error) {
+	values := strings.Split(value, ",")
+	for i := range values {
+		values[i] = strings.TrimSpace(values[i])
+	}
+
+	buckets, err := stringToFloat64Array(values)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] <= buckets[i-1] {
+			return nil, errors.New("buckets must be in strictly increasing order")
+		}
+	}
+
+	return buckets, nil
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	. "github.com/shipwright-io/build/pkg/config"
+)
+
+var _ = Describe("Config reloading", func() {
+	var config *Config
+
+	BeforeEach(func() {
+		config = NewDefaultConfig()
+		config.GitContainerTemplate.Image = "registry.example.com/git:env"
+	})
+
+	Context("when no ConfigMap was applied", func() {
+		It("should return the configuration itself as active configuration", func() {
+			Expect(config.Active()).To(BeIdenticalTo(config))
+			Expect(config.Generation()).To(BeZero())
+		})
+	})
+
+	Context("when a valid ConfigMap is applied", func() {
+		It("should activate the settings and increase the generation", func() {
+			active, err := config.Apply(map[string]string{
+				"contextTimeout":                    "30s",
+				"gitRewriteRule":                    "true",
+				"vulnerabilityCountLimit":           "10",
+				"remoteArtifactsContainerImage":     "registry.example.com/busybox:latest",
+				"buildRunCompletionDurationBuckets": "10, 60, 300",
+				"bundleContainerTemplate":           "image: registry.example.com/bundle:v1\nimagePullPolicy: Always\n",
+				"logArchiveRepository":              "registry.example.com/build-logs",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(config.Active()).To(BeIdenticalTo(active))
+			Expect(config.Generation()).To(Equal(int64(1)))
+			Expect(active.Generation()).To(Equal(int64(1)))
+
+			Expect(active.CtxTimeOut).To(Equal(30 * time.Second))
+			Expect(active.GitRewriteRule).To(BeTrue())
+			Expect(active.VulnerabilityCountLimit).To(Equal(10))
+			Expect(active.RemoteArtifactsContainerImage).To(Equal("registry.example.com/busybox:latest"))
+			Expect(active.Prometheus.BuildRunCompletionDurationBuckets).To(Equal([]float64{10, 60, 300}))
+			Expect(active.LogArchive.Repository).To(Equal("registry.example.com/build-logs"))
+			Expect(active.BundleContainerTemplate).To(Equal(Step{
+				Image:           "registry.example.com/bundle:v1",
+				ImagePullPolicy: corev1.PullAlways,
+			}))
+
+			// settings that are not part of the ConfigMap remain unchanged
+			Expect(active.GitContainerTemplate).To(Equal(config.GitContainerTemplate))
+			Expect(config.CtxTimeOut).ToNot(Equal(30 * time.Second))
+		})
+
+		It("should retain the configured image if a container template does not define one", func() {
+			active, err := config.Apply(map[string]string{
+				"gitContainerTemplate": `{"command": ["/ko-app/git"], "workingDir": "/workspace"}`,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(active.GitContainerTemplate.Image).To(Equal("registry.example.com/git:env"))
+			Expect(active.GitContainerTemplate.WorkingDir).To(Equal("/workspace"))
+		})
+
+		It("should restore the configuration from the environment when the settings are removed", func() {
+			_, err := config.Apply(map[string]string{"vulnerabilityCountLimit": "10"})
+			Expect(err).ToNot(HaveOccurred())
+
+			active, err := config.Apply(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(active.VulnerabilityCountLimit).To(Equal(config.VulnerabilityCountLimit))
+			Expect(config.Generation()).To(Equal(int64(2)))
+		})
+	})
+
+	Context("when an invalid ConfigMap is applied", func() {
+		BeforeEach(func() {
+			_, err := config.Apply(map[string]string{"vulnerabilityCountLimit": "10"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		DescribeTable("should reject the configuration and keep the active one",
+			func(data map[string]string, message string) {
+				previous := config.Active()
+
+				_, err := config.Apply(data)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(message))
+
+				Expect(config.Active()).To(BeIdenticalTo(previous))
+				Expect(config.Generation()).To(Equal(int64(1)))
+			},
+			Entry("unknown key", map[string]string{"gitImage": "foo"}, "gitImage: unknown setting"),
+			Entry("invalid duration", map[string]string{"contextTimeout": "ten seconds"}, "contextTimeout"),
+			Entry("negative duration", map[string]string{"contextTimeout": "-5s"}, "contextTimeout: must be positive"),
+			Entry("invalid boolean", map[string]string{"gitRewriteRule": "maybe"}, "gitRewriteRule"),
+			Entry("negative limit", map[string]string{"vulnerabilityCountLimit": "-1"}, "vulnerabilityCountLimit: must not be negative"),
+			Entry("unsorted buckets", map[string]string{"buildRunRampUpDurationBuckets": "1,3,2"}, "strictly increasing"),
+			Entry("unknown template field", map[string]string{"waiterContainerTemplate": "image: foo\nimagePulPolicy: Always\n"}, "waiterContainerTemplate"),
+			Entry("invalid pull policy", map[string]string{"archiveContainerTemplate": "imagePullPolicy: Sometimes\n"}, `unsupported imagePullPolicy "Sometimes"`),
+		)
+	})
+})
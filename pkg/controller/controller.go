@@ -22,11 +22,14 @@ import (
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/ctxlog"
 	"github.com/shipwright-io/build/pkg/reconciler/build"
+	"github.com/shipwright-io/build/pkg/reconciler/buildcachecleanup"
 	"github.com/shipwright-io/build/pkg/reconciler/buildlimitcleanup"
+	"github.com/shipwright-io/build/pkg/reconciler/buildquota"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrunttlcleanup"
 	"github.com/shipwright-io/build/pkg/reconciler/buildstrategy"
 	"github.com/shipwright-io/build/pkg/reconciler/clusterbuildstrategy"
+	"github.com/shipwright-io/build/pkg/reconciler/controllerconfig"
 )
 
 // NewManager add all the controllers to the manager and register the required schemes
@@ -49,6 +52,11 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 		return nil, err
 	}
 
+	cacheVolumeLabelExistsSelector, err := labels.Parse(buildv1beta1.LabelCacheVolume)
+	if err != nil {
+		return nil, err
+	}
+
 	options.Cache = cache.Options{
 		ByObject: map[client.Object]cache.ByObject{
 			&corev1.Pod{}: {
@@ -57,6 +65,9 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 			&pipelineapi.TaskRun{}: {
 				Label: buildRunLabelExistsSelector,
 			},
+			&corev1.PersistentVolumeClaim{}: {
+				Label: cacheVolumeLabelExistsSelector,
+			},
 		},
 	}
 
@@ -99,5 +110,17 @@ func NewManager(ctx context.Context, config *config.Config, cfg *rest.Config, op
 		return nil, err
 	}
 
+	if err := buildcachecleanup.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := buildquota.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
+	if err := controllerconfig.Add(ctx, config, mgr); err != nil {
+		return nil, err
+	}
+
 	return mgr, nil
 }
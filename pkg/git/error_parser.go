@@ -49,6 +49,19 @@ const (
 	RepositoryNotFound
 	// AuthPrompted is caused when a repo is not found, is private and authentication is insufficient
 	AuthPrompted
+	// TLSCertificate expresses that the TLS handshake with the Git server failed, e.g. because of an untrusted or
+	// expired server certificate.
+	TLSCertificate
+	// DNSResolution expresses that the hostname of the Git server could not be resolved.
+	DNSResolution
+	// ConnectionReset expresses that the connection to the Git server was interrupted or refused.
+	ConnectionReset
+	// RateLimited expresses that the Git server rejected the request due to rate limiting (HTTP 429).
+	RateLimited
+	// ServerError expresses that the Git server responded with an internal error (HTTP 5xx).
+	ServerError
+	// DiskFull expresses that there is no space left on the device to write the clone to.
+	DiskFull
 )
 
 type rawToken struct {
@@ -100,6 +113,18 @@ func (class ErrorClass) String() string {
 		return "GitSSHAuthExpected"
 	case AuthUnexpectedHTTP:
 		return "AuthUnexpectedHTTP"
+	case TLSCertificate:
+		return "GitTLSCertificateError"
+	case DNSResolution:
+		return "GitDNSResolutionFailed"
+	case ConnectionReset:
+		return "GitConnectionReset"
+	case RateLimited:
+		return "GitRateLimited"
+	case ServerError:
+		return "GitServerError"
+	case DiskFull:
+		return "GitDiskFull"
 	}
 
 	return "GitError"
@@ -126,11 +151,34 @@ func (class ErrorClass) ToMessage() string {
 		return "Basic Auth incomplete: Both username and password need to be configured."
 	case AuthUnexpectedHTTP:
 		return "Refusing to continue with basic authentication (username and password) over insecure HTTP connection"
+	case TLSCertificate:
+		return "The TLS certificate of the Git server could not be verified. Check that the server certificate is valid and its certificate authority is trusted."
+	case DNSResolution:
+		return "The hostname of the Git server could not be resolved. Check the URL, or retry in case of a temporary DNS problem."
+	case ConnectionReset:
+		return "The connection to the Git server was interrupted. This is likely a temporary network problem, retry the BuildRun."
+	case RateLimited:
+		return "The Git server rejected the request due to rate limiting. Retry the BuildRun later, or use credentials with a higher rate limit."
+	case ServerError:
+		return "The Git server responded with an internal error. This is likely a temporary problem on the server side, retry the BuildRun."
+	case DiskFull:
+		return "There is no space left on the device to clone the repository into. Check the size of the repository and the available storage."
 	}
 
 	return "Git encountered an unknown error."
 }
 
+// IsTransient returns whether the error class describes a failure that may go away
+// when the same Git operation is repeated, for example a network hiccup
+func (class ErrorClass) IsTransient() bool {
+	switch class {
+	case DNSResolution, ConnectionReset, RateLimited, ServerError:
+		return true
+	}
+
+	return false
+}
+
 func (token errorToken) String() string {
 	return token.prefixToken.String() + ": " + token.classToken.String()
 }
@@ -214,6 +262,44 @@ func isBranchNotFound(raw string) bool {
 	return strings.Contains(raw, "remote branch") && strings.Contains(raw, "not found")
 }
 
+func isTLSCertificate(raw string) bool {
+	return strings.Contains(raw, "ssl certificate problem") ||
+		strings.Contains(raw, "server certificate verification failed") ||
+		strings.Contains(raw, "ssl_connect") ||
+		strings.Contains(raw, "certificate verify failed")
+}
+
+func isDNSResolution(raw string) bool {
+	return strings.Contains(raw, "could not resolve host") ||
+		strings.Contains(raw, "could not resolve hostname") ||
+		strings.Contains(raw, "temporary failure in name resolution")
+}
+
+func isConnectionReset(raw string) bool {
+	return strings.Contains(raw, "connection reset") ||
+		strings.Contains(raw, "connection refused") ||
+		strings.Contains(raw, "connection timed out") ||
+		strings.Contains(raw, "the remote end hung up unexpectedly") ||
+		strings.Contains(raw, "early eof") ||
+		strings.Contains(raw, "unexpected disconnect")
+}
+
+func isRateLimited(raw string) bool {
+	return strings.Contains(raw, "returned error: 429") ||
+		strings.Contains(raw, "http 429") ||
+		strings.Contains(raw, "rate limit")
+}
+
+func isServerError(raw string) bool {
+	isMatch, _ := regexp.Match(`(returned error: |http )5\d\d`, []byte(raw))
+
+	return isMatch
+}
+
+func isDiskFull(raw string) bool {
+	return strings.Contains(raw, "no space left on device")
+}
+
 func parseErrorMessage(raw string) errorClassToken {
 	errorClass := Unknown
 	toCheck := strings.ToLower(strings.TrimSpace(raw))
@@ -229,6 +315,18 @@ func parseErrorMessage(raw string) errorClassToken {
 		errorClass = RepositoryNotFound
 	case isBranchNotFound(toCheck):
 		errorClass = RevisionNotFound
+	case isDiskFull(toCheck):
+		errorClass = DiskFull
+	case isTLSCertificate(toCheck):
+		errorClass = TLSCertificate
+	case isDNSResolution(toCheck):
+		errorClass = DNSResolution
+	case isRateLimited(toCheck):
+		errorClass = RateLimited
+	case isServerError(toCheck):
+		errorClass = ServerError
+	case isConnectionReset(toCheck):
+		errorClass = ConnectionReset
 	}
 
 	return errorClassToken{errorClass, rawToken{
@@ -243,6 +341,8 @@ func classifyTokensWithRemotePrefix(tokens []errorToken) ErrorClass {
 			return AuthInvalidUserOrPass
 		case RepositoryNotFound:
 			return RepositoryNotFound
+		case RateLimited:
+			return RateLimited
 		}
 	}
 
@@ -250,9 +350,16 @@ func classifyTokensWithRemotePrefix(tokens []errorToken) ErrorClass {
 }
 
 func classifyTokensWithErrorPrefix(tokens []errorToken) ErrorClass {
-	for _, remoteToken := range tokens {
-		if remoteToken.classToken.class == RepositoryNotFound {
+	for _, errorToken := range tokens {
+		switch errorToken.classToken.class {
+		case RepositoryNotFound:
 			return RepositoryNotFound
+		case DiskFull:
+			return DiskFull
+		case RateLimited:
+			return RateLimited
+		case ServerError:
+			return ServerError
 		}
 	}
 
@@ -270,6 +377,24 @@ func classifyTokensWithFatalPrefix(tokens []errorToken) ErrorClass {
 			return RevisionNotFound
 		case AuthInvalidUserOrPass:
 			return AuthInvalidUserOrPass
+		case DiskFull:
+			return DiskFull
+		case TLSCertificate:
+			return TLSCertificate
+		case DNSResolution:
+			return DNSResolution
+		case RateLimited:
+			return RateLimited
+		case ServerError:
+			return ServerError
+		}
+	}
+
+	// connection problems are often reported as a follow-up of a more
+	// specific error, therefore only consider them as a last resort
+	for _, fatalToken := range tokens {
+		if fatalToken.classToken.class == ConnectionReset {
+			return ConnectionReset
 		}
 	}
 
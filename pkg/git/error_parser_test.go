@@ -51,6 +51,30 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			parsed := parseErrorMessage("Repository not found.")
 			Expect(parsed.class).To(Equal(RepositoryNotFound))
 		})
+		It("should recognize certificate problems", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/repo/': SSL certificate problem: self signed certificate")
+			Expect(parsed.class).To(Equal(TLSCertificate))
+		})
+		It("should recognize failed name resolution", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/repo/': Could not resolve host: git.example.com")
+			Expect(parsed.class).To(Equal(DNSResolution))
+		})
+		It("should recognize an interrupted connection", func() {
+			parsed := parseErrorMessage("the remote end hung up unexpectedly")
+			Expect(parsed.class).To(Equal(ConnectionReset))
+		})
+		It("should recognize rate limiting", func() {
+			parsed := parseErrorMessage("unable to access 'https://git.example.com/repo/': The requested URL returned error: 429")
+			Expect(parsed.class).To(Equal(RateLimited))
+		})
+		It("should recognize server errors", func() {
+			parsed := parseErrorMessage("RPC failed; HTTP 502 curl 22 The requested URL returned error: 502")
+			Expect(parsed.class).To(Equal(ServerError))
+		})
+		It("should recognize a full disk", func() {
+			parsed := parseErrorMessage("unable to write file src/main.go: No space left on device")
+			Expect(parsed.class).To(Equal(DiskFull))
+		})
 		It("should not be able to specify exact error class for unknown message type", func() {
 			parsed := parseErrorMessage("Something went wrong")
 			Expect(parsed.class).To(Equal(Unknown))
@@ -63,4 +87,36 @@ var _ = Describe("Parsing Git Error Messages", func() {
 			Expect(errorResult.Reason.String()).To(Equal(RepositoryNotFound.String()))
 		})
 	})
+	Context("classify transient errors", func() {
+		It("should prefer a server error over the follow-up connection error", func() {
+			errorResult := NewErrorResultFromMessage("error: RPC failed; HTTP 503 curl 22 The requested URL returned error: 503\nfatal: the remote end hung up unexpectedly")
+			Expect(errorResult.Reason).To(Equal(ServerError))
+			Expect(errorResult.Reason.IsTransient()).To(BeTrue())
+		})
+		It("should classify a reset connection", func() {
+			errorResult := NewErrorResultFromMessage("error: RPC failed; curl 56 Recv failure: Connection reset by peer\nfatal: early EOF\nfatal: fetch-pack: invalid index-pack output")
+			Expect(errorResult.Reason).To(Equal(ConnectionReset))
+			Expect(errorResult.Reason.IsTransient()).To(BeTrue())
+		})
+		It("should classify a DNS failure as transient", func() {
+			errorResult := NewErrorResultFromMessage("fatal: unable to access 'https://git.example.com/repo/': Could not resolve host: git.example.com")
+			Expect(errorResult.Reason).To(Equal(DNSResolution))
+			Expect(errorResult.Reason.String()).To(Equal("GitDNSResolutionFailed"))
+			Expect(errorResult.Reason.IsTransient()).To(BeTrue())
+		})
+		It("should not classify certificate problems as transient", func() {
+			errorResult := NewErrorResultFromMessage("fatal: unable to access 'https://git.example.com/repo/': server certificate verification failed. CAfile: none CRLfile: none")
+			Expect(errorResult.Reason).To(Equal(TLSCertificate))
+			Expect(errorResult.Reason.IsTransient()).To(BeFalse())
+		})
+		It("should not classify a full disk as transient", func() {
+			errorResult := NewErrorResultFromMessage("fatal: write error: No space left on device")
+			Expect(errorResult.Reason).To(Equal(DiskFull))
+			Expect(errorResult.Reason.IsTransient()).To(BeFalse())
+		})
+		It("should not classify authentication problems as transient", func() {
+			Expect(AuthInvalidUserOrPass.IsTransient()).To(BeFalse())
+			Expect(AuthPrompted.IsTransient()).To(BeFalse())
+		})
+	})
 })
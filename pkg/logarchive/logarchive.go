@@ -0,0 +1,262 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logarchive archives the logs of the steps of completed BuildRuns, so
+// that they remain available after the pod of the BuildRun was deleted.
+package logarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/image"
+)
+
+const (
+	// maxLogBytes limits the size of the log of a single step that is archived
+	maxLogBytes int64 = 10 * 1024 * 1024
+
+	// stepContainerPrefix is the prefix that Tekton uses for the containers of steps
+	stepContainerPrefix = "step-"
+
+	userAgent = "shipwright-build-controller"
+
+	// MaxAttempts is the number of times that the archival of the logs of a BuildRun is attempted
+	MaxAttempts = 5
+)
+
+var (
+	// retryDelay is the delay before a failed archival is retried, it doubles for every further attempt
+	retryDelay = 10 * time.Second
+
+	// maxRetryDelay is the maximum delay before a failed archival is retried
+	maxRetryDelay = 10 * time.Minute
+)
+
+// StepLog is the log of a step
+type StepLog struct {
+	Step string
+	Log  []byte
+}
+
+// PodLogs returns the log of a container of a pod
+type PodLogs func(ctx context.Context, namespace string, pod string, container string) ([]byte, error)
+
+// NewPodLogs returns PodLogs that read the logs using the Kubernetes API. The client
+// is created with the first read.
+func NewPodLogs(restConfig *rest.Config) PodLogs {
+	var (
+		once      sync.Once
+		clientset kubernetes.Interface
+		err       error
+	)
+
+	return func(ctx context.Context, namespace string, pod string, container string) ([]byte, error) {
+		once.Do(func() {
+			if restConfig == nil {
+				err = errors.New("no configuration to access the Kubernetes API")
+				return
+			}
+
+			clientset, err = kubernetes.NewForConfig(restConfig)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+			Container:  container,
+			LimitBytes: ptr.To(maxLogBytes),
+		}).DoRaw(ctx)
+	}
+}
+
+// Collect returns the logs of the steps of the pod, in the order of the steps
+func Collect(ctx context.Context, pod *corev1.Pod, podLogs PodLogs) ([]StepLog, error) {
+	var stepLogs []StepLog
+	for _, container := range pod.Spec.Containers {
+		if !strings.HasPrefix(container.Name, stepContainerPrefix) {
+			continue
+		}
+
+		log, err := podLogs(ctx, pod.Namespace, pod.Name, container.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the log of container %s: %w", container.Name, err)
+		}
+
+		stepLogs = append(stepLogs, StepLog{
+			Step: strings.TrimPrefix(container.Name, stepContainerPrefix),
+			Log:  log,
+		})
+	}
+
+	return stepLogs, nil
+}
+
+// Archive stores the step logs of the BuildRun in the configured repository and directory,
+// and returns where they were stored
+func Archive(ctx context.Context, cfg config.LogArchiveConfig, buildRun *buildv1beta1.BuildRun, stepLogs []StepLog) *buildv1beta1.LogArchiveResult {
+	result := &buildv1beta1.LogArchiveResult{Time: metav1.Now()}
+
+	var errs []error
+	if cfg.Repository != "" {
+		reference, err := push(ctx, cfg, buildRun, stepLogs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to push the logs to %s: %w", cfg.Repository, err))
+		}
+		result.Image = reference
+	}
+
+	if cfg.Directory != "" {
+		directory, err := write(cfg.Directory, buildRun, stepLogs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to write the logs to %s: %w", cfg.Directory, err))
+		}
+		result.Directory = directory
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// RetryIn returns whether the last archival of the logs of the BuildRun failed and is
+// retried, and the duration after which the retry is due. The delay doubles for every
+// attempt, and the archival is not retried anymore after the maximum number of attempts.
+func RetryIn(buildRun *buildv1beta1.BuildRun) (time.Duration, bool) {
+	last := buildRun.Status.LogArchive
+	if last == nil || last.Error == "" || last.Attempts >= MaxAttempts {
+		return 0, false
+	}
+
+	delay := min(retryDelay<<max(last.Attempts-1, 0), maxRetryDelay)
+	return time.Until(last.Time.Add(delay)), true
+}
+
+// push pushes the step logs as single layer image, and returns its digest reference
+func push(ctx context.Context, cfg config.LogArchiveConfig, buildRun *buildv1beta1.BuildRun, stepLogs []StepLog) (string, error) {
+	ref, err := name.ParseReference(fmt.Sprintf("%s:%s", cfg.Repository, tag(buildRun)))
+	if err != nil {
+		return "", err
+	}
+
+	content, err := tarOf(stepLogs, buildRun.Status.CompletionTime)
+	if err != nil {
+		return "", err
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return "", err
+	}
+
+	img, _, err = image.MutateImageOrImageIndex(img, nil, map[string]string{
+		"io.shipwright.buildrun.namespace": buildRun.Namespace,
+		"io.shipwright.buildrun.name":      buildRun.Name,
+		"io.shipwright.build.name":         buildRun.Spec.BuildName(),
+	}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	options, _, err := image.GetOptions(ctx, ref, cfg.Insecure, cfg.DockerConfigPath, userAgent)
+	if err != nil {
+		return "", err
+	}
+
+	digest, _, err := image.PushImageOrImageIndex(ref, img, nil, options)
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Context().Digest(digest).String(), nil
+}
+
+// write writes the step logs into a directory for the BuildRun, and returns the directory
+func write(root string, buildRun *buildv1beta1.BuildRun, stepLogs []StepLog) (string, error) {
+	directory := filepath.Join(root, buildRun.Namespace, buildRun.Name)
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return "", err
+	}
+
+	for _, stepLog := range stepLogs {
+		if err := os.WriteFile(filepath.Join(directory, stepLog.Step+".log"), stepLog.Log, 0o644); err != nil { // #nosec G306 logs are meant to be read by auditors
+			return "", err
+		}
+	}
+
+	return directory, nil
+}
+
+// tarOf returns a tar archive with a <step>.log file for every step
+func tarOf(stepLogs []StepLog, modTime *metav1.Time) ([]byte, error) {
+	timestamp := time.Unix(0, 0)
+	if modTime != nil {
+		timestamp = modTime.Time
+	}
+
+	var buf bytes.Buffer
+	writer := tar.NewWriter(&buf)
+	for _, stepLog := range stepLogs {
+		if err := writer.WriteHeader(&tar.Header{
+			Name:     stepLog.Step + ".log",
+			Mode:     0o644,
+			Size:     int64(len(stepLog.Log)),
+			ModTime:  timestamp,
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return nil, err
+		}
+
+		if _, err := writer.Write(stepLog.Log); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// tag returns the image tag for the BuildRun, the name of the BuildRun is used if it
+// fits into the 128 characters of a tag, otherwise the UID
+func tag(buildRun *buildv1beta1.BuildRun) string {
+	if tag := fmt.Sprintf("%s.%s", buildRun.Namespace, buildRun.Name); len(tag) <= 128 {
+		return tag
+	}
+
+	return string(buildRun.UID)
+}
@@ -0,0 +1,158 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logarchive_test
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/logarchive"
+)
+
+var _ = Describe("Log archive", func() {
+	var (
+		ctx      context.Context
+		buildRun *buildv1beta1.BuildRun
+		stepLogs []logarchive.StepLog
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		buildRun = &buildv1beta1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "builds", Name: "sample-go-abcde"},
+		}
+
+		stepLogs = []logarchive.StepLog{
+			{Step: "source-default", Log: []byte("Successfully loaded https://github.com/shipwright-io/sample-go\n")},
+			{Step: "build-and-push", Log: []byte("STEP 1/4: FROM golang\n")},
+		}
+	})
+
+	Context("Collect", func() {
+		It("collects the logs of the step containers in order", func() {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "builds", Name: "sample-go-abcde-pod"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "step-source-default"},
+						{Name: "sidecar-registry"},
+						{Name: "step-build-and-push"},
+					},
+				},
+			}
+
+			collected, err := logarchive.Collect(ctx, pod, func(_ context.Context, namespace string, pod string, container string) ([]byte, error) {
+				return []byte(fmt.Sprintf("%s/%s/%s", namespace, pod, container)), nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(collected).To(Equal([]logarchive.StepLog{
+				{Step: "source-default", Log: []byte("builds/sample-go-abcde-pod/step-source-default")},
+				{Step: "build-and-push", Log: []byte("builds/sample-go-abcde-pod/step-build-and-push")},
+			}))
+		})
+
+		It("fails if a log cannot be read", func() {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-build"}}}}
+
+			_, err := logarchive.Collect(ctx, pod, func(context.Context, string, string, string) ([]byte, error) {
+				return nil, errors.New("pod not found")
+			})
+			Expect(err).To(MatchError(ContainSubstring("pod not found")))
+		})
+	})
+
+	Context("Archive", func() {
+		It("writes the logs into a directory of the BuildRun", func() {
+			root := GinkgoT().TempDir()
+
+			result := logarchive.Archive(ctx, config.LogArchiveConfig{Directory: root}, buildRun, stepLogs)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.Directory).To(Equal(filepath.Join(root, "builds", "sample-go-abcde")))
+
+			content, err := os.ReadFile(filepath.Join(result.Directory, "build-and-push.log"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("STEP 1/4: FROM golang\n"))
+		})
+
+		It("pushes the logs as OCI artifact", func() {
+			server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+			DeferCleanup(server.Close)
+			repository := strings.TrimPrefix(server.URL, "http://") + "/build-logs"
+
+			result := logarchive.Archive(ctx, config.LogArchiveConfig{Repository: repository, Insecure: true}, buildRun, stepLogs)
+			Expect(result.Error).To(BeEmpty())
+			Expect(result.Image).To(HavePrefix(repository + "@sha256:"))
+
+			ref, err := name.ParseReference(result.Image)
+			Expect(err).ToNot(HaveOccurred())
+			img, err := remote.Image(ref)
+			Expect(err).ToNot(HaveOccurred())
+
+			reader := tar.NewReader(mutate.Extract(img))
+			var files []string
+			for {
+				header, err := reader.Next()
+				if err == io.EOF {
+					break
+				}
+				Expect(err).ToNot(HaveOccurred())
+				files = append(files, header.Name)
+			}
+			Expect(files).To(Equal([]string{"source-default.log", "build-and-push.log"}))
+		})
+
+		It("records why the logs could not be archived", func() {
+			result := logarchive.Archive(ctx, config.LogArchiveConfig{Repository: "registry.example.com/UPPERCASE"}, buildRun, stepLogs)
+			Expect(result.Error).ToNot(BeEmpty())
+			Expect(result.Image).To(BeEmpty())
+		})
+	})
+
+	Context("RetryIn", func() {
+		It("retries a failed archival with a growing delay", func() {
+			buildRun.Status.LogArchive = &buildv1beta1.LogArchiveResult{
+				Time:     metav1.NewTime(time.Now().Add(-time.Minute)),
+				Error:    "failed to get the log of container step-build-and-push",
+				Attempts: 3,
+			}
+
+			wait, retry := logarchive.RetryIn(buildRun)
+			Expect(retry).To(BeTrue())
+			Expect(wait).To(BeNumerically("~", -20*time.Second, time.Second))
+		})
+
+		It("does not retry a successful archival or after the last attempt", func() {
+			buildRun.Status.LogArchive = &buildv1beta1.LogArchiveResult{Time: metav1.Now(), Directory: "builds/sample-go-abcde", Attempts: 1}
+			_, retry := logarchive.RetryIn(buildRun)
+			Expect(retry).To(BeFalse())
+
+			buildRun.Status.LogArchive = &buildv1beta1.LogArchiveResult{Time: metav1.Now(), Error: "failed", Attempts: logarchive.MaxAttempts}
+			_, retry = logarchive.RetryIn(buildRun)
+			Expect(retry).To(BeFalse())
+		})
+	})
+})
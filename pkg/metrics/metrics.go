@@ -6,6 +6,8 @@ package metrics
 
 import (
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -19,6 +21,8 @@ const (
 	NamespaceLabel     string = "namespace"
 	BuildLabel         string = "build"
 	BuildRunLabel      string = "buildrun"
+	StepLabel          string = "step"
+	StepOriginLabel    string = "origin"
 )
 
 var (
@@ -32,6 +36,15 @@ var (
 	taskRunRampUpDuration    *prometheus.HistogramVec
 	taskRunPodRampUpDuration *prometheus.HistogramVec
 
+	buildRunStepDuration *prometheus.HistogramVec
+
+	// histogramsMutex guards the histograms which are replaced when their buckets change
+	histogramsMutex sync.RWMutex
+	buildRunLabels  []string
+	activeBuckets   config.PrometheusConfig
+
+	configGeneration prometheus.Gauge
+
 	buildStrategyLabelEnabled = false
 	namespaceLabelEnabled     = false
 	buildLabelEnabled         = false
@@ -52,7 +65,6 @@ func InitPrometheus(config *config.Config) {
 	initialized = true
 
 	var buildLabels []string
-	var buildRunLabels []string
 	if contains(config.Prometheus.EnabledLabels, BuildStrategyLabel) {
 		buildLabels = append(buildLabels, BuildStrategyLabel)
 		buildRunLabels = append(buildRunLabels, BuildStrategyLabel)
@@ -87,56 +99,126 @@ func InitPrometheus(config *config.Config) {
 		},
 		buildRunLabels)
 
-	buildRunEstablishDuration = prometheus.NewHistogramVec(
+	buildRunEstablishDuration = newBuildRunEstablishDuration(config.Prometheus.BuildRunEstablishDurationBuckets)
+	buildRunCompletionDuration = newBuildRunCompletionDuration(config.Prometheus.BuildRunCompletionDurationBuckets)
+	buildRunRampUpDuration = newBuildRunRampUpDuration(config.Prometheus.BuildRunRampUpDurationBuckets)
+	taskRunRampUpDuration = newTaskRunRampUpDuration(config.Prometheus.BuildRunRampUpDurationBuckets)
+	taskRunPodRampUpDuration = newTaskRunPodRampUpDuration(config.Prometheus.BuildRunRampUpDurationBuckets)
+	buildRunStepDuration = newBuildRunStepDuration(config.Prometheus.BuildRunStepDurationBuckets)
+	activeBuckets = config.Prometheus
+
+	configGeneration = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "build_config_generation",
+			Help: "Generation of the active controller configuration, increased whenever the configuration is reloaded.",
+		})
+
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(
+		buildCount,
+		buildRunCount,
+		buildRunEstablishDuration,
+		buildRunCompletionDuration,
+		buildRunRampUpDuration,
+		taskRunRampUpDuration,
+		taskRunPodRampUpDuration,
+		buildRunStepDuration,
+		configGeneration,
+	)
+}
+
+func newBuildRunEstablishDuration(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "build_buildrun_establish_duration_seconds",
 			Help:    "BuildRun establish duration in seconds.",
-			Buckets: config.Prometheus.BuildRunEstablishDurationBuckets,
+			Buckets: buckets,
 		},
 		buildRunLabels)
+}
 
-	buildRunCompletionDuration = prometheus.NewHistogramVec(
+func newBuildRunCompletionDuration(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "build_buildrun_completion_duration_seconds",
 			Help:    "BuildRun completion duration in seconds.",
-			Buckets: config.Prometheus.BuildRunCompletionDurationBuckets,
+			Buckets: buckets,
 		},
 		buildRunLabels)
+}
 
-	buildRunRampUpDuration = prometheus.NewHistogramVec(
+func newBuildRunRampUpDuration(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "build_buildrun_rampup_duration_seconds",
 			Help:    "BuildRun ramp-up duration in seconds (time between buildrun creation and taskrun creation).",
-			Buckets: config.Prometheus.BuildRunRampUpDurationBuckets,
+			Buckets: buckets,
 		},
 		buildRunLabels)
+}
 
-	taskRunRampUpDuration = prometheus.NewHistogramVec(
+func newTaskRunRampUpDuration(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "build_buildrun_taskrun_rampup_duration_seconds",
 			Help:    "BuildRun taskrun ramp-up duration in seconds (time between taskrun creation and taskrun pod creation).",
-			Buckets: config.Prometheus.BuildRunRampUpDurationBuckets,
+			Buckets: buckets,
 		},
 		buildRunLabels)
+}
 
-	taskRunPodRampUpDuration = prometheus.NewHistogramVec(
+func newTaskRunPodRampUpDuration(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "build_buildrun_taskrun_pod_rampup_duration_seconds",
 			Help:    "BuildRun taskrun pod ramp-up duration in seconds (time between pod creation and last init container completion).",
-			Buckets: config.Prometheus.BuildRunRampUpDurationBuckets,
+			Buckets: buckets,
 		},
 		buildRunLabels)
+}
 
-	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(
-		buildCount,
-		buildRunCount,
-		buildRunEstablishDuration,
-		buildRunCompletionDuration,
-		buildRunRampUpDuration,
-		taskRunRampUpDuration,
-		taskRunPodRampUpDuration,
-	)
+func newBuildRunStepDuration(buckets []float64) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "build_buildrun_step_duration_seconds",
+			Help:    "BuildRun step duration in seconds (time between step start and step termination).",
+			Buckets: buckets,
+		},
+		append(slices.Clone(buildRunLabels), StepLabel, StepOriginLabel))
+}
+
+// UpdateConfig applies a reloaded configuration: the config generation is recorded,
+// and histograms whose buckets changed are replaced. A replaced histogram starts
+// empty, because Prometheus does not support to change the buckets of a histogram.
+func UpdateConfig(config *config.Config) {
+	if !initialized {
+		return
+	}
+
+	configGeneration.Set(float64(config.Generation()))
+
+	histogramsMutex.Lock()
+	defer histogramsMutex.Unlock()
+
+	replace := func(histogram **prometheus.HistogramVec, oldBuckets []float64, newBuckets []float64, create func([]float64) *prometheus.HistogramVec) {
+		if slices.Equal(oldBuckets, newBuckets) {
+			return
+		}
+
+		replacement := create(newBuckets)
+		metrics.Registry.Unregister(*histogram)
+		metrics.Registry.MustRegister(replacement)
+		*histogram = replacement
+	}
+
+	replace(&buildRunEstablishDuration, activeBuckets.BuildRunEstablishDurationBuckets, config.Prometheus.BuildRunEstablishDurationBuckets, newBuildRunEstablishDuration)
+	replace(&buildRunCompletionDuration, activeBuckets.BuildRunCompletionDurationBuckets, config.Prometheus.BuildRunCompletionDurationBuckets, newBuildRunCompletionDuration)
+	replace(&buildRunRampUpDuration, activeBuckets.BuildRunRampUpDurationBuckets, config.Prometheus.BuildRunRampUpDurationBuckets, newBuildRunRampUpDuration)
+	replace(&taskRunRampUpDuration, activeBuckets.BuildRunRampUpDurationBuckets, config.Prometheus.BuildRunRampUpDurationBuckets, newTaskRunRampUpDuration)
+	replace(&taskRunPodRampUpDuration, activeBuckets.BuildRunRampUpDurationBuckets, config.Prometheus.BuildRunRampUpDurationBuckets, newTaskRunPodRampUpDuration)
+	replace(&buildRunStepDuration, activeBuckets.BuildRunStepDurationBuckets, config.Prometheus.BuildRunStepDurationBuckets, newBuildRunStepDuration)
+
+	activeBuckets = config.Prometheus
 }
 
 // ExtraHandlers returns a mapping of paths and their respective
@@ -205,6 +287,9 @@ func BuildRunCountInc(buildStrategy string, namespace string, build string, buil
 
 // BuildRunEstablishObserve sets the build run establish time
 func BuildRunEstablishObserve(buildStrategy string, namespace string, build string, buildRun string, duration time.Duration) {
+	histogramsMutex.RLock()
+	defer histogramsMutex.RUnlock()
+
 	if buildRunEstablishDuration != nil {
 		buildRunEstablishDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
 	}
@@ -212,6 +297,9 @@ func BuildRunEstablishObserve(buildStrategy string, namespace string, build stri
 
 // BuildRunCompletionObserve sets the build run completion time
 func BuildRunCompletionObserve(buildStrategy string, namespace string, build string, buildRun string, duration time.Duration) {
+	histogramsMutex.RLock()
+	defer histogramsMutex.RUnlock()
+
 	if buildRunCompletionDuration != nil {
 		buildRunCompletionDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
 	}
@@ -219,6 +307,9 @@ func BuildRunCompletionObserve(buildStrategy string, namespace string, build str
 
 // BuildRunRampUpDurationObserve processes the observation of a new buildrun ramp-up duration
 func BuildRunRampUpDurationObserve(buildStrategy string, namespace string, build string, buildRun string, duration time.Duration) {
+	histogramsMutex.RLock()
+	defer histogramsMutex.RUnlock()
+
 	if buildRunRampUpDuration != nil {
 		buildRunRampUpDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
 	}
@@ -226,6 +317,9 @@ func BuildRunRampUpDurationObserve(buildStrategy string, namespace string, build
 
 // TaskRunRampUpDurationObserve processes the observation of a new taskrun ramp-up duration
 func TaskRunRampUpDurationObserve(buildStrategy string, namespace string, build string, buildRun string, duration time.Duration) {
+	histogramsMutex.RLock()
+	defer histogramsMutex.RUnlock()
+
 	if taskRunRampUpDuration != nil {
 		taskRunRampUpDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
 	}
@@ -233,7 +327,23 @@ func TaskRunRampUpDurationObserve(buildStrategy string, namespace string, build
 
 // TaskRunPodRampUpDurationObserve processes the observation of a new taskrun pod ramp-up duration
 func TaskRunPodRampUpDurationObserve(buildStrategy string, namespace string, build string, buildRun string, duration time.Duration) {
+	histogramsMutex.RLock()
+	defer histogramsMutex.RUnlock()
+
 	if taskRunPodRampUpDuration != nil {
 		taskRunPodRampUpDuration.With(createBuildRunLabels(buildStrategy, namespace, build, buildRun)).Observe(duration.Seconds())
 	}
 }
+
+// BuildRunStepDurationObserve processes the observation of a finished step of a buildrun
+func BuildRunStepDurationObserve(buildStrategy string, namespace string, build string, buildRun string, step string, origin string, duration time.Duration) {
+	histogramsMutex.RLock()
+	defer histogramsMutex.RUnlock()
+
+	if buildRunStepDuration != nil {
+		labels := createBuildRunLabels(buildStrategy, namespace, build, buildRun)
+		labels[StepLabel] = step
+		labels[StepOriginLabel] = origin
+		buildRunStepDuration.With(labels).Observe(duration.Seconds())
+	}
+}
@@ -86,6 +86,7 @@ var _ = BeforeSuite(func() {
 			"build_buildrun_rampup_duration_seconds",
 			"build_buildrun_taskrun_rampup_duration_seconds",
 			"build_buildrun_taskrun_pod_rampup_duration_seconds",
+			"build_buildrun_step_duration_seconds",
 		}
 	)
 
@@ -115,6 +116,7 @@ var _ = BeforeSuite(func() {
 		BuildRunRampUpDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(1)*time.Second)
 		TaskRunRampUpDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(2)*time.Second)
 		TaskRunPodRampUpDurationObserve(buildStrategy, namespace, build, buildRun, time.Duration(3)*time.Second)
+		BuildRunStepDurationObserve(buildStrategy, namespace, build, buildRun, "build-and-push", "strategy", time.Duration(42)*time.Second)
 	}
 
 	// gather metrics from prometheus and fill the result maps
@@ -174,6 +176,11 @@ var _ = Describe("Custom Metrics", func() {
 			Expect(buildRunHistogramMetrics["build_buildrun_taskrun_rampup_duration_seconds"][buildRunLabels{"kaniko", "default", "kaniko-build", "kaniko-buildrun"}]).To(BeNumerically(">", 0.0))
 			Expect(buildRunHistogramMetrics["build_buildrun_taskrun_pod_rampup_duration_seconds"][buildRunLabels{"kaniko", "default", "kaniko-build", "kaniko-buildrun"}]).To(BeNumerically(">", 0.0))
 		})
+
+		It("should record the kaniko step duration", func() {
+			Expect(buildRunHistogramMetrics).To(HaveKey("build_buildrun_step_duration_seconds"))
+			Expect(buildRunHistogramMetrics["build_buildrun_step_duration_seconds"][buildRunLabels{"kaniko", "default", "kaniko-build", "kaniko-buildrun"}]).To(Equal(42.0))
+		})
 	})
 
 	Context("when create a new buildpacks buildrun", func() {
@@ -209,3 +216,38 @@ var _ = Describe("Custom Metrics", func() {
 		})
 	})
 })
+
+var _ = Describe("Reloaded configuration", func() {
+	Context("when the histogram buckets change", func() {
+		It("should record the config generation and use the new buckets", func() {
+			base := config.NewDefaultConfig()
+			active, err := base.Apply(map[string]string{
+				"buildRunEstablishDurationBuckets": "1,5,25",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			UpdateConfig(active)
+			BuildRunEstablishObserve("kaniko", "default", "kaniko-build", "kaniko-buildrun", 3*time.Second)
+
+			metrics, err := crmetrics.Registry.Gather()
+			Expect(err).ToNot(HaveOccurred())
+
+			var generation float64
+			var upperBounds []float64
+			for _, metricFamily := range metrics {
+				switch metricFamily.GetName() {
+				case "build_config_generation":
+					generation = metricFamily.GetMetric()[0].GetGauge().GetValue()
+
+				case "build_buildrun_establish_duration_seconds":
+					for _, bucket := range metricFamily.GetMetric()[0].GetHistogram().GetBucket() {
+						upperBounds = append(upperBounds, bucket.GetUpperBound())
+					}
+				}
+			}
+
+			Expect(generation).To(Equal(1.0))
+			Expect(upperBounds).To(Equal([]float64{1, 5, 25}))
+		})
+	})
+})
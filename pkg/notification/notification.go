@@ -0,0 +1,386 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notification delivers the lifecycle events of BuildRuns as
+// CloudEvents to the HTTP endpoints that are configured for a Build or
+// for the namespace of the BuildRun.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap that defines the notifications
+	// for all BuildRuns in its namespace
+	ConfigMapName = "shipwright-build-notifications"
+
+	// ConfigMapKey is the key of the ConfigMap that holds the YAML list of notifications
+	ConfigMapKey = "notifications"
+
+	// SecretKeyHMAC is the key of the notification Secret that holds the HMAC key
+	SecretKeyHMAC = "hmacKey"
+
+	// HeaderSignature is the HTTP header that carries the HMAC-SHA256 signature of the payload
+	HeaderSignature = "X-Shipwright-Signature"
+
+	// EventTypePrefix is the prefix of the CloudEvents type, it is followed by the lower-case event
+	EventTypePrefix = "io.shipwright.buildrun."
+
+	contentTypeCloudEvent = "application/cloudevents+json; charset=UTF-8"
+)
+
+var (
+	// attempts is the number of delivery attempts for every event
+	attempts = 3
+
+	// retryDelay is the delay before the second attempt, it doubles for every further attempt
+	retryDelay = 10 * time.Second
+
+	httpClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// Data is the payload of the CloudEvents
+type Data struct {
+	BuildRun          string                       `json:"buildRun"`
+	Namespace         string                       `json:"namespace"`
+	Build             string                       `json:"build,omitempty"`
+	Reason            string                       `json:"reason,omitempty"`
+	Message           string                       `json:"message,omitempty"`
+	StartTime         *metav1.Time                 `json:"startTime,omitempty"`
+	CompletionTime    *metav1.Time                 `json:"completionTime,omitempty"`
+	Source            *buildv1beta1.SourceResult   `json:"source,omitempty"`
+	AdditionalSources []buildv1beta1.SourceResult  `json:"additionalSources,omitempty"`
+	Output            *buildv1beta1.Output         `json:"output,omitempty"`
+	FailureDetails    *buildv1beta1.FailureDetails `json:"failureDetails,omitempty"`
+}
+
+// cloudEvent is a CloudEvent in the structured content mode
+type cloudEvent struct {
+	SpecVersion     string    `json:"specversion"`
+	ID              string    `json:"id"`
+	Source          string    `json:"source"`
+	Type            string    `json:"type"`
+	Subject         string    `json:"subject"`
+	Time            time.Time `json:"time"`
+	DataContentType string    `json:"datacontenttype"`
+	Data            Data      `json:"data"`
+}
+
+// CompletionEvent returns the event that describes how the BuildRun completed
+func CompletionEvent(buildRun *buildv1beta1.BuildRun) buildv1beta1.NotificationEvent {
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+
+	switch {
+	case condition == nil || condition.Status == corev1.ConditionUnknown:
+		return ""
+
+	case condition.Status == corev1.ConditionTrue:
+		return buildv1beta1.NotificationEventSucceeded
+
+	case condition.Reason == buildv1beta1.BuildRunStateCancel:
+		return buildv1beta1.NotificationEventCanceled
+
+	default:
+		return buildv1beta1.NotificationEventFailed
+	}
+}
+
+// Schedule records a pending delivery of the event for every notification of the
+// BuildRun that is subscribed to it (mutates). An event that is already recorded for
+// a notification is not scheduled again, so that every event is delivered once.
+func Schedule(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun, event buildv1beta1.NotificationEvent) {
+	if event == "" {
+		return
+	}
+
+	for _, notification := range notifications(ctx, reader, buildRun) {
+		if !notification.Subscribed(event) || recorded(buildRun, notification.Name, event) {
+			continue
+		}
+
+		buildRun.Status.Notifications = append(buildRun.Status.Notifications, buildv1beta1.NotificationDelivery{
+			Name:  notification.Name,
+			Event: event,
+			State: buildv1beta1.NotificationDeliveryPending,
+		})
+	}
+}
+
+// Deliver makes one delivery attempt for every pending delivery of the BuildRun that
+// is due, and records the outcome (mutates). It returns whether a delivery was attempted,
+// and the duration after which the next pending delivery is due, which is zero if no
+// delivery is pending anymore. The attempts run in parallel, so that a slow endpoint
+// does not delay the others.
+func Deliver(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun) (bool, time.Duration) {
+	now := time.Now()
+
+	var due []int
+	for i, delivery := range buildRun.Status.Notifications {
+		if delivery.State == buildv1beta1.NotificationDeliveryPending && !dueAt(delivery).After(now) {
+			due = append(due, i)
+		}
+	}
+
+	if len(due) > 0 {
+		byName := map[string]buildv1beta1.Notification{}
+		for _, notification := range notifications(ctx, reader, buildRun) {
+			byName[notification.Name] = notification
+		}
+
+		outcomes := make([]buildv1beta1.NotificationDelivery, len(due))
+		var wg sync.WaitGroup
+		for j, i := range due {
+			outcomes[j] = buildRun.Status.Notifications[i]
+
+			notification, exists := byName[outcomes[j].Name]
+			if !exists {
+				outcomes[j].State = buildv1beta1.NotificationDeliveryFailed
+				outcomes[j].Message = "the notification does not exist anymore"
+				continue
+			}
+
+			body, key, err := payload(ctx, reader, buildRun, notification, outcomes[j].Event)
+			if err != nil {
+				outcomes[j].State = buildv1beta1.NotificationDeliveryFailed
+				outcomes[j].Message = err.Error()
+				continue
+			}
+
+			wg.Add(1)
+			go func(outcome *buildv1beta1.NotificationDelivery) {
+				defer wg.Done()
+				attempt(ctx, outcome, notification.URL, body, key)
+			}(&outcomes[j])
+		}
+		wg.Wait()
+
+		for j, i := range due {
+			if outcomes[j].State != buildv1beta1.NotificationDeliveryDelivered {
+				ctxlog.Info(ctx, "failed to deliver notification", "namespace", buildRun.Namespace, "name", buildRun.Name, "notification", outcomes[j].Name, "event", outcomes[j].Event, "attempts", outcomes[j].Attempts, "error", outcomes[j].Message)
+			}
+
+			buildRun.Status.Notifications[i] = outcomes[j]
+		}
+	}
+
+	var next time.Duration
+	for _, delivery := range buildRun.Status.Notifications {
+		if delivery.State != buildv1beta1.NotificationDeliveryPending {
+			continue
+		}
+
+		if wait := max(time.Until(dueAt(delivery)), time.Millisecond); next == 0 || wait < next {
+			next = wait
+		}
+	}
+
+	return len(due) > 0, next
+}
+
+// recorded returns whether the delivery of the event to the named notification is recorded
+func recorded(buildRun *buildv1beta1.BuildRun, name string, event buildv1beta1.NotificationEvent) bool {
+	for _, delivery := range buildRun.Status.Notifications {
+		if delivery.Name == name && delivery.Event == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dueAt returns when the next attempt of a delivery is due, the delay after a failed
+// attempt doubles for every further attempt
+func dueAt(delivery buildv1beta1.NotificationDelivery) time.Time {
+	if delivery.Time == nil || delivery.Attempts == 0 {
+		return time.Time{}
+	}
+
+	return delivery.Time.Add(retryDelay << (delivery.Attempts - 1))
+}
+
+// notifications returns the notifications of the Build of the BuildRun, followed by
+// the ones of the namespace that do not have the name of a notification of the Build
+func notifications(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun) []buildv1beta1.Notification {
+	var result []buildv1beta1.Notification
+	names := map[string]struct{}{}
+
+	if buildRun.Status.BuildSpec != nil {
+		for _, notification := range buildRun.Status.BuildSpec.Notifications {
+			result = append(result, notification)
+			names[notification.Name] = struct{}{}
+		}
+	}
+
+	var configMap corev1.ConfigMap
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: ConfigMapName}, &configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			ctxlog.Error(ctx, err, "failed to get the notifications of the namespace", "namespace", buildRun.Namespace)
+		}
+		return result
+	}
+
+	var namespaceNotifications []buildv1beta1.Notification
+	if err := yaml.Unmarshal([]byte(configMap.Data[ConfigMapKey]), &namespaceNotifications); err != nil {
+		ctxlog.Error(ctx, err, "failed to parse the notifications of the namespace", "namespace", buildRun.Namespace, "configmap", ConfigMapName)
+		return result
+	}
+
+	for _, notification := range namespaceNotifications {
+		if _, exists := names[notification.Name]; exists {
+			continue
+		}
+
+		// the notifications of the namespace are validated like the ones of a Build
+		if valid, _, message := validate.BuildNotifications([]buildv1beta1.Notification{notification}); !valid {
+			ctxlog.Info(ctx, "ignoring invalid notification of the namespace", "namespace", buildRun.Namespace, "configmap", ConfigMapName, "reason", message)
+			continue
+		}
+
+		result = append(result, notification)
+	}
+
+	return result
+}
+
+// payload returns the body of the event for a notification, and the key to sign it
+func payload(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun, notification buildv1beta1.Notification, event buildv1beta1.NotificationEvent) ([]byte, []byte, error) {
+	var key []byte
+	if notification.Secret != nil {
+		var secret corev1.Secret
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: *notification.Secret}, &secret); err != nil {
+			return nil, nil, fmt.Errorf("failed to get secret %s: %w", *notification.Secret, err)
+		}
+
+		if key = secret.Data[SecretKeyHMAC]; len(key) == 0 {
+			return nil, nil, fmt.Errorf("secret %s does not contain the %s key", *notification.Secret, SecretKeyHMAC)
+		}
+	}
+
+	body, err := json.Marshal(newCloudEvent(buildRun, event))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, key, nil
+}
+
+// attempt posts the body once, and records the outcome in the delivery (mutates)
+func attempt(ctx context.Context, delivery *buildv1beta1.NotificationDelivery, url string, body []byte, key []byte) {
+	retry, err := post(ctx, url, body, key)
+
+	delivery.Attempts++
+	delivery.Time = ptr.To(metav1.Now())
+	delivery.Message = ""
+
+	switch {
+	case err == nil:
+		delivery.State = buildv1beta1.NotificationDeliveryDelivered
+
+	case retry && delivery.Attempts < attempts:
+		delivery.State = buildv1beta1.NotificationDeliveryPending
+		delivery.Message = err.Error()
+
+	default:
+		delivery.State = buildv1beta1.NotificationDeliveryFailed
+		delivery.Message = err.Error()
+	}
+}
+
+// post sends the body once, and returns whether a failed delivery should be retried
+func post(ctx context.Context, url string, body []byte, key []byte) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	request.Header.Set("Content-Type", contentTypeCloudEvent)
+	if key != nil {
+		request.Header.Set(HeaderSignature, Signature(key, body))
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return true, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode < 300:
+		return false, nil
+
+	case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500:
+		return true, fmt.Errorf("endpoint responded with %s", response.Status)
+
+	default:
+		return false, fmt.Errorf("endpoint responded with %s", response.Status)
+	}
+}
+
+// Signature returns the value of the signature header for the body
+func Signature(key []byte, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newCloudEvent(buildRun *buildv1beta1.BuildRun, event buildv1beta1.NotificationEvent) cloudEvent {
+	data := Data{
+		BuildRun:          buildRun.Name,
+		Namespace:         buildRun.Namespace,
+		Build:             buildRun.GetLabels()[buildv1beta1.LabelBuild],
+		StartTime:         buildRun.Status.StartTime,
+		CompletionTime:    buildRun.Status.CompletionTime,
+		Source:            buildRun.Status.Source,
+		AdditionalSources: buildRun.Status.AdditionalSources,
+		Output:            buildRun.Status.Output,
+		FailureDetails:    buildRun.Status.FailureDetails,
+	}
+
+	if condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded); condition != nil {
+		data.Reason = condition.Reason
+		data.Message = condition.Message
+	}
+
+	// the id is stable, so that receivers can discard events that are delivered twice
+	id := fmt.Sprintf("%s-%s", buildRun.UID, strings.ToLower(string(event)))
+	if buildRun.UID == "" {
+		id = fmt.Sprintf("%s-%s-%s", buildRun.Namespace, buildRun.Name, strings.ToLower(string(event)))
+	}
+
+	return cloudEvent{
+		SpecVersion:     "1.0",
+		ID:              id,
+		Source:          fmt.Sprintf("/apis/shipwright.io/v1beta1/namespaces/%s/buildruns/%s", buildRun.Namespace, buildRun.Name),
+		Type:            EventTypePrefix + strings.ToLower(string(event)),
+		Subject:         buildRun.Name,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+)
+
+var _ = Describe("Notifications", func() {
+	var (
+		ctx      context.Context
+		client   *fakes.FakeClient
+		buildRun *buildv1beta1.BuildRun
+
+		mutex     sync.Mutex
+		requests  []*http.Request
+		bodies    [][]byte
+		responses []int
+		server    *httptest.Server
+
+		configMap *corev1.ConfigMap
+		secret    *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		retryDelay = time.Millisecond
+
+		requests, bodies, responses = nil, nil, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r)
+			bodies = append(bodies, body)
+
+			status := http.StatusNoContent
+			if len(responses) > 0 {
+				status, responses = responses[0], responses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+		DeferCleanup(server.Close)
+
+		configMap, secret = nil, nil
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *corev1.ConfigMap:
+				if configMap != nil {
+					configMap.DeepCopyInto(object)
+					return nil
+				}
+			case *corev1.Secret:
+				if secret != nil {
+					secret.DeepCopyInto(object)
+					return nil
+				}
+			}
+			return apierrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		})
+
+		buildRun = &buildv1beta1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "sample-buildrun",
+				UID:       "5b3e8b2b",
+				Labels:    map[string]string{buildv1beta1.LabelBuild: "sample"},
+			},
+			Status: buildv1beta1.BuildRunStatus{
+				BuildSpec: &buildv1beta1.BuildSpec{
+					Notifications: []buildv1beta1.Notification{{Name: "chat", URL: server.URL}},
+				},
+				Output: &buildv1beta1.Output{Digest: "sha256:c3c0e5"},
+				Conditions: buildv1beta1.Conditions{{
+					Type:   buildv1beta1.Succeeded,
+					Status: corev1.ConditionTrue,
+					Reason: "Succeeded",
+				}},
+			},
+		}
+	})
+
+	Context("determining the completion event", func() {
+		It("returns Succeeded, Failed and Canceled depending on the condition", func() {
+			Expect(CompletionEvent(buildRun)).To(Equal(buildv1beta1.NotificationEventSucceeded))
+
+			buildRun.Status.Conditions[0].Status = corev1.ConditionFalse
+			buildRun.Status.Conditions[0].Reason = "Failed"
+			Expect(CompletionEvent(buildRun)).To(Equal(buildv1beta1.NotificationEventFailed))
+
+			buildRun.Status.Conditions[0].Reason = buildv1beta1.BuildRunStateCancel
+			Expect(CompletionEvent(buildRun)).To(Equal(buildv1beta1.NotificationEventCanceled))
+
+			buildRun.Status.Conditions = nil
+			Expect(CompletionEvent(buildRun)).To(BeEmpty())
+		})
+	})
+
+	Context("scheduling an event", func() {
+		It("records a pending delivery for every subscribed notification once", func() {
+			buildRun.Status.BuildSpec.Notifications = append(buildRun.Status.BuildSpec.Notifications, buildv1beta1.Notification{
+				Name:   "pager",
+				URL:    server.URL,
+				Events: []buildv1beta1.NotificationEvent{buildv1beta1.NotificationEventFailed},
+			})
+
+			Schedule(ctx, client, buildRun, buildv1beta1.NotificationEventSucceeded)
+			Schedule(ctx, client, buildRun, buildv1beta1.NotificationEventSucceeded)
+
+			Expect(buildRun.Status.Notifications).To(Equal([]buildv1beta1.NotificationDelivery{{
+				Name:  "chat",
+				Event: buildv1beta1.NotificationEventSucceeded,
+				State: buildv1beta1.NotificationDeliveryPending,
+			}}))
+			Expect(requests).To(BeEmpty())
+		})
+
+		It("adds the valid notifications of the namespace", func() {
+			buildRun.Status.BuildSpec = nil
+			configMap = &corev1.ConfigMap{Data: map[string]string{
+				ConfigMapKey: "- name: deploy\n  url: " + server.URL + "\n  events: [Started]\n" +
+					"- name: metadata\n  url: file:///var/run/secrets\n",
+			}}
+
+			Schedule(ctx, client, buildRun, buildv1beta1.NotificationEventStarted)
+
+			Expect(buildRun.Status.Notifications).To(HaveLen(1))
+			Expect(buildRun.Status.Notifications[0].Name).To(Equal("deploy"))
+		})
+	})
+
+	Context("delivering the scheduled events", func() {
+		deliver := func() (bool, time.Duration) {
+			return Deliver(ctx, client, buildRun)
+		}
+
+		BeforeEach(func() {
+			Schedule(ctx, client, buildRun, buildv1beta1.NotificationEventSucceeded)
+		})
+
+		It("posts a CloudEvent with the results of the BuildRun", func() {
+			attempted, next := deliver()
+			Expect(attempted).To(BeTrue())
+			Expect(next).To(BeZero())
+			Expect(buildRun.Status.Notifications[0].State).To(Equal(buildv1beta1.NotificationDeliveryDelivered))
+			Expect(buildRun.Status.Notifications[0].Attempts).To(Equal(1))
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Header.Get("Content-Type")).To(Equal(contentTypeCloudEvent))
+			Expect(requests[0].Header.Get(HeaderSignature)).To(BeEmpty())
+
+			var event cloudEvent
+			Expect(json.Unmarshal(bodies[0], &event)).To(Succeed())
+			Expect(event.SpecVersion).To(Equal("1.0"))
+			Expect(event.ID).To(Equal("5b3e8b2b-succeeded"))
+			Expect(event.Type).To(Equal("io.shipwright.buildrun.succeeded"))
+			Expect(event.Source).To(Equal("/apis/shipwright.io/v1beta1/namespaces/default/buildruns/sample-buildrun"))
+			Expect(event.Data.Build).To(Equal("sample"))
+			Expect(event.Data.Reason).To(Equal("Succeeded"))
+			Expect(event.Data.Output.Digest).To(Equal("sha256:c3c0e5"))
+
+			attempted, _ = deliver()
+			Expect(attempted).To(BeFalse())
+			Expect(requests).To(HaveLen(1))
+		})
+
+		It("signs the payload with the key of the secret", func() {
+			buildRun.Status.BuildSpec.Notifications[0].Secret = ptr.To("chat-hmac")
+			secret = &corev1.Secret{Data: map[string][]byte{SecretKeyHMAC: []byte("s3cr3t")}}
+
+			deliver()
+
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Header.Get(HeaderSignature)).To(Equal(Signature([]byte("s3cr3t"), bodies[0])))
+		})
+
+		It("retries transient errors with one attempt per delivery", func() {
+			responses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+
+			attempted, next := deliver()
+			Expect(attempted).To(BeTrue())
+			Expect(next).To(BeNumerically(">", 0))
+			Expect(requests).To(HaveLen(1))
+			Expect(buildRun.Status.Notifications[0].State).To(Equal(buildv1beta1.NotificationDeliveryPending))
+			Expect(buildRun.Status.Notifications[0].Message).To(ContainSubstring("503"))
+
+			Eventually(func() []buildv1beta1.NotificationDelivery {
+				deliver()
+				return buildRun.Status.Notifications
+			}).Should(ConsistOf(HaveField("State", buildv1beta1.NotificationDeliveryDelivered)))
+			Expect(requests).To(HaveLen(3))
+			Expect(buildRun.Status.Notifications[0].Attempts).To(Equal(3))
+		})
+
+		It("does not attempt a delivery before it is due", func() {
+			retryDelay = time.Hour
+			responses = []int{http.StatusServiceUnavailable}
+
+			deliver()
+			attempted, next := deliver()
+			Expect(attempted).To(BeFalse())
+			Expect(next).To(BeNumerically("~", time.Hour, time.Minute))
+			Expect(requests).To(HaveLen(1))
+		})
+
+		It("records a failed delivery after the last attempt", func() {
+			responses = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+
+			Eventually(func() []buildv1beta1.NotificationDelivery {
+				deliver()
+				return buildRun.Status.Notifications
+			}).Should(ConsistOf(HaveField("State", buildv1beta1.NotificationDeliveryFailed)))
+			Expect(requests).To(HaveLen(3))
+			Expect(buildRun.Status.Notifications[0].Name).To(Equal("chat"))
+			Expect(buildRun.Status.Notifications[0].Event).To(Equal(buildv1beta1.NotificationEventSucceeded))
+			Expect(buildRun.Status.Notifications[0].Attempts).To(Equal(3))
+			Expect(buildRun.Status.Notifications[0].Message).To(ContainSubstring("502"))
+		})
+
+		It("does not retry when the endpoint rejects the event", func() {
+			responses = []int{http.StatusBadRequest}
+
+			_, next := deliver()
+			Expect(next).To(BeZero())
+			Expect(requests).To(HaveLen(1))
+			Expect(buildRun.Status.Notifications[0].State).To(Equal(buildv1beta1.NotificationDeliveryFailed))
+			Expect(buildRun.Status.Notifications[0].Attempts).To(Equal(1))
+		})
+
+		It("records a failed delivery when the secret is missing", func() {
+			buildRun.Status.BuildSpec.Notifications[0].Secret = ptr.To("missing")
+
+			deliver()
+			Expect(requests).To(BeEmpty())
+			Expect(buildRun.Status.Notifications[0].State).To(Equal(buildv1beta1.NotificationDeliveryFailed))
+			Expect(buildRun.Status.Notifications[0].Attempts).To(Equal(0))
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -22,6 +23,9 @@ import (
 	"github.com/shipwright-io/build/pkg/validate"
 )
 
+// reason of the event that is recorded when a Build was registered
+const eventReasonRegistered = "Registered"
+
 // build a list of current validation types
 var validationTypes = [...]string{
 	validate.OwnerReferences,
@@ -29,6 +33,7 @@ var validationTypes = [...]string{
 	validate.Secrets,
 	validate.Strategies,
 	validate.Source,
+	validate.AdditionalSources,
 	validate.Output,
 	validate.BuildName,
 	validate.Envs,
@@ -36,6 +41,7 @@ var validationTypes = [...]string{
 	validate.NodeSelector,
 	validate.Tolerations,
 	validate.SchedulerName,
+	validate.Notifications,
 }
 
 // ReconcileBuild reconciles a Build object
@@ -46,6 +52,7 @@ type ReconcileBuild struct {
 	client                client.Client
 	scheme                *runtime.Scheme
 	setOwnerReferenceFunc setOwnerReferenceFunc
+	recorder              record.EventRecorder
 }
 
 // NewReconciler returns a new reconcile.Reconciler
@@ -55,6 +62,7 @@ func NewReconciler(c *config.Config, mgr manager.Manager, ownerRef setOwnerRefer
 		client:                client.WithFieldOwner(mgr.GetClient(), "shipwright-build-controller"),
 		scheme:                mgr.GetScheme(),
 		setOwnerReferenceFunc: ownerRef,
+		recorder:              mgr.GetEventRecorderFor("shipwright-build-controller"),
 	}
 }
 
@@ -62,7 +70,7 @@ func NewReconciler(c *config.Config, mgr manager.Manager, ownerRef setOwnerRefer
 // and what is in the Build.Spec
 func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	// Set the ctx to be Background, as the top-level context for incoming requests.
-	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
 	defer cancel()
 
 	ctxlog.Debug(ctx, "start reconciling Build", namespace, request.Namespace, name, request.Name)
@@ -77,6 +85,11 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, err
 	}
 
+	// Builds are reconciled again when their strategy changes, the registration is
+	// only announced if the Build was not registered before
+	wasRegistered := ptr.Deref(b.Status.Registered, "") == corev1.ConditionTrue &&
+		ptr.Deref(b.Status.Reason, "") == build.SucceedStatus
+
 	// Populate the status struct with default values
 	b.Status.Registered = ptr.To[corev1.ConditionStatus](corev1.ConditionFalse)
 	b.Status.Reason = ptr.To[build.BuildReason](build.SucceedStatus)
@@ -112,6 +125,10 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 				return reconcile.Result{}, err
 			}
 
+			if b.Status.Reason != nil {
+				r.recorder.Event(b, corev1.EventTypeWarning, string(*b.Status.Reason), ptr.Deref(b.Status.Message, ""))
+			}
+
 			return reconcile.Result{}, nil
 		}
 	}
@@ -122,8 +139,12 @@ func (r *ReconcileBuild) Reconcile(ctx context.Context, request reconcile.Reques
 		return reconcile.Result{}, err
 	}
 
-	// Increase Build count in metrics
-	buildmetrics.BuildCountInc(b.Spec.Strategy.Name, b.Namespace, b.Name)
+	if !wasRegistered {
+		r.recorder.Event(b, corev1.EventTypeNormal, eventReasonRegistered, build.AllValidationsSucceeded)
+
+		// Increase Build count in metrics
+		buildmetrics.BuildCountInc(b.Spec.Strategy.Name, b.Namespace, b.Name)
+	}
 
 	ctxlog.Debug(ctx, "finishing reconciling Build", namespace, request.Namespace, name, request.Name)
 	return reconcile.Result{}, nil
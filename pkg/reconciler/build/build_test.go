@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	crc "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -40,6 +41,7 @@ var _ = Describe("Reconcile Build", func() {
 		client                       *fakes.FakeClient
 		ctl                          test.Catalog
 		statusWriter                 *fakes.FakeStatusWriter
+		recorder                     *record.FakeRecorder
 		registrySecret               string
 		buildName                    string
 		namespace, buildStrategyName string
@@ -53,6 +55,8 @@ var _ = Describe("Reconcile Build", func() {
 
 		// Fake the manager and get a reconcile Request
 		manager = &fakes.FakeManager{}
+		recorder = record.NewFakeRecorder(10)
+		manager.GetEventRecorderForReturns(recorder)
 		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildName, Namespace: namespace}}
 
 		// Fake the client GET calls when reconciling,
@@ -95,6 +99,20 @@ var _ = Describe("Reconcile Build", func() {
 				_, err := reconciler.Reconcile(context.TODO(), request)
 				Expect(err).To(BeNil())
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(Equal("Warning SpecSourceSecretRefNotFound referenced secret non-existing not found")))
+			})
+
+			It("fails when the commit status secret does not exist", func() {
+				buildSample.Spec.Source.Git.CommitStatus = &build.GitCommitStatus{Secret: "non-existing"}
+				buildSample.Spec.Output.PushSecret = nil
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, build.SpecCommitStatusSecretRefNotFound, "referenced secret non-existing not found")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(Equal("Warning SpecCommitStatusSecretRefNotFound referenced secret non-existing not found")))
 			})
 
 			It("succeeds when the secret exists foobar", func() {
@@ -123,6 +141,7 @@ var _ = Describe("Reconcile Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
 				Expect(reconcile.Result{}).To(Equal(result))
+				Expect(recorder.Events).To(Receive(Equal("Normal Registered all validations succeeded")))
 			})
 		})
 
@@ -228,6 +247,62 @@ var _ = Describe("Reconcile Build", func() {
 			})
 		})
 
+		Context("when the strategy of a Build changed", func() {
+			BeforeEach(func() {
+				client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+					switch object := object.(type) {
+					case *build.Build:
+						buildSample.DeepCopyInto(object)
+					case *build.ClusterBuildStrategy:
+						clusterBuildStrategySample.DeepCopyInto(object)
+					case *corev1.Secret:
+						secretSample = ctl.SecretWithoutAnnotation("existing", namespace)
+						secretSample.DeepCopyInto(object)
+					}
+					return nil
+				})
+			})
+
+			It("registers a Build that was reported with a strategy that was not found", func() {
+				buildSample.Status.Registered = ptr.To(corev1.ConditionFalse)
+				buildSample.Status.Reason = ptr.To(build.ClusterBuildStrategyNotFound)
+
+				statusCall := ctl.StubFunc(corev1.ConditionTrue, build.SucceedStatus, "all validations succeeded")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(Equal("Normal Registered all validations succeeded")))
+			})
+
+			It("does not announce the registration again for a Build that is registered", func() {
+				buildSample.Status.Registered = ptr.To(corev1.ConditionTrue)
+				buildSample.Status.Reason = ptr.To(build.SucceedStatus)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(recorder.Events).ToNot(Receive())
+			})
+
+			It("fails when the Build sets a parameter that the strategy no longer defines", func() {
+				buildSample.Status.Registered = ptr.To(corev1.ConditionTrue)
+				buildSample.Status.Reason = ptr.To(build.SucceedStatus)
+				buildSample.Spec.ParamValues = []build.ParamValue{{
+					Name:        "removed-param",
+					SingleValue: &build.SingleValue{Value: ptr.To("value")},
+				}}
+
+				statusCall := ctl.StubFunc(corev1.ConditionFalse, build.UndefinedParameter, "The following parameters are not defined in the build strategy: removed-param")
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when spec strategy BuildStrategy is specified", func() {
 			JustBeforeEach(func() {
 				buildStrategyName = "buildpacks-v3"
@@ -157,6 +157,39 @@ func add(ctx context.Context, mgr manager.Manager, r reconcile.Reconciler, maxCo
 		},
 	}
 
+	// Watch for changes to the strategies, so that the Builds that reference them are validated again
+	if err = c.Watch(source.Kind(mgr.GetCache(), &build.BuildStrategy{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, strategy *build.BuildStrategy) []reconcile.Request {
+		return buildsReferencingStrategy(ctx, mgr.GetClient(), build.NamespacedBuildStrategyKind, strategy.Namespace, strategy.Name)
+	}), strategyPredicate[*build.BuildStrategy]())); err != nil {
+		return err
+	}
+
+	if err = c.Watch(source.Kind(mgr.GetCache(), &build.ClusterBuildStrategy{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, strategy *build.ClusterBuildStrategy) []reconcile.Request {
+		return buildsReferencingStrategy(ctx, mgr.GetClient(), build.ClusterBuildStrategyKind, "", strategy.Name)
+	}), strategyPredicate[*build.ClusterBuildStrategy]())); err != nil {
+		return err
+	}
+
+	// Watch for revisions of the strategies, so that the Builds that pin a version are validated again
+	if err = c.Watch(source.Kind(mgr.GetCache(), &build.BuildStrategyRevision{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, revision *build.BuildStrategyRevision) []reconcile.Request {
+		return buildsReferencingStrategy(ctx, mgr.GetClient(), build.NamespacedBuildStrategyKind, revision.Namespace, revision.Spec.Strategy)
+	}), strategyPredicate[*build.BuildStrategyRevision]())); err != nil {
+		return err
+	}
+
+	if err = c.Watch(source.Kind(mgr.GetCache(), &build.ClusterBuildStrategyRevision{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, revision *build.ClusterBuildStrategyRevision) []reconcile.Request {
+		return buildsReferencingStrategy(ctx, mgr.GetClient(), build.ClusterBuildStrategyKind, "", revision.Spec.Strategy)
+	}), strategyPredicate[*build.ClusterBuildStrategyRevision]())); err != nil {
+		return err
+	}
+
+	// Watch for label changes of namespaces, so that the Builds that use cluster build strategies are validated again
+	if err = c.Watch(source.Kind(mgr.GetCache(), &corev1.Namespace{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, ns *corev1.Namespace) []reconcile.Request {
+		return buildsReferencingClusterBuildStrategies(ctx, mgr.GetClient(), ns.Name)
+	}), namespacePredicate())); err != nil {
+		return err
+	}
+
 	return c.Watch(source.Kind(mgr.GetCache(), &corev1.Secret{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
 		buildList := &build.BuildList{}
 
@@ -208,3 +241,104 @@ func buildCredentialsAnnotationExist(annotation map[string]string) (string, bool
 	}
 	return "", false
 }
+
+// strategyPredicate filters the strategy events that can change the validation result of
+// a Build, the status updates of a strategy do not change its generation, and the privileged
+// annotation of a cluster build strategy is not part of it
+func strategyPredicate[T client.Object]() predicate.TypedFuncs[T] {
+	return predicate.TypedFuncs[T]{
+		CreateFunc: func(_ event.TypedCreateEvent[T]) bool {
+			return true
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[T]) bool {
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				e.ObjectOld.GetAnnotations()[build.AnnotationPrivilegedStrategy] != e.ObjectNew.GetAnnotations()[build.AnnotationPrivilegedStrategy]
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[T]) bool {
+			return true
+		},
+		GenericFunc: func(_ event.TypedGenericEvent[T]) bool {
+			return false
+		},
+	}
+}
+
+// buildsReferencingStrategy returns a request for every Build that references the strategy
+// of the given kind and name, the namespace is empty for cluster build strategies
+func buildsReferencingStrategy(ctx context.Context, c client.Client, kind build.BuildStrategyKind, strategyNamespace string, strategyName string) []reconcile.Request {
+	buildList := &build.BuildList{}
+	if err := c.List(ctx, buildList, &client.ListOptions{Namespace: strategyNamespace}); err != nil {
+		ctxlog.Info(ctx, "unexpected error happened while listing builds", namespace, strategyNamespace, "error", err)
+		return []reconcile.Request{}
+	}
+
+	reconcileList := []reconcile.Request{}
+	for _, b := range buildList.Items {
+		if b.Spec.Strategy.Name != strategyName {
+			continue
+		}
+
+		buildKind := build.NamespacedBuildStrategyKind
+		if b.Spec.Strategy.Kind != nil {
+			buildKind = *b.Spec.Strategy.Kind
+		}
+
+		if buildKind != kind {
+			continue
+		}
+
+		reconcileList = append(reconcileList, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      b.Name,
+				Namespace: b.Namespace,
+			},
+		})
+	}
+
+	return reconcileList
+}
+
+// namespacePredicate filters the namespace events to label changes, which can change whether
+// the Builds of the namespace are allowed to use a cluster build strategy
+func namespacePredicate() predicate.TypedFuncs[*corev1.Namespace] {
+	return predicate.TypedFuncs[*corev1.Namespace]{
+		CreateFunc: func(_ event.TypedCreateEvent[*corev1.Namespace]) bool {
+			return false
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Namespace]) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*corev1.Namespace]) bool {
+			return false
+		},
+		GenericFunc: func(_ event.TypedGenericEvent[*corev1.Namespace]) bool {
+			return false
+		},
+	}
+}
+
+// buildsReferencingClusterBuildStrategies returns a request for every Build in the namespace that
+// references a cluster build strategy
+func buildsReferencingClusterBuildStrategies(ctx context.Context, c client.Client, namespaceName string) []reconcile.Request {
+	buildList := &build.BuildList{}
+	if err := c.List(ctx, buildList, &client.ListOptions{Namespace: namespaceName}); err != nil {
+		ctxlog.Info(ctx, "unexpected error happened while listing builds", namespace, namespaceName, "error", err)
+		return []reconcile.Request{}
+	}
+
+	reconcileList := []reconcile.Request{}
+	for _, b := range buildList.Items {
+		if b.Spec.Strategy.Kind == nil || *b.Spec.Strategy.Kind != build.ClusterBuildStrategyKind {
+			continue
+		}
+
+		reconcileList = append(reconcileList, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      b.Name,
+				Namespace: b.Namespace,
+			},
+		})
+	}
+
+	return reconcileList
+}
@@ -0,0 +1,104 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package buildcachecleanup
+
+import (
+	"context"
+	"time"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileBuildCache reconciles a cache PersistentVolumeClaim of a Build
+type ReconcileBuildCache struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+}
+
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileBuildCache{
+		config: c,
+		client: client.WithFieldOwner(mgr.GetClient(), "shipwright-build-cache-cleanup-controller"),
+	}
+}
+
+// Reconcile deletes a cache PersistentVolumeClaim once it was not used by any
+// BuildRun for the duration of its ttl
+func (r *ReconcileBuildCache) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "Start reconciling build cache", namespace, request.Namespace, name, request.Name)
+
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, claim)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "Finish reconciling build cache. PersistentVolumeClaim was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if claim.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	ttl := resources.DefaultCacheTTL
+	if value, ok := claim.Annotations[buildv1beta1.AnnotationCacheTTL]; ok {
+		if ttl, err = time.ParseDuration(value); err != nil {
+			ctxlog.Info(ctx, "Ignoring invalid cache ttl.", namespace, request.Namespace, name, request.Name, "error", err)
+			ttl = resources.DefaultCacheTTL
+		}
+	}
+
+	// a cache that is in use expires earliest one ttl after it was released
+	if lockedBy := claim.Annotations[buildv1beta1.AnnotationCacheLockedBy]; lockedBy != "" {
+		buildRun := &buildv1beta1.BuildRun{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: lockedBy, Namespace: request.Namespace}, buildRun)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+
+		if err == nil && buildRun.Status.CompletionTime == nil {
+			return reconcile.Result{Requeue: true, RequeueAfter: ttl}, nil
+		}
+	}
+
+	lastUsed := claim.CreationTimestamp.Time
+	if value, ok := claim.Annotations[buildv1beta1.AnnotationCacheLastUsed]; ok {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			lastUsed = parsed
+		}
+	}
+
+	if timeLeft := time.Until(lastUsed.Add(ttl)); timeLeft > 0 {
+		return reconcile.Result{Requeue: true, RequeueAfter: timeLeft}, nil
+	}
+
+	ctxlog.Info(ctx, "Deleting build cache as ttl has been reached.", namespace, request.Namespace, name, request.Name)
+	if err := r.client.Delete(ctx, claim, &client.DeleteOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "Error deleting build cache.", namespace, request.Namespace, name, request.Name, "error", err)
+			return reconcile.Result{}, err
+		}
+		ctxlog.Debug(ctx, "Error deleting build cache. It has already been deleted.", namespace, request.Namespace, name, request.Name)
+	}
+
+	ctxlog.Debug(ctx, "Finishing reconciling request from a PersistentVolumeClaim event", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package buildcachecleanup
+
+import (
+	"context"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new build cache cleanup Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(_ context.Context, c *config.Config, mgr manager.Manager) error {
+	return add(mgr, NewReconciler(c, mgr), c.Controllers.BuildCacheCleanup.MaxConcurrentReconciles)
+}
+
+// isCacheClaim returns true if the PersistentVolumeClaim holds the cache of a Build
+func isCacheClaim(claim *corev1.PersistentVolumeClaim) bool {
+	_, ok := claim.Labels[buildv1beta1.LabelCacheVolume]
+	return ok
+}
+
+func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	c, err := controller.New("build-cache-cleanup-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	predClaim := predicate.TypedFuncs[*corev1.PersistentVolumeClaim]{
+		CreateFunc: func(e event.TypedCreateEvent[*corev1.PersistentVolumeClaim]) bool {
+			return isCacheClaim(e.Object)
+		},
+		UpdateFunc: func(e event.TypedUpdateEvent[*corev1.PersistentVolumeClaim]) bool {
+			// the expiration only changes when the claim is released or its TTL changes
+			return isCacheClaim(e.ObjectNew) && (e.ObjectOld.Annotations[buildv1beta1.AnnotationCacheLockedBy] != e.ObjectNew.Annotations[buildv1beta1.AnnotationCacheLockedBy] ||
+				e.ObjectOld.Annotations[buildv1beta1.AnnotationCacheLastUsed] != e.ObjectNew.Annotations[buildv1beta1.AnnotationCacheLastUsed] ||
+				e.ObjectOld.Annotations[buildv1beta1.AnnotationCacheTTL] != e.ObjectNew.Annotations[buildv1beta1.AnnotationCacheTTL])
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*corev1.PersistentVolumeClaim]) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	// Watch for changes to the cache PersistentVolumeClaims
+	return c.Watch(source.Kind(mgr.GetCache(), &corev1.PersistentVolumeClaim{}, &handler.TypedEnqueueRequestForObject[*corev1.PersistentVolumeClaim]{}, predClaim))
+}
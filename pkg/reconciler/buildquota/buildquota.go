@@ -0,0 +1,76 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package buildquota
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// blank assignment to verify that ReconcileBuildQuota implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileBuildQuota{}
+
+// ReconcileBuildQuota reconciles a BuildQuota object
+type ReconcileBuildQuota struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	config *config.Config
+	client client.Client
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcileBuildQuota{
+		config: c,
+		client: client.WithFieldOwner(mgr.GetClient(), "shipwright-buildquota-controller"),
+	}
+}
+
+// Reconcile reports the current usage of the BuildRuns of the namespace in the status of the BuildQuota
+func (r *ReconcileBuildQuota) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
+	defer cancel()
+
+	ctxlog.Debug(ctx, "start reconciling BuildQuota", namespace, request.Namespace, name, request.Name)
+
+	quota := &buildv1beta1.BuildQuota{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: request.Name, Namespace: request.Namespace}, quota); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling BuildQuota. BuildQuota was not found", namespace, request.Namespace, name, request.Name)
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	usage, err := resources.BuildQuotaUsage(ctx, r.client, request.Namespace, "")
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if equality.Semantic.DeepEqual(quota.Status, *usage) {
+		ctxlog.Debug(ctx, "finish reconciling BuildQuota. Usage is unchanged", namespace, request.Namespace, name, request.Name)
+		return reconcile.Result{}, nil
+	}
+
+	quota.Status = *usage
+	if err := r.client.Status().Update(ctx, quota); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	ctxlog.Debug(ctx, "finish reconciling BuildQuota", namespace, request.Namespace, name, request.Name)
+	return reconcile.Result{}, nil
+}
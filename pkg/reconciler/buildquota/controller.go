@@ -0,0 +1,103 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package buildquota
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// Add creates a new BuildQuota Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(_ context.Context, c *config.Config, mgr manager.Manager) error {
+	return add(mgr, NewReconciler(c, mgr), c.Controllers.BuildQuota.MaxConcurrentReconciles)
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles int) error {
+	// Create the controller options
+	options := controller.Options{
+		Reconciler: r,
+	}
+
+	if maxConcurrentReconciles > 0 {
+		options.MaxConcurrentReconciles = maxConcurrentReconciles
+	}
+
+	c, err := controller.New("buildquota-controller", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	predBuildQuota := predicate.TypedFuncs[*buildv1beta1.BuildQuota]{
+		UpdateFunc: func(e event.TypedUpdateEvent[*buildv1beta1.BuildQuota]) bool {
+			// Ignore updates of the status, which the controller makes itself
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+		},
+		DeleteFunc: func(_ event.TypedDeleteEvent[*buildv1beta1.BuildQuota]) bool {
+			// Never reconcile on deletion, there is nothing we have to do
+			return false
+		},
+	}
+
+	predBuildRun := predicate.TypedFuncs[*buildv1beta1.BuildRun]{
+		UpdateFunc: func(e event.TypedUpdateEvent[*buildv1beta1.BuildRun]) bool {
+			// The usage only changes when a BuildRun is admitted, starts, or completes
+			return (e.ObjectOld.Status.TaskRunName == nil) != (e.ObjectNew.Status.TaskRunName == nil) || // nolint:staticcheck
+				(e.ObjectOld.Status.CompletionTime == nil) != (e.ObjectNew.Status.CompletionTime == nil) ||
+				resources.HasBuildQuotaReservation(e.ObjectOld) != resources.HasBuildQuotaReservation(e.ObjectNew)
+		},
+	}
+
+	// Watch for changes to primary resource BuildQuota
+	if err = c.Watch(source.Kind[*buildv1beta1.BuildQuota](mgr.GetCache(), &buildv1beta1.BuildQuota{}, &handler.TypedEnqueueRequestForObject[*buildv1beta1.BuildQuota]{}, predBuildQuota)); err != nil {
+		return err
+	}
+
+	// Watch for BuildRuns to update the usage of the BuildQuotas of their namespace
+	return c.Watch(source.Kind(mgr.GetCache(), &buildv1beta1.BuildRun{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, buildRun *buildv1beta1.BuildRun) []reconcile.Request {
+		return quotasInNamespace(ctx, mgr.GetClient(), buildRun.Namespace)
+	}), predBuildRun))
+}
+
+// quotasInNamespace returns a request for every BuildQuota of the namespace
+func quotasInNamespace(ctx context.Context, c client.Client, namespaceName string) []reconcile.Request {
+	quotaList := &buildv1beta1.BuildQuotaList{}
+	if err := c.List(ctx, quotaList, client.InNamespace(namespaceName)); err != nil {
+		ctxlog.Info(ctx, "unexpected error happened while listing BuildQuotas", namespace, namespaceName, "error", err)
+		return []reconcile.Request{}
+	}
+
+	reconcileList := []reconcile.Request{}
+	for _, quota := range quotaList.Items {
+		reconcileList = append(reconcileList, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      quota.Name,
+				Namespace: quota.Namespace,
+			},
+		})
+	}
+
+	return reconcileList
+}
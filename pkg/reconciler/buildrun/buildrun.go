@@ -10,24 +10,31 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"knative.dev/pkg/apis"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/commitstatus"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/logarchive"
 	buildmetrics "github.com/shipwright-io/build/pkg/metrics"
+	"github.com/shipwright-io/build/pkg/notification"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 	"github.com/shipwright-io/build/pkg/validate"
 )
@@ -36,6 +43,14 @@ const (
 	namespace          string = "namespace"
 	name               string = "name"
 	generatedNameRegex        = "-[a-z0-9]{5,5}$"
+
+	// reasons of the events that are recorded for a BuildRun in addition to the
+	// reasons of its Succeeded condition
+	eventReasonTaskRunCreated = "TaskRunCreated"
+	eventReasonCanceling      = "Canceling"
+
+	// buildQuotaRequeueInterval is the interval in which a BuildRun that waits for a BuildQuota checks it again
+	buildQuotaRequeueInterval = 15 * time.Second
 )
 
 // blank assignment to verify that ReconcileBuildRun implements reconcile.Reconciler
@@ -47,9 +62,15 @@ type ReconcileBuildRun struct {
 	// that reads objects from the cache and writes to the apiserver
 	config                *config.Config
 	client                client.Client
+	apiReader             client.Reader
 	scheme                *runtime.Scheme
 	setOwnerReferenceFunc setOwnerReferenceFunc
 	taskRunnerFactory     ImageBuildRunnerFactory
+	recorder              record.EventRecorder
+	podLogs               logarchive.PodLogs
+
+	// buildQuotaLocks serializes the BuildQuota admission of the BuildRuns per namespace
+	buildQuotaLocks sync.Map
 }
 
 // NewReconciler returns a new reconcile.Reconciler
@@ -57,29 +78,42 @@ func NewReconciler(c *config.Config, mgr manager.Manager, ownerRef setOwnerRefer
 	return &ReconcileBuildRun{
 		config:                c,
 		client:                client.WithFieldOwner(mgr.GetClient(), "shipwright-buildrun-controller"),
+		apiReader:             mgr.GetAPIReader(),
 		scheme:                mgr.GetScheme(),
 		setOwnerReferenceFunc: ownerRef,
 		taskRunnerFactory:     &TektonTaskRunImageBuildRunnerFactory{},
+		recorder:              mgr.GetEventRecorderFor("shipwright-buildrun-controller"),
+		podLogs:               logarchive.NewPodLogs(mgr.GetConfig()),
 	}
 }
 
 // Reconcile reads that state of the cluster for a Build object and makes changes based on the state read
 // and what is in the Build.Spec
 func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	var buildRun *buildv1beta1.BuildRun
+	// Set the ctx to be Background, as the top-level context for incoming requests.
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
+	defer cancel()
+
+	buildRun := &buildv1beta1.BuildRun{}
+	result, err := r.reconcile(ctx, request, buildRun)
+	if err != nil || buildRun.Name == "" {
+		return result, err
+	}
+
+	return r.reconcileFollowUps(ctx, buildRun, result)
+}
+
+// reconcile synchronizes the BuildRun with its TaskRun, or creates the TaskRun, the
+// BuildRun that the request refers to is loaded into the provided object
+func (r *ReconcileBuildRun) reconcile(ctx context.Context, request reconcile.Request, buildRun *buildv1beta1.BuildRun) (reconcile.Result, error) {
 	var build *buildv1beta1.Build
 
 	updateBuildRunRequired := false
 
-	// Set the ctx to be Background, as the top-level context for incoming requests.
-	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
-	defer cancel()
-
 	ctxlog.Debug(ctx, "starting reconciling request from a BuildRun or TaskRun event", namespace, request.Namespace, name, request.Name)
 
 	// with build run cancel, it is now possible for a build run update to stem from something other than a task run update,
 	// so we can no longer assume that a build run event will not come in after the build run has a task run ref in its status
-	buildRun = &buildv1beta1.BuildRun{}
 	getBuildRunErr := r.GetBuildRunObject(ctx, request.Name, request.Namespace, buildRun)
 	lastTaskRun, getTaskRunErr := r.taskRunnerFactory.GetImageBuildRunner(ctx, r.client, types.NamespacedName{Name: request.Name, Namespace: request.Namespace})
 
@@ -101,9 +135,8 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 		// Validating buildrun name is a valid label value
 		if errs := validation.IsValidLabelValue(buildRun.Name); len(errs) > 0 {
 			// stop reconciling and mark the BuildRun as Failed
-			return reconcile.Result{}, resources.UpdateConditionWithFalseStatus(
+			return reconcile.Result{}, r.updateConditionWithFalseStatus(
 				ctx,
-				r.client,
 				buildRun,
 				strings.Join(errs, ", "),
 				resources.BuildRunNameInvalid,
@@ -112,9 +145,8 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 
 		// Validate BuildRun for disallowed field combinations (could technically be also done in a validating webhook)
 		if reason, message := validate.BuildRunFields(buildRun); reason != "" {
-			return reconcile.Result{}, resources.UpdateConditionWithFalseStatus(
+			return reconcile.Result{}, r.updateConditionWithFalseStatus(
 				ctx,
-				r.client,
 				buildRun,
 				message,
 				reason,
@@ -134,6 +166,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			build = &buildv1beta1.Build{}
 			if err := resources.GetBuildObject(ctx, r.client, buildRun, build); err != nil {
 				if !resources.IsClientStatusUpdateError(err) && buildRun.Status.IsFailed(buildv1beta1.Succeeded) {
+					r.completeBuildRun(ctx, buildRun)
 					return reconcile.Result{}, nil
 				}
 				// system call failure, reconcile again
@@ -158,11 +191,13 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 						validate.NewCredentials(r.client, build),
 						validate.NewStrategies(r.client, build),
 						validate.NewSourceRef(build),
+						validate.NewAdditionalSources(build),
 						validate.NewBuildName(build),
 						validate.NewEnv(build),
 						validate.NewNodeSelector(build),
 						validate.NewTolerations(build),
 						validate.NewSchedulerName(build),
+						validate.NewNotifications(build),
 					)
 
 					// an internal/technical error during validation happened
@@ -173,9 +208,8 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 					// one or more of the validations failed
 					if build.Status.Reason != nil {
 						return reconcile.Result{},
-							resources.UpdateConditionWithFalseStatus(
+							r.updateConditionWithFalseStatus(
 								ctx,
-								r.client,
 								buildRun,
 								*build.Status.Message,
 								resources.ConditionBuildRegistrationFailed,
@@ -198,7 +232,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				}
 
 				message := fmt.Sprintf("the Build is not registered correctly, build: %s, registered status: %s, reason: %s", build.Name, *build.Status.Registered, reason)
-				if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, resources.ConditionBuildRegistrationFailed); updateErr != nil {
+				if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, message, resources.ConditionBuildRegistrationFailed); updateErr != nil {
 					return reconcile.Result{}, updateErr
 				}
 
@@ -212,7 +246,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 
 			// make sure the BuildRun has not already been cancelled
 			if buildRun.IsCanceled() {
-				if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, "the BuildRun is marked canceled.", buildv1beta1.BuildRunStateCancel); updateErr != nil {
+				if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, "the BuildRun is marked canceled.", buildv1beta1.BuildRunStateCancel); updateErr != nil {
 					return reconcile.Result{}, updateErr
 				}
 				return reconcile.Result{}, nil
@@ -261,6 +295,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			svcAccount, err := resources.RetrieveServiceAccount(ctx, r.client, build, buildRun)
 			if err != nil {
 				if !resources.IsClientStatusUpdateError(err) && buildRun.Status.IsFailed(buildv1beta1.Succeeded) {
+					r.completeBuildRun(ctx, buildRun)
 					return reconcile.Result{}, nil
 				}
 				// system call failure, reconcile again
@@ -275,10 +310,25 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				return reconcile.Result{}, err
 			}
 
+			strategy, err = r.resolveStrategyFragments(ctx, buildRun, strategy)
+			if err != nil {
+				if !resources.IsClientStatusUpdateError(err) && buildRun.Status.IsFailed(buildv1beta1.Succeeded) {
+					return reconcile.Result{}, nil
+				}
+				return reconcile.Result{}, err
+			}
+
+			if err := r.checkClusterBuildStrategyAllowed(ctx, build, buildRun, strategy); err != nil {
+				if !resources.IsClientStatusUpdateError(err) && buildRun.Status.IsFailed(buildv1beta1.Succeeded) {
+					return reconcile.Result{}, nil
+				}
+				return reconcile.Result{}, err
+			}
+
 			// Validate the parameters
 			valid, reason, message := validate.BuildRunParameters(strategy.GetParameters(), build.Spec.ParamValues, buildRun.Spec.ParamValues)
 			if !valid {
-				if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
 					return reconcile.Result{}, err
 				}
 				return reconcile.Result{}, nil
@@ -287,7 +337,25 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			// Validate the volumes
 			valid, reason, message = validate.BuildRunVolumes(strategy.GetVolumes(), buildRun.Spec.Volumes)
 			if !valid {
-				if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{}, nil
+			}
+
+			// Validate the step resources
+			valid, reason, message = validate.BuildRunStepResources(strategy.GetBuildSteps(), buildRun.Spec.StepResources)
+			if !valid {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{}, nil
+			}
+
+			// Validate the parameters that the when expressions of the steps reference
+			valid, reason, message = validate.BuildRunStepConditions(strategy.GetBuildSteps(), build.Spec.ParamValues, buildRun.Spec.ParamValues)
+			if !valid {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
 					return reconcile.Result{}, err
 				}
 				return reconcile.Result{}, nil
@@ -296,7 +364,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			// Validate the nodeSelector
 			valid, reason, message = validate.BuildRunNodeSelector(buildRun.Spec.NodeSelector)
 			if !valid {
-				if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
 					return reconcile.Result{}, err
 				}
 				return reconcile.Result{}, nil
@@ -305,7 +373,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			// Validate the tolerations
 			valid, reason, message = validate.BuildRunTolerations(buildRun.Spec.Tolerations)
 			if !valid {
-				if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
 					return reconcile.Result{}, err
 				}
 				return reconcile.Result{}, nil
@@ -314,7 +382,31 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			// Validate the schedulerName
 			valid, reason, message = validate.BuildRunSchedulerName(buildRun.Spec.SchedulerName)
 			if !valid {
-				if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, message, reason); err != nil {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{}, nil
+			}
+
+			// Validate the push secret of the cache
+			valid, reason, message, err = validate.BuildRunCacheSecret(ctx, r.client, buildRun)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !valid {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{}, nil
+			}
+
+			// Validate the upload secret of the local source
+			valid, reason, message, err = validate.BuildRunUploadSecret(ctx, r.client, buildRun)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if !valid {
+				if err := r.updateConditionWithFalseStatus(ctx, buildRun, message, reason); err != nil {
 					return reconcile.Result{}, err
 				}
 				return reconcile.Result{}, nil
@@ -335,7 +427,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			// if resource is not found, fais the build run
 			if err != nil {
 				if apierrors.IsNotFound(err) {
-					if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), string(buildv1beta1.VolumeDoesNotExist)); err != nil {
+					if err := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), string(buildv1beta1.VolumeDoesNotExist)); err != nil {
 						return reconcile.Result{}, err
 					}
 
@@ -347,11 +439,29 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				return reconcile.Result{}, err
 			}
 
+			// Enforce the BuildQuotas of the namespace, the BuildRun waits while the running BuildRuns use up a quota
+			wait, err := r.enforceBuildQuotas(ctx, buildRun, generatedTaskRun)
+			if err != nil {
+				if !resources.IsClientStatusUpdateError(err) && buildRun.Status.IsFailed(buildv1beta1.Succeeded) {
+					return reconcile.Result{}, nil
+				}
+				return reconcile.Result{}, err
+			}
+			if wait {
+				return reconcile.Result{Requeue: true, RequeueAfter: buildQuotaRequeueInterval}, nil
+			}
+
+			// Mount the caches of the Build unless they are in use by another BuildRun
+			if err := resources.AcquireCacheVolumes(ctx, r.client, build, buildRun, strategy.GetVolumes(), generatedTaskRun); err != nil {
+				return reconcile.Result{}, err
+			}
+
 			ctxlog.Info(ctx, "creating TaskRun from BuildRun", namespace, request.Namespace, name, generatedTaskRun.GenerateName, "BuildRun", buildRun.Name)
 			if err = r.client.Create(ctx, generatedTaskRun); err != nil {
 				// system call failure, reconcile again
 				return reconcile.Result{}, err
 			}
+			r.recorder.Eventf(buildRun, corev1.EventTypeNormal, eventReasonTaskRunCreated, "Created TaskRun %s", generatedTaskRun.Name)
 
 			// Set the TaskRunName and BuildExecutor in the BuildRun status
 			buildRun.Status.TaskRunName = &generatedTaskRun.Name // nolint:staticcheck
@@ -359,6 +469,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 				Name: generatedTaskRun.Name,
 				Kind: "TaskRun",
 			}
+			buildRun.Status.Strategy = strategyResult(build, strategy)
 			ctxlog.Info(ctx, "updating BuildRun status with TaskRun name", namespace, request.Namespace, name, request.Name, "TaskRun", generatedTaskRun.Name)
 			if err = r.client.Status().Update(ctx, buildRun); err != nil {
 				// we ignore the error here to prevent another reconciliation that would create another TaskRun,
@@ -410,6 +521,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			if err := lastTaskRun.Cancel(ctx, r.client); err != nil {
 				return reconcile.Result{}, fmt.Errorf("failed to cancel TaskRun: %v", err)
 			}
+			r.recorder.Eventf(buildRun, corev1.EventTypeNormal, eventReasonCanceling, "Canceling TaskRun %s", lastTaskRun.GetName())
 		}
 
 		// Check if the BuildRun is already finished, this happens if the build controller is restarted.
@@ -437,6 +549,7 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 			}
 
 			resources.UpdateBuildRunUsingTaskFailures(ctx, r.client, buildRun, taskRunObj)
+			resources.UpdateBuildRunUsingTaskStepStates(buildRun, taskRunObj)
 			taskRunStatus := trCondition.Status
 
 			// check if we should delete the generated service account by checking the build run spec and that the task run is complete
@@ -468,10 +581,18 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 					buildRun.Name,
 					buildRun.Status.StartTime.Time.Sub(buildRun.CreationTimestamp.Time),
 				)
+
+				notification.Schedule(ctx, r.apiReader, buildRun, buildv1beta1.NotificationEventStarted)
 			}
 
 			if lastTaskRun.GetCompletionTime() != nil && buildRun.Status.CompletionTime == nil {
 				buildRun.Status.CompletionTime = lastTaskRun.GetCompletionTime()
+				r.completeBuildRun(ctx, buildRun)
+
+				// Allow the next BuildRun of the Build to use the caches
+				if err := resources.ReleaseCacheVolumes(ctx, r.client, buildRun); err != nil {
+					ctxlog.Error(ctx, err, "failed to release the caches of the BuildRun", namespace, request.Namespace, name, request.Name)
+				}
 
 				// buildrun completion duration (total time between the creation of the buildrun and the buildrun completion)
 				buildmetrics.BuildRunCompletionObserve(
@@ -482,6 +603,21 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 					buildRun.Status.CompletionTime.Time.Sub(buildRun.CreationTimestamp.Time),
 				)
 
+				// step durations (time between the start and the termination of every step)
+				for _, step := range buildRun.Status.Steps {
+					if step.StartTime != nil && step.CompletionTime != nil {
+						buildmetrics.BuildRunStepDurationObserve(
+							buildRun.Status.BuildSpec.StrategyName(),
+							buildRun.Namespace,
+							buildRun.Spec.BuildName(),
+							buildRun.Name,
+							step.Name,
+							string(step.Origin),
+							step.CompletionTime.Time.Sub(step.StartTime.Time),
+						)
+					}
+				}
+
 				// Look for the pod created by the taskrun
 				var pod = &corev1.Pod{}
 				if err := r.client.Get(ctx, types.NamespacedName{Namespace: request.Namespace, Name: lastTaskRun.GetPodName()}, pod); err == nil {
@@ -510,9 +646,16 @@ func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Req
 						buildRun.Name,
 						pod.CreationTimestamp.Time.Sub(lastTaskRun.GetCreationTimestamp().Time),
 					)
+
+					// the logs are gone once the pod is deleted
+					if r.config.Active().LogArchive.Enabled() {
+						r.archiveLogs(ctx, buildRun, pod)
+					}
 				}
 			}
 
+			r.reportCommitStatus(ctx, buildRun)
+
 			ctxlog.Info(ctx, "updating buildRun status", namespace, request.Namespace, name, request.Name)
 			if err := r.client.Status().Update(ctx, buildRun); err != nil {
 				return reconcile.Result{}, err
@@ -547,20 +690,24 @@ func (r *ReconcileBuildRun) VerifyRequestName(ctx context.Context, request recon
 				// We ignore the errors from the following call, because the parent call of this function will always
 				// return back a reconcile.Result{}, nil. This is done to avoid infinite reconcile loops when a BuildRun
 				// does not longer exists
-				_ = resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, fmt.Sprintf("taskRun %s doesn't exist", request.Name), resources.ConditionTaskRunIsMissing)
+				_ = r.updateConditionWithFalseStatus(ctx, buildRun, fmt.Sprintf("taskRun %s doesn't exist", request.Name), resources.ConditionTaskRunIsMissing)
 			}
 		}
 	}
 }
 
 func (r *ReconcileBuildRun) getReferencedStrategy(ctx context.Context, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun) (strategy buildv1beta1.BuilderStrategy, err error) {
+	if build.Spec.Strategy.Version != nil {
+		return r.getReferencedStrategyRevision(ctx, build, buildRun)
+	}
+
 	if build.Spec.Strategy.Kind == nil {
 		// If the strategy Kind is not specified, we default to a namespaced-scope strategy
 		ctxlog.Info(ctx, "missing strategy Kind, defaulting to a namespaced-scope one", buildRun.Name, build.Name, namespace)
 		strategy, err = resources.RetrieveBuildStrategy(ctx, r.client, build)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
-				if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), resources.BuildStrategyNotFound); updateErr != nil {
+				if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.BuildStrategyNotFound); updateErr != nil {
 					return nil, resources.HandleError("failed to get referenced strategy", err, updateErr)
 				}
 			}
@@ -573,7 +720,7 @@ func (r *ReconcileBuildRun) getReferencedStrategy(ctx context.Context, build *bu
 		strategy, err = resources.RetrieveBuildStrategy(ctx, r.client, build)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
-				if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), resources.BuildStrategyNotFound); updateErr != nil {
+				if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.BuildStrategyNotFound); updateErr != nil {
 					return nil, resources.HandleError("failed to get referenced strategy", err, updateErr)
 				}
 			}
@@ -582,14 +729,14 @@ func (r *ReconcileBuildRun) getReferencedStrategy(ctx context.Context, build *bu
 		strategy, err = resources.RetrieveClusterBuildStrategy(ctx, r.client, build)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
-				if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), resources.ClusterBuildStrategyNotFound); updateErr != nil {
+				if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.ClusterBuildStrategyNotFound); updateErr != nil {
 					return nil, resources.HandleError("failed to get referenced strategy", err, updateErr)
 				}
 			}
 		}
 	default:
 		err = fmt.Errorf("unknown strategy %s", string(*build.Spec.Strategy.Kind))
-		if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), resources.ConditionUnknownStrategyKind); updateErr != nil {
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.ConditionUnknownStrategyKind); updateErr != nil {
 			return nil, resources.HandleError("failed to get referenced strategy", err, updateErr)
 		}
 	}
@@ -597,14 +744,348 @@ func (r *ReconcileBuildRun) getReferencedStrategy(ctx context.Context, build *bu
 	return strategy, err
 }
 
+// getReferencedStrategyRevision returns the revision of the strategy version that the Build pins
+func (r *ReconcileBuildRun) getReferencedStrategyRevision(ctx context.Context, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun) (strategy buildv1beta1.BuilderStrategy, err error) {
+	kind := buildv1beta1.NamespacedBuildStrategyKind
+	if build.Spec.Strategy.Kind != nil {
+		kind = *build.Spec.Strategy.Kind
+	}
+
+	var reason string
+	switch kind {
+	case buildv1beta1.NamespacedBuildStrategyKind:
+		strategy, err = resources.RetrieveBuildStrategyRevision(ctx, r.client, build)
+		reason = resources.BuildStrategyRevisionNotFound
+	case buildv1beta1.ClusterBuildStrategyKind:
+		strategy, err = resources.RetrieveClusterBuildStrategyRevision(ctx, r.client, build)
+		reason = resources.ClusterBuildStrategyRevisionNotFound
+	default:
+		err = fmt.Errorf("unknown strategy %s", string(kind))
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.ConditionUnknownStrategyKind); updateErr != nil {
+			return nil, resources.HandleError("failed to get referenced strategy", err, updateErr)
+		}
+		return nil, err
+	}
+
+	if err != nil && apierrors.IsNotFound(err) {
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), reason); updateErr != nil {
+			return nil, resources.HandleError("failed to get referenced strategy", err, updateErr)
+		}
+	}
+
+	return strategy, err
+}
+
+// resolveStrategyFragments merges the ClusterStrategyFragments that the strategy references into it
+func (r *ReconcileBuildRun) resolveStrategyFragments(ctx context.Context, buildRun *buildv1beta1.BuildRun, strategy buildv1beta1.BuilderStrategy) (buildv1beta1.BuilderStrategy, error) {
+	resolved, err := resources.ResolveStrategyFragments(ctx, r.client, strategy)
+	if err != nil {
+		var reason string
+		switch {
+		case apierrors.IsNotFound(err):
+			reason = resources.StrategyFragmentNotFound
+		case resources.IsStrategyFragmentConflictError(err):
+			reason = resources.StrategyFragmentConflict
+		default:
+			return nil, err
+		}
+
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), reason); updateErr != nil {
+			return nil, resources.HandleError("failed to resolve strategy fragments", err, updateErr)
+		}
+	}
+
+	return resolved, err
+}
+
+// checkClusterBuildStrategyAllowed checks that the namespace of the BuildRun is allowed to use the ClusterBuildStrategy
+func (r *ReconcileBuildRun) checkClusterBuildStrategyAllowed(ctx context.Context, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun, strategy buildv1beta1.BuilderStrategy) error {
+	if build.Spec.Strategy.Kind == nil || *build.Spec.Strategy.Kind != buildv1beta1.ClusterBuildStrategyKind {
+		return nil
+	}
+
+	err := resources.CheckClusterBuildStrategyAllowed(ctx, r.client, buildRun.Namespace, build.Spec.Strategy.Name, strategy)
+	if err != nil {
+		var reason string
+		switch {
+		case resources.IsClusterBuildStrategyNotAllowedError(err):
+			reason = resources.ClusterBuildStrategyNotAllowed
+		case apierrors.IsNotFound(err):
+			reason = resources.ClusterBuildStrategyNotFound
+		default:
+			return err
+		}
+
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), reason); updateErr != nil {
+			return resources.HandleError("failed to check the access to the ClusterBuildStrategy", err, updateErr)
+		}
+	}
+
+	return err
+}
+
+// enforceBuildQuotas checks the TaskRun of the BuildRun against the BuildQuotas of the namespace, reserves room for the
+// BuildRun in them, and deletes the oldest completed BuildRuns above the retained maximum. It returns true if the BuildRun
+// has to wait for running BuildRuns. The BuildRuns of a namespace are admitted one at a time with a usage that is read
+// from the API server, waiting BuildRuns in the order of their creation.
+func (r *ReconcileBuildRun) enforceBuildQuotas(ctx context.Context, buildRun *buildv1beta1.BuildRun, taskRun *pipelineapi.TaskRun) (bool, error) {
+	quotaList := &buildv1beta1.BuildQuotaList{}
+	if err := r.client.List(ctx, quotaList, client.InNamespace(buildRun.Namespace)); err != nil {
+		return false, err
+	}
+
+	if len(quotaList.Items) == 0 {
+		return false, nil
+	}
+
+	lock, _ := r.buildQuotaLocks.LoadOrStore(buildRun.Namespace, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	// A BuildRun with a reservation was admitted before, but its TaskRun was not created yet
+	reserved := resources.HasBuildQuotaReservation(buildRun)
+	if !reserved {
+		waiting, err := resources.BuildRunsWaitingBefore(ctx, r.apiReader, buildRun)
+		if err != nil {
+			return false, err
+		}
+
+		if waiting > 0 {
+			ctxlog.Info(ctx, "buildRun waits for the BuildRuns that are ahead of it", namespace, buildRun.Namespace, name, buildRun.Name, "waiting", waiting)
+			message := fmt.Sprintf("the BuildRun waits for the BuildQuotas, %d BuildRuns that were created before it wait as well", waiting)
+			return true, resources.UpdateConditionWithWaitingStatus(ctx, r.client, buildRun, message, resources.WaitingForBuildQuota)
+		}
+	}
+
+	usage, err := resources.BuildQuotaUsage(ctx, r.apiReader, buildRun.Namespace, buildRun.Name)
+	if err != nil {
+		return false, err
+	}
+
+	var maxRetained *int32
+	for i := range quotaList.Items {
+		quota := &quotaList.Items[i]
+
+		err := resources.CheckBuildQuota(quota, usage, taskRun)
+		switch {
+		case err == nil:
+
+		case resources.IsBuildQuotaExceededError(err):
+			if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.BuildQuotaExceeded); updateErr != nil {
+				return false, resources.HandleError("failed to enforce the BuildQuotas", err, updateErr)
+			}
+			return false, err
+
+		case resources.IsBuildQuotaWaitError(err):
+			ctxlog.Info(ctx, "buildRun waits for the BuildQuota", namespace, buildRun.Namespace, name, buildRun.Name, "BuildQuota", quota.Name)
+			return true, resources.UpdateConditionWithWaitingStatus(ctx, r.client, buildRun, err.Error(), resources.WaitingForBuildQuota)
+
+		default:
+			return false, err
+		}
+
+		if quota.Spec.MaxRetainedBuildRuns != nil && (maxRetained == nil || *quota.Spec.MaxRetainedBuildRuns < *maxRetained) {
+			maxRetained = quota.Spec.MaxRetainedBuildRuns
+		}
+	}
+
+	if !reserved {
+		if err := resources.ReserveBuildQuota(ctx, r.client, buildRun, taskRun); err != nil {
+			return false, err
+		}
+	}
+
+	if maxRetained != nil {
+		return false, resources.PruneBuildRuns(ctx, r.client, buildRun.Namespace, *maxRetained)
+	}
+
+	return false, nil
+}
+
+// strategyResult returns which strategy the BuildRun uses
+func strategyResult(build *buildv1beta1.Build, strategy buildv1beta1.BuilderStrategy) *buildv1beta1.StrategyResult {
+	result := &buildv1beta1.StrategyResult{
+		Kind:       buildv1beta1.NamespacedBuildStrategyKind,
+		Name:       build.Spec.Strategy.Name,
+		Generation: strategy.GetGeneration(),
+	}
+
+	if build.Spec.Strategy.Kind != nil {
+		result.Kind = *build.Spec.Strategy.Kind
+	}
+
+	if build.Spec.Strategy.Version != nil {
+		result.Version = *build.Spec.Strategy.Version
+		result.Revision = strategy.GetName()
+	}
+
+	return result
+}
+
+// updateConditionWithFalseStatus marks the BuildRun as failed and records
+// the failure as a Warning event
+func (r *ReconcileBuildRun) updateConditionWithFalseStatus(ctx context.Context, buildRun *buildv1beta1.BuildRun, errorMessage string, reason string) error {
+	if err := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, errorMessage, reason); err != nil {
+		return err
+	}
+
+	r.completeBuildRun(ctx, buildRun)
+	return nil
+}
+
+// completeBuildRun records the event of the completed BuildRun, and schedules the
+// delivery of the completion event to its notifications (mutates)
+func (r *ReconcileBuildRun) completeBuildRun(ctx context.Context, buildRun *buildv1beta1.BuildRun) {
+	r.recordConditionEvent(buildRun)
+	notification.Schedule(ctx, r.apiReader, buildRun, notification.CompletionEvent(buildRun))
+}
+
+// reconcileFollowUps performs the work that remains after the BuildRun was synchronized,
+// which is the delivery of pending notifications, and the retry of a failed commit status
+// report or log archival. The result is extended to requeue the BuildRun when the next
+// attempt is due.
+func (r *ReconcileBuildRun) reconcileFollowUps(ctx context.Context, buildRun *buildv1beta1.BuildRun, result reconcile.Result) (reconcile.Result, error) {
+	requeueAfter := func(next time.Duration) {
+		next = max(next, time.Millisecond)
+		if result.RequeueAfter == 0 || next < result.RequeueAfter {
+			result.RequeueAfter = next
+		}
+	}
+
+	updated, next := notification.Deliver(ctx, r.apiReader, buildRun)
+	if next > 0 {
+		requeueAfter(next)
+	}
+
+	if wait, retry := commitstatus.RetryIn(buildRun); retry {
+		if wait <= 0 {
+			r.reportCommitStatus(ctx, buildRun)
+			updated = true
+			wait, retry = commitstatus.RetryIn(buildRun)
+		}
+
+		if retry {
+			requeueAfter(wait)
+		}
+	}
+
+	if wait, retry := logarchive.RetryIn(buildRun); retry && r.config.Active().LogArchive.Enabled() {
+		if wait <= 0 {
+			r.retryArchiveLogs(ctx, buildRun)
+			updated = true
+			wait, retry = logarchive.RetryIn(buildRun)
+		}
+
+		if retry {
+			requeueAfter(wait)
+		}
+	}
+
+	if updated {
+		ctxlog.Info(ctx, "updating the follow-ups of the BuildRun", namespace, buildRun.Namespace, name, buildRun.Name)
+		if err := r.client.Status().Update(ctx, buildRun); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// recordConditionEvent records an event with the reason and message of the
+// Succeeded condition of the BuildRun, a Normal event if the BuildRun
+// succeeded, and a Warning event if it failed
+func (r *ReconcileBuildRun) recordConditionEvent(buildRun *buildv1beta1.BuildRun) {
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+	if condition == nil {
+		return
+	}
+
+	switch condition.Status {
+	case corev1.ConditionTrue:
+		r.recorder.Event(buildRun, corev1.EventTypeNormal, condition.Reason, condition.Message)
+
+	case corev1.ConditionFalse:
+		r.recorder.Event(buildRun, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+}
+
+// archiveLogs archives the logs of the steps of the BuildRun, and records where
+// they were archived in the BuildRun status (mutates)
+func (r *ReconcileBuildRun) archiveLogs(ctx context.Context, buildRun *buildv1beta1.BuildRun, pod *corev1.Pod) {
+	attempts := 1
+	if buildRun.Status.LogArchive != nil {
+		attempts = buildRun.Status.LogArchive.Attempts + 1
+	}
+
+	stepLogs, err := logarchive.Collect(ctx, pod, r.podLogs)
+	if err != nil {
+		buildRun.Status.LogArchive = &buildv1beta1.LogArchiveResult{Time: metav1.Now(), Error: err.Error()}
+	} else {
+		buildRun.Status.LogArchive = logarchive.Archive(ctx, r.config.Active().LogArchive, buildRun, stepLogs)
+	}
+
+	buildRun.Status.LogArchive.Attempts = attempts
+	if buildRun.Status.LogArchive.Error != "" {
+		ctxlog.Info(ctx, "failed to archive the logs of the BuildRun", namespace, buildRun.Namespace, name, buildRun.Name, "error", buildRun.Status.LogArchive.Error)
+	}
+}
+
+// retryArchiveLogs archives the logs of the steps of the BuildRun again after the
+// last archival failed (mutates). The archival is given up once the pod does not exist
+// anymore.
+func (r *ReconcileBuildRun) retryArchiveLogs(ctx context.Context, buildRun *buildv1beta1.BuildRun) {
+	last := buildRun.Status.LogArchive
+
+	pod, err := r.getPod(ctx, buildRun)
+	if err == nil {
+		r.archiveLogs(ctx, buildRun, pod)
+		return
+	}
+
+	buildRun.Status.LogArchive = &buildv1beta1.LogArchiveResult{Time: metav1.Now(), Error: err.Error(), Attempts: last.Attempts + 1}
+	if apierrors.IsNotFound(err) {
+		ctxlog.Info(ctx, "giving up to archive the logs of the BuildRun, the pod does not exist anymore", namespace, buildRun.Namespace, name, buildRun.Name)
+		buildRun.Status.LogArchive.Error = fmt.Sprintf("the pod does not exist anymore, the last archival failed: %s", last.Error)
+		buildRun.Status.LogArchive.Attempts = logarchive.MaxAttempts
+	}
+}
+
+// getPod returns the pod of the TaskRun that executed the BuildRun
+func (r *ReconcileBuildRun) getPod(ctx context.Context, buildRun *buildv1beta1.BuildRun) (*corev1.Pod, error) {
+	if buildRun.Status.Executor == nil {
+		return nil, apierrors.NewNotFound(pipelineapi.Resource("taskruns"), buildRun.Name)
+	}
+
+	taskRun, err := r.taskRunnerFactory.GetImageBuildRunner(ctx, r.client, types.NamespacedName{Namespace: buildRun.Namespace, Name: buildRun.Status.Executor.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: taskRun.GetPodName()}, pod); err != nil {
+		return nil, err
+	}
+
+	return pod, nil
+}
+
+// reportCommitStatus reports the state of the BuildRun as commit status of the
+// built commit, and records the report in the BuildRun status (mutates)
+func (r *ReconcileBuildRun) reportCommitStatus(ctx context.Context, buildRun *buildv1beta1.BuildRun) {
+	if result := commitstatus.Report(ctx, r.apiReader, buildRun); result != nil {
+		buildRun.Status.CommitStatus = result
+	}
+}
+
 func (r *ReconcileBuildRun) createTaskRun(ctx context.Context, serviceAccount *corev1.ServiceAccount, strategy buildv1beta1.BuilderStrategy, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun) (*pipelineapi.TaskRun, error) {
 	var (
 		generatedTaskRun *pipelineapi.TaskRun
 	)
 
-	generatedTaskRun, err := resources.GenerateTaskRun(r.config, build, buildRun, serviceAccount.Name, strategy)
+	// the active configuration is used, so that a reloaded configuration applies to new BuildRuns
+	generatedTaskRun, err := resources.GenerateTaskRun(r.config.Active(), build, buildRun, serviceAccount.Name, strategy)
 	if err != nil {
-		if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), resources.ConditionTaskRunGenerationFailed); updateErr != nil {
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.ConditionTaskRunGenerationFailed); updateErr != nil {
 			return nil, resources.HandleError("failed to create taskrun runtime object", err, updateErr)
 		}
 
@@ -613,7 +1094,7 @@ func (r *ReconcileBuildRun) createTaskRun(ctx context.Context, serviceAccount *c
 
 	// Set OwnerReference for BuildRun and TaskRun
 	if err := r.setOwnerReferenceFunc(buildRun, generatedTaskRun, r.scheme); err != nil {
-		if updateErr := resources.UpdateConditionWithFalseStatus(ctx, r.client, buildRun, err.Error(), resources.ConditionSetOwnerReferenceFailed); updateErr != nil {
+		if updateErr := r.updateConditionWithFalseStatus(ctx, buildRun, err.Error(), resources.ConditionSetOwnerReferenceFailed); updateErr != nil {
 			return nil, resources.HandleError("failed to create taskrun runtime object", err, updateErr)
 		}
 
@@ -7,6 +7,8 @@ package buildrun_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	knativeapi "knative.dev/pkg/apis"
 	knativev1 "knative.dev/pkg/apis/duck/v1"
@@ -33,6 +36,8 @@ import (
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/logarchive"
+	"github.com/shipwright-io/build/pkg/notification"
 	buildrunctl "github.com/shipwright-io/build/pkg/reconciler/buildrun"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 	test "github.com/shipwright-io/build/test/v1beta1_samples"
@@ -49,6 +54,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 		buildRunSample                                         *build.BuildRun
 		taskRunSample                                          *pipelineapi.TaskRun
 		statusWriter                                           *fakes.FakeStatusWriter
+		recorder                                               *record.FakeRecorder
 		taskRunName, buildRunName, buildName, strategyName, ns string
 	)
 
@@ -91,6 +97,8 @@ var _ = Describe("Reconcile BuildRun", func() {
 		apis.AddToScheme(scheme.Scheme)
 		manager = &fakes.FakeManager{}
 		manager.GetSchemeReturns(scheme.Scheme)
+		recorder = record.NewFakeRecorder(10)
+		manager.GetEventRecorderForReturns(recorder)
 
 		// initialize the fake client and let the
 		// client know on the stubs when get calls are executed
@@ -102,6 +110,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 		statusWriter = &fakes.FakeStatusWriter{}
 		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
 		manager.GetClientReturns(client)
+		manager.GetAPIReaderReturns(&fakes.FakeClient{})
 
 		// init the Build resource, this never change throughout this test suite
 		buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
@@ -213,6 +222,109 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(client.StatusCallCount()).To(Equal(0))
 			})
 
+			It("retries a failed commit status report of a completed BuildRun", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+				}))
+				defer server.Close()
+
+				apiReader := &fakes.FakeClient{}
+				apiReader.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+					if secret, ok := object.(*corev1.Secret); ok {
+						secret.Data = map[string][]byte{"token": []byte("s3cr3t")}
+					}
+					return nil
+				})
+				manager.GetAPIReaderReturns(apiReader)
+				reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager, controllerutil.SetControllerReference)
+
+				buildRunSample = ctl.BuildRunWithSAGenerate(buildRunName, buildName)
+				buildRunSample.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+				buildRunSample.Status.BuildSpec = &build.BuildSpec{
+					Source: &build.Source{
+						Type: build.GitType,
+						Git: &build.Git{
+							URL:          server.URL + "/shipwright-io/sample-go",
+							CommitStatus: &build.GitCommitStatus{Secret: "forge-token", Forge: ptr.To(build.GitForgeGitHub)},
+						},
+					},
+				}
+				buildRunSample.Status.Source = &build.SourceResult{Git: &build.GitSourceResult{CommitSha: "0123456789abcdef"}}
+				buildRunSample.Status.CommitStatus = &build.CommitStatusResult{
+					CommitSha: "0123456789abcdef",
+					State:     build.CommitStatePending,
+					Time:      metav1.NewTime(time.Now().Add(-time.Minute)),
+					Error:     "GitHub API responded with 502 Bad Gateway",
+					Attempts:  1,
+				}
+
+				client.GetCalls(ctl.StubBuildRunAndTaskRun(buildRunSample, taskRunSample))
+
+				var commitStatus *build.CommitStatusResult
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					commitStatus = o.(*build.BuildRun).Status.CommitStatus
+					return nil
+				})
+
+				result, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(commitStatus.Error).To(BeEmpty())
+				Expect(commitStatus.Attempts).To(Equal(2))
+			})
+
+			It("gives up a failed log archival of a completed BuildRun once the pod is gone", func() {
+				cfg := config.NewDefaultConfig()
+				cfg.LogArchive.Directory = GinkgoT().TempDir()
+				reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+
+				buildRunSample = ctl.BuildRunWithSAGenerate(buildRunName, buildName)
+				buildRunSample.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+				buildRunSample.Status.Executor = &build.BuildExecutor{Name: taskRunName, Kind: "TaskRun"}
+				buildRunSample.Status.LogArchive = &build.LogArchiveResult{
+					Time:     metav1.NewTime(time.Now().Add(-time.Minute)),
+					Error:    "failed to get the log of container step-build-and-push",
+					Attempts: 1,
+				}
+
+				client.GetCalls(ctl.StubBuildRunAndTaskRun(buildRunSample, taskRunSample))
+
+				var logArchive *build.LogArchiveResult
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					logArchive = o.(*build.BuildRun).Status.LogArchive
+					return nil
+				})
+
+				result, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+				Expect(logArchive.Error).To(ContainSubstring("the pod does not exist anymore"))
+				Expect(logArchive.Attempts).To(Equal(logarchive.MaxAttempts))
+			})
+
+			It("requeues a completed BuildRun until the retry of a failed log archival is due", func() {
+				cfg := config.NewDefaultConfig()
+				cfg.LogArchive.Directory = GinkgoT().TempDir()
+				reconciler = buildrunctl.NewReconciler(cfg, manager, controllerutil.SetControllerReference)
+
+				buildRunSample = ctl.BuildRunWithSAGenerate(buildRunName, buildName)
+				buildRunSample.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+				buildRunSample.Status.LogArchive = &build.LogArchiveResult{
+					Time:     metav1.Now(),
+					Error:    "failed to get the log of container step-build-and-push",
+					Attempts: 1,
+				}
+
+				client.GetCalls(ctl.StubBuildRunAndTaskRun(buildRunSample, taskRunSample))
+
+				result, err := reconciler.Reconcile(context.TODO(), taskRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 10*time.Second, time.Second))
+				Expect(client.StatusCallCount()).To(Equal(0))
+			})
+
 			It("deletes a generated service account when the task run ends", func() {
 
 				// setup a buildrun to use a generated service account
@@ -590,6 +702,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
 				Expect(err).To(BeNil())
 				Expect(resources.IsClientStatusUpdateError(err)).To(BeFalse())
+				Expect(recorder.Events).To(Receive(HavePrefix("Warning BuildNotFound")))
 			})
 
 			It("should return an error and continue reconciling if referenced Build is not found and the status update fails", func() {
@@ -984,6 +1097,7 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(client.CreateCallCount()).To(Equal(1))
+				Expect(recorder.Events).To(Receive(Equal(fmt.Sprintf("Normal TaskRunCreated Created TaskRun %s", taskRunName))))
 			})
 
 			It("succeeds creating a TaskRun from a cluster buildstrategy", func() {
@@ -1013,6 +1127,292 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 
+			It("fails on a TaskRun creation due to a missing revision of the pinned cluster buildstrategy version", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+				buildSample.Spec.Strategy.Version = ptr.To("1.0.0")
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					nil),
+				)
+
+				statusCall := ctl.StubBuildRunStatus(
+					fmt.Sprintf(" %q not found", strategyName+"-1.0.0"),
+					emptyTaskRunName,
+					build.Condition{
+						Type:   build.Succeeded,
+						Reason: "ClusterBuildStrategyRevisionNotFound",
+						Status: corev1.ConditionFalse,
+					},
+					corev1.ConditionFalse,
+					buildSample.Spec,
+					true,
+				)
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
+			It("fails on a TaskRun creation due to a missing fragment of the cluster buildstrategy", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+
+				strategy := ctl.DefaultClusterBuildStrategy()
+				strategy.Spec.Fragments = []build.StrategyFragmentReference{{Name: "ca-bundle"}}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					strategy,
+					nil),
+				)
+
+				statusCall := ctl.StubBuildRunStatus(
+					`ClusterStrategyFragment "ca-bundle"`,
+					emptyTaskRunName,
+					build.Condition{
+						Type:   build.Succeeded,
+						Reason: "StrategyFragmentNotFound",
+						Status: corev1.ConditionFalse,
+					},
+					corev1.ConditionFalse,
+					buildSample.Spec,
+					true,
+				)
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
+			It("fails on a TaskRun creation due to a cluster buildstrategy that the namespace is not allowed to use", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+
+				strategy := ctl.DefaultClusterBuildStrategy()
+				strategy.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tenant-tier": "trusted"}}
+
+				getStrategies := ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					strategy,
+					nil,
+				)
+				client.GetCalls(func(ctx context.Context, nn types.NamespacedName, object crc.Object, opts ...crc.GetOption) error {
+					if namespace, ok := object.(*corev1.Namespace); ok {
+						namespace.Name = nn.Name
+						return nil
+					}
+					return getStrategies(ctx, nn, object, opts...)
+				})
+
+				statusCall := ctl.StubBuildRunStatus(
+					"does not match its namespaceSelector",
+					emptyTaskRunName,
+					build.Condition{
+						Type:   build.Succeeded,
+						Reason: "ClusterBuildStrategyNotAllowed",
+						Status: corev1.ConditionFalse,
+					},
+					corev1.ConditionFalse,
+					buildSample.Spec,
+					true,
+				)
+				statusWriter.UpdateCalls(statusCall)
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
+			Context("with a BuildQuota in the namespace", func() {
+				var apiReader *fakes.FakeClient
+				var buildRuns []build.BuildRun
+				var taskRuns []pipelineapi.TaskRun
+
+				BeforeEach(func() {
+					buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+					buildRuns, taskRuns = nil, nil
+
+					client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+						buildSample,
+						buildRunSample,
+						ctl.DefaultServiceAccount(saName),
+						ctl.DefaultClusterBuildStrategy(),
+						nil),
+					)
+					client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+						if list, ok := list.(*build.BuildQuotaList); ok {
+							list.Items = []build.BuildQuota{{
+								ObjectMeta: metav1.ObjectMeta{Name: "build-quota"},
+								Spec:       build.BuildQuotaSpec{MaxConcurrentBuildRuns: ptr.To[int32](1)},
+							}}
+						}
+						return nil
+					})
+
+					// the usage is read from the API server instead of the cache
+					apiReader = &fakes.FakeClient{}
+					apiReader.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+						switch list := list.(type) {
+						case *build.BuildRunList:
+							list.Items = buildRuns
+						case *pipelineapi.TaskRunList:
+							list.Items = taskRuns
+						}
+						return nil
+					})
+					manager.GetAPIReaderReturns(apiReader)
+					reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager, controllerutil.SetControllerReference)
+				})
+
+				It("waits without creating a TaskRun while the BuildQuota is used up", func() {
+					taskRuns = []pipelineapi.TaskRun{{}}
+
+					result, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(client.CreateCallCount()).To(Equal(0))
+					Expect(client.PatchCallCount()).To(Equal(0))
+
+					_, object, _ := statusWriter.UpdateArgsForCall(statusWriter.UpdateCallCount() - 1)
+					condition := object.(*build.BuildRun).Status.GetCondition(build.Succeeded)
+					Expect(condition.Status).To(Equal(corev1.ConditionUnknown))
+					Expect(condition.Reason).To(Equal("WaitingForBuildQuota"))
+				})
+
+				It("waits while a BuildRun with a reservation has no TaskRun yet", func() {
+					buildRuns = []build.BuildRun{{ObjectMeta: metav1.ObjectMeta{
+						Name:        "admitted-buildrun",
+						Annotations: map[string]string{build.AnnotationBuildQuotaReservation: "{}"},
+					}}}
+
+					result, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(client.CreateCallCount()).To(Equal(0))
+				})
+
+				It("waits behind an older BuildRun that waits for the BuildQuota", func() {
+					buildRunSample.CreationTimestamp = metav1.Now()
+					buildRuns = []build.BuildRun{{
+						ObjectMeta: metav1.ObjectMeta{Name: "older-buildrun", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+						Status: build.BuildRunStatus{Conditions: build.Conditions{{
+							Type:   build.Succeeded,
+							Status: corev1.ConditionUnknown,
+							Reason: "WaitingForBuildQuota",
+						}}},
+					}}
+
+					result, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(client.CreateCallCount()).To(Equal(0))
+
+					_, object, _ := statusWriter.UpdateArgsForCall(statusWriter.UpdateCallCount() - 1)
+					condition := object.(*build.BuildRun).Status.GetCondition(build.Succeeded)
+					Expect(condition.Reason).To(Equal("WaitingForBuildQuota"))
+					Expect(condition.Message).To(ContainSubstring("1 BuildRuns that were created before it wait as well"))
+				})
+
+				It("reserves the BuildQuota before it creates the TaskRun", func() {
+					result, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(client.PatchCallCount()).To(Equal(1))
+					Expect(client.CreateCallCount()).To(Equal(1))
+
+					_, object, _, _ := client.PatchArgsForCall(0)
+					Expect(object.GetAnnotations()).To(HaveKey(build.AnnotationBuildQuotaReservation))
+				})
+			})
+
+			It("succeeds creating a TaskRun with the steps of the fragments of the cluster buildstrategy", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+
+				strategy := ctl.DefaultClusterBuildStrategy()
+				strategy.Spec.Fragments = []build.StrategyFragmentReference{{Name: "ca-bundle"}}
+
+				getStrategies := ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					strategy,
+					nil,
+				)
+				client.GetCalls(func(ctx context.Context, nn types.NamespacedName, object crc.Object, opts ...crc.GetOption) error {
+					if fragment, ok := object.(*build.ClusterStrategyFragment); ok {
+						fragment.Name = nn.Name
+						fragment.Spec.Steps = []build.Step{{Name: "inject-ca-bundle", Image: "ubi-minimal"}}
+						return nil
+					}
+					return getStrategies(ctx, nn, object, opts...)
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+
+				_, object, _ := client.CreateArgsForCall(0)
+				taskRun, ok := object.(*pipelineapi.TaskRun)
+				Expect(ok).To(BeTrue())
+				Expect(taskRun.Spec.TaskSpec.Steps).To(ContainElement(HaveField("Name", "inject-ca-bundle")))
+			})
+
+			It("succeeds creating a TaskRun from the revision of a pinned cluster buildstrategy version", func() {
+				buildSample = ctl.DefaultBuild(buildName, strategyName, build.ClusterBuildStrategyKind)
+				buildSample.Spec.Strategy.Version = ptr.To("1.0.0")
+
+				getStrategies := ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					nil,
+				)
+				client.GetCalls(func(ctx context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+					if revision, ok := object.(*build.ClusterBuildStrategyRevision); ok {
+						revision.Name = nn.Name
+						revision.Spec = build.BuildStrategyRevisionSpec{
+							Strategy:   strategyName,
+							Version:    "1.0.0",
+							Generation: 3,
+						}
+						return nil
+					}
+
+					return getStrategies(ctx, nn, object, getOptions...)
+				})
+
+				client.CreateCalls(func(_ context.Context, object crc.Object, _ ...crc.CreateOption) error {
+					switch object := object.(type) {
+					case *pipelineapi.TaskRun:
+						Expect(object.Labels).To(HaveKeyWithValue(build.LabelClusterBuildStrategyVersion, "1.0.0"))
+						ctl.DefaultTaskRunWithStatus(taskRunName, buildRunName, ns, corev1.ConditionTrue, "Succeeded").DeepCopyInto(object)
+					}
+					return nil
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(statusWriter.UpdateCallCount() - 1)
+				Expect(object.(*build.BuildRun).Status.Strategy).To(Equal(&build.StrategyResult{
+					Kind:       build.ClusterBuildStrategyKind,
+					Name:       strategyName,
+					Generation: 3,
+					Version:    "1.0.0",
+					Revision:   strategyName + "-1.0.0",
+				}))
+			})
+
 			It("stops creation when a FALSE registered status of the build occurs", func() {
 				// Init the Build with registered status false
 				buildSample = ctl.DefaultBuildWithFalseRegistered(buildName, strategyName, build.ClusterBuildStrategyKind)
@@ -1098,6 +1498,71 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(client.CreateCallCount()).To(Equal(1))
 			})
 
+			It("fails when the push secret of the cache does not exist", func() {
+				buildRunSample = ctl.DefaultBuildRun(buildRunName, buildName)
+				buildRunSample.Spec.Cache = &build.ImageCache{
+					Image:      "registry.example.com/org/cache",
+					PushSecret: ptr.To("cache-secret"),
+				}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					ctl.DefaultNamespacedBuildStrategy()),
+				)
+
+				var condition *build.Condition
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					condition = o.(*build.BuildRun).Status.GetCondition(build.Succeeded)
+					return nil
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(client.CreateCallCount()).To(BeZero())
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(string(build.SpecCacheSecretRefNotFound)))
+				Expect(condition.Message).To(Equal("referenced secret cache-secret not found"))
+			})
+
+			It("fails when the upload secret of the local source does not exist", func() {
+				buildRunSample = ctl.DefaultBuildRun(buildRunName, buildName)
+				buildRunSample.Spec.Source = &build.BuildRunSource{
+					Type: build.LocalType,
+					Local: &build.Local{
+						Method:       ptr.To(build.LocalUploadMethodHTTP),
+						UploadSecret: ptr.To("upload-secret"),
+					},
+				}
+
+				client.GetCalls(ctl.StubBuildRunGetWithSAandStrategies(
+					buildSample,
+					buildRunSample,
+					ctl.DefaultServiceAccount(saName),
+					ctl.DefaultClusterBuildStrategy(),
+					ctl.DefaultNamespacedBuildStrategy()),
+				)
+
+				var condition *build.Condition
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					condition = o.(*build.BuildRun).Status.GetCondition(build.Succeeded)
+					return nil
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(client.CreateCallCount()).To(BeZero())
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(string(build.SpecSourceSecretRefNotFound)))
+				Expect(condition.Message).To(Equal("referenced secret upload-secret not found"))
+			})
+
 			It("updates Build with error when BuildRun is already owned", func() {
 				fakeOwnerName := "fakeOwner"
 
@@ -1258,6 +1723,40 @@ var _ = Describe("Reconcile BuildRun", func() {
 				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
 			})
 
+			It("should notify about a BuildRun that fails before its TaskRun is created", func() {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				}))
+				defer server.Close()
+
+				apiReader := &fakes.FakeClient{}
+				apiReader.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+					if configMap, ok := object.(*corev1.ConfigMap); ok {
+						configMap.Data = map[string]string{notification.ConfigMapKey: "- name: chat\n  url: " + server.URL + "\n"}
+					}
+					return nil
+				})
+				manager.GetAPIReaderReturns(apiReader)
+				reconciler = buildrunctl.NewReconciler(config.NewDefaultConfig(), manager, controllerutil.SetControllerReference)
+
+				buildRunSample = ctl.BuildRunWithoutSA("fööbar", buildName)
+				client.GetCalls(ctl.StubBuildRun(buildRunSample))
+
+				var notifications []build.NotificationDelivery
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					notifications = o.(*build.BuildRun).Status.Notifications
+					return nil
+				})
+
+				result, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(2))
+				Expect(notifications).To(HaveLen(1))
+				Expect(notifications[0].Event).To(Equal(build.NotificationEventFailed))
+				Expect(notifications[0].State).To(Equal(build.NotificationDeliveryDelivered))
+			})
+
 			It("should fail the reconcile if an update call failed during a validation error", func() {
 				buildRunSample = ctl.BuildRunWithoutSA("fööbar", buildName)
 
@@ -1705,6 +2204,31 @@ var _ = Describe("Reconcile BuildRun", func() {
 			})
 		})
 
+		Context("when a buildrun has a buildSpec defined and overrides cache", func() {
+			BeforeEach(func() {
+				buildRunSample = &build.BuildRun{
+					ObjectMeta: metav1.ObjectMeta{Name: buildRunName},
+					Spec: build.BuildRunSpec{
+						Build: build.ReferencedBuild{Spec: &build.BuildSpec{Strategy: build.Strategy{Name: "foobar"}}},
+						Cache: &build.ImageCache{Image: "registry.example.com/org/cache"},
+					},
+				}
+			})
+
+			It("should fail to register", func() {
+				statusWriter.UpdateCalls(func(_ context.Context, o crc.Object, _ ...crc.SubResourceUpdateOption) error {
+					condition := o.(*build.BuildRun).Status.GetCondition(build.Succeeded)
+					Expect(condition.Reason).To(Equal(resources.BuildRunBuildFieldOverrideForbidden))
+					Expect(condition.Message).To(Equal("cannot use 'cache' override and 'buildSpec' simultaneously"))
+					return nil
+				})
+
+				_, err := reconciler.Reconcile(context.TODO(), buildRunRequest)
+				Expect(err).To(BeNil())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when a buildrun has a buildSpec defined and overrides schedulerName", func() {
 			BeforeEach(func() {
 				buildRunSample = ctl.BuildRunWithSchedulerNameOverride(buildRunName, buildName, "testSchedulerName")
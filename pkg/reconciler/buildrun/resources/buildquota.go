@@ -0,0 +1,263 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+// BuildQuotaUsage returns the current usage of the BuildRuns of a namespace. A BuildRun runs while its TaskRun is not done,
+// or while it has a BuildQuota reservation but no TaskRun yet. The reservation of the excluded BuildRun is not counted.
+func BuildQuotaUsage(ctx context.Context, reader client.Reader, namespaceName string, excludedBuildRun string) (*buildv1beta1.BuildQuotaStatus, error) {
+	buildRunList := &buildv1beta1.BuildRunList{}
+	if err := reader.List(ctx, buildRunList, client.InNamespace(namespaceName)); err != nil {
+		return nil, err
+	}
+
+	taskRunList := &pipelineapi.TaskRunList{}
+	if err := reader.List(ctx, taskRunList, client.InNamespace(namespaceName), client.HasLabels{buildv1beta1.LabelBuildRun}); err != nil {
+		return nil, err
+	}
+
+	// #nosec G115, the number of BuildRuns in a namespace does not exceed the int32 range
+	usage := &buildv1beta1.BuildQuotaStatus{RetainedBuildRuns: int32(len(buildRunList.Items))}
+	resources := corev1.ResourceList{}
+	withTaskRun := map[string]struct{}{}
+	for i := range taskRunList.Items {
+		withTaskRun[taskRunList.Items[i].Labels[buildv1beta1.LabelBuildRun]] = struct{}{}
+		if taskRunList.Items[i].IsDone() {
+			continue
+		}
+
+		usage.ConcurrentBuildRuns++
+		addResourceList(resources, TaskRunRequests(&taskRunList.Items[i]))
+	}
+
+	for i := range buildRunList.Items {
+		buildRun := &buildRunList.Items[i]
+		if _, ok := withTaskRun[buildRun.Name]; ok || buildRun.Name == excludedBuildRun || buildRun.Status.CompletionTime != nil {
+			continue
+		}
+
+		reservation, ok := buildRun.Annotations[buildv1beta1.AnnotationBuildQuotaReservation]
+		if !ok {
+			continue
+		}
+
+		usage.ConcurrentBuildRuns++
+		requests := corev1.ResourceList{}
+		if err := json.Unmarshal([]byte(reservation), &requests); err != nil {
+			ctxlog.Info(ctx, "ignoring the resources of an invalid BuildQuota reservation", namespace, namespaceName, name, buildRun.Name, "error", err)
+			continue
+		}
+		addResourceList(resources, requests)
+	}
+
+	if len(resources) > 0 {
+		usage.Resources = resources
+	}
+
+	return usage, nil
+}
+
+// HasBuildQuotaReservation returns whether the BuildRun was already admitted by the BuildQuotas of its namespace
+func HasBuildQuotaReservation(buildRun *buildv1beta1.BuildRun) bool {
+	_, ok := buildRun.Annotations[buildv1beta1.AnnotationBuildQuotaReservation]
+	return ok
+}
+
+// ReserveBuildQuota records the resource requests of the TaskRun in a BuildQuota reservation on the BuildRun, so that
+// the BuildRun is counted in the usage of the namespace before its TaskRun is created. Only the annotation is patched,
+// so that pending changes of the status of the BuildRun are kept.
+func ReserveBuildQuota(ctx context.Context, c client.Client, buildRun *buildv1beta1.BuildRun, taskRun *pipelineapi.TaskRun) error {
+	reservation, err := json.Marshal(TaskRunRequests(taskRun))
+	if err != nil {
+		return err
+	}
+
+	reserved := buildRun.DeepCopy()
+	if reserved.Annotations == nil {
+		reserved.Annotations = map[string]string{}
+	}
+	reserved.Annotations[buildv1beta1.AnnotationBuildQuotaReservation] = string(reservation)
+
+	ctxlog.Debug(ctx, "reserving the BuildQuota", namespace, buildRun.Namespace, name, buildRun.Name, "requests", string(reservation))
+	if err := c.Patch(ctx, reserved, client.MergeFrom(buildRun)); err != nil {
+		return err
+	}
+
+	buildRun.Annotations = reserved.Annotations
+	buildRun.ResourceVersion = reserved.ResourceVersion
+	return nil
+}
+
+// BuildRunsWaitingBefore returns the number of BuildRuns of the namespace that wait for a BuildQuota and are ahead of
+// the BuildRun. Waiting BuildRuns are admitted in the order of their creation, BuildRuns created at the same time in
+// the order of their names.
+func BuildRunsWaitingBefore(ctx context.Context, reader client.Reader, buildRun *buildv1beta1.BuildRun) (int, error) {
+	buildRunList := &buildv1beta1.BuildRunList{}
+	if err := reader.List(ctx, buildRunList, client.InNamespace(buildRun.Namespace)); err != nil {
+		return 0, err
+	}
+
+	var count int
+	for i := range buildRunList.Items {
+		other := &buildRunList.Items[i]
+		if !isWaitingForBuildQuota(other) || !createdBefore(other, buildRun) {
+			continue
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// TaskRunRequests returns the total of the resource requests of the steps and sidecars of a TaskRun. Like for
+// containers, the limit of a resource is its request if the request is not set.
+func TaskRunRequests(taskRun *pipelineapi.TaskRun) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	if taskRun.Spec.TaskSpec == nil {
+		return requests
+	}
+
+	for _, step := range taskRun.Spec.TaskSpec.Steps {
+		addResourceList(requests, effectiveRequests(step.ComputeResources))
+	}
+
+	for _, sidecar := range taskRun.Spec.TaskSpec.Sidecars {
+		addResourceList(requests, effectiveRequests(sidecar.ComputeResources))
+	}
+
+	return requests
+}
+
+// CheckBuildQuota checks the TaskRun of a BuildRun against a BuildQuota and the current usage of the namespace. It sets
+// the timeout of a TaskRun without one to the maxTimeout of the BuildQuota. A BuildQuotaExceededError is returned if
+// the BuildRun on its own exceeds a limit, a BuildQuotaWaitError if it exceeds a limit together with the running BuildRuns.
+func CheckBuildQuota(quota *buildv1beta1.BuildQuota, usage *buildv1beta1.BuildQuotaStatus, taskRun *pipelineapi.TaskRun) error {
+	if maxTimeout := quota.Spec.MaxTimeout; maxTimeout != nil {
+		switch {
+		case taskRun.Spec.Timeout == nil:
+			taskRun.Spec.Timeout = maxTimeout.DeepCopy()
+
+		case taskRun.Spec.Timeout.Duration == 0 || taskRun.Spec.Timeout.Duration > maxTimeout.Duration:
+			return BuildQuotaExceededError{quota: quota.Name, message: fmt.Sprintf("the timeout of %s exceeds the maximum of %s", taskRun.Spec.Timeout.Duration, maxTimeout.Duration)}
+		}
+	}
+
+	requests := TaskRunRequests(taskRun)
+	for _, resourceName := range slices.Sorted(maps.Keys(quota.Spec.MaxResources)) {
+		maximum := quota.Spec.MaxResources[resourceName]
+		if request := requests[resourceName]; request.Cmp(maximum) > 0 {
+			return BuildQuotaExceededError{quota: quota.Name, message: fmt.Sprintf("the %s request of %s exceeds the maximum of %s", resourceName, request.String(), maximum.String())}
+		}
+	}
+
+	if quota.Spec.MaxConcurrentBuildRuns != nil && usage.ConcurrentBuildRuns >= *quota.Spec.MaxConcurrentBuildRuns {
+		return BuildQuotaWaitError{quota: quota.Name, message: fmt.Sprintf("%d BuildRuns run, which is the maximum", usage.ConcurrentBuildRuns)}
+	}
+
+	for _, resourceName := range slices.Sorted(maps.Keys(quota.Spec.MaxResources)) {
+		maximum := quota.Spec.MaxResources[resourceName]
+		running, request := usage.Resources[resourceName], requests[resourceName]
+		total := running.DeepCopy()
+		total.Add(request)
+		if total.Cmp(maximum) > 0 {
+			return BuildQuotaWaitError{quota: quota.Name, message: fmt.Sprintf("the running BuildRuns request %s of %s, together with the request of %s this exceeds the maximum of %s", running.String(), resourceName, request.String(), maximum.String())}
+		}
+	}
+
+	return nil
+}
+
+// PruneBuildRuns deletes the oldest completed BuildRuns of a namespace until no more than maxRetained BuildRuns are left
+func PruneBuildRuns(ctx context.Context, c client.Client, namespaceName string, maxRetained int32) error {
+	buildRunList := &buildv1beta1.BuildRunList{}
+	if err := c.List(ctx, buildRunList, client.InNamespace(namespaceName)); err != nil {
+		return err
+	}
+
+	excess := len(buildRunList.Items) - int(maxRetained)
+	if excess <= 0 {
+		return nil
+	}
+
+	var completed []buildv1beta1.BuildRun
+	for _, buildRun := range buildRunList.Items {
+		if buildRun.Status.CompletionTime != nil && buildRun.DeletionTimestamp == nil {
+			completed = append(completed, buildRun)
+		}
+	}
+
+	// Sort buildruns with oldest one at the beginning
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].Status.CompletionTime.Before(completed[j].Status.CompletionTime)
+	})
+
+	for i := 0; i < excess && i < len(completed); i++ {
+		ctxlog.Info(ctx, "Deleting completed buildrun as the retained BuildRuns of the BuildQuota have been reached.", namespace, namespaceName, name, completed[i].Name)
+		if err := c.Delete(ctx, &completed[i], &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isWaitingForBuildQuota returns whether the BuildRun waits for a BuildQuota and has not been admitted in the meantime
+func isWaitingForBuildQuota(buildRun *buildv1beta1.BuildRun) bool {
+	if buildRun.Status.CompletionTime != nil || buildRun.Status.Executor != nil || HasBuildQuotaReservation(buildRun) {
+		return false
+	}
+
+	condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded)
+	return condition != nil && condition.Status == corev1.ConditionUnknown && condition.Reason == WaitingForBuildQuota
+}
+
+// createdBefore returns whether a BuildRun was created before another one, using the name to order BuildRuns that
+// were created in the same second
+func createdBefore(buildRun *buildv1beta1.BuildRun, other *buildv1beta1.BuildRun) bool {
+	if !buildRun.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return buildRun.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+
+	return buildRun.Name < other.Name
+}
+
+func effectiveRequests(resources corev1.ResourceRequirements) corev1.ResourceList {
+	requests := resources.Requests.DeepCopy()
+	for resourceName, limit := range resources.Limits {
+		if _, ok := requests[resourceName]; !ok {
+			if requests == nil {
+				requests = corev1.ResourceList{}
+			}
+			requests[resourceName] = limit.DeepCopy()
+		}
+	}
+
+	return requests
+}
+
+func addResourceList(total corev1.ResourceList, resources corev1.ResourceList) {
+	for resourceName, quantity := range resources {
+		sum := total[resourceName].DeepCopy()
+		sum.Add(quantity)
+		total[resourceName] = sum
+	}
+}
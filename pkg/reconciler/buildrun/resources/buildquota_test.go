@@ -0,0 +1,253 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+var _ = Describe("BuildQuota", func() {
+
+	taskRun := func(cpu string, memory string, done bool) pipelineapi.TaskRun {
+		taskRun := pipelineapi.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{buildv1beta1.LabelBuildRun: "a-buildrun"},
+			},
+			Spec: pipelineapi.TaskRunSpec{
+				TaskSpec: &pipelineapi.TaskSpec{
+					Steps: []pipelineapi.Step{{
+						Name: "build",
+						ComputeResources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+							Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+						},
+					}, {
+						Name: "push",
+						ComputeResources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+						},
+					}},
+				},
+			},
+		}
+
+		if done {
+			taskRun.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}
+		}
+
+		return taskRun
+	}
+
+	Context("TaskRunRequests", func() {
+		It("sums the requests of the steps and uses limits without request", func() {
+			generatedTaskRun := taskRun("500m", "1Gi", false)
+			requests := resources.TaskRunRequests(&generatedTaskRun)
+			Expect(requests.Cpu().String()).To(Equal("600m"))
+			Expect(requests.Memory().String()).To(Equal("1Gi"))
+		})
+	})
+
+	Context("BuildQuotaUsage", func() {
+		It("counts the running BuildRuns and all BuildRuns of the namespace", func() {
+			client := &fakes.FakeClient{}
+			client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+				switch list := list.(type) {
+				case *buildv1beta1.BuildRunList:
+					list.Items = make([]buildv1beta1.BuildRun, 5)
+				case *pipelineapi.TaskRunList:
+					list.Items = []pipelineapi.TaskRun{taskRun("1", "1Gi", false), taskRun("2", "2Gi", false), taskRun("4", "4Gi", true)}
+				}
+				return nil
+			})
+
+			usage, err := resources.BuildQuotaUsage(context.TODO(), client, "a-namespace", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage.ConcurrentBuildRuns).To(Equal(int32(2)))
+			Expect(usage.RetainedBuildRuns).To(Equal(int32(5)))
+			Expect(usage.Resources.Cpu().String()).To(Equal("3200m"))
+			Expect(usage.Resources.Memory().String()).To(Equal("3Gi"))
+		})
+
+		It("counts the reservations of BuildRuns without a TaskRun except for the excluded BuildRun", func() {
+			reserved := func(name string) buildv1beta1.BuildRun {
+				return buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Annotations: map[string]string{buildv1beta1.AnnotationBuildQuotaReservation: `{"cpu":"500m","memory":"1Gi"}`},
+				}}
+			}
+
+			client := &fakes.FakeClient{}
+			client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+				switch list := list.(type) {
+				case *buildv1beta1.BuildRunList:
+					list.Items = []buildv1beta1.BuildRun{reserved("a-buildrun"), reserved("reserved"), reserved("excluded")}
+				case *pipelineapi.TaskRunList:
+					list.Items = []pipelineapi.TaskRun{taskRun("1", "1Gi", false)}
+				}
+				return nil
+			})
+
+			usage, err := resources.BuildQuotaUsage(context.TODO(), client, "a-namespace", "excluded")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(usage.ConcurrentBuildRuns).To(Equal(int32(2)))
+			Expect(usage.Resources.Cpu().String()).To(Equal("1600m"))
+			Expect(usage.Resources.Memory().String()).To(Equal("2Gi"))
+		})
+	})
+
+	Context("ReserveBuildQuota", func() {
+		It("patches the requests of the TaskRun into the annotation of the BuildRun", func() {
+			client := &fakes.FakeClient{}
+			client.PatchCalls(func(_ context.Context, object crc.Object, _ crc.Patch, _ ...crc.PatchOption) error {
+				object.SetResourceVersion("2")
+				return nil
+			})
+
+			buildRun := &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Name: "a-buildrun", ResourceVersion: "1"}}
+			generatedTaskRun := taskRun("500m", "1Gi", false)
+			Expect(resources.ReserveBuildQuota(context.TODO(), client, buildRun, &generatedTaskRun)).To(Succeed())
+			Expect(client.PatchCallCount()).To(Equal(1))
+			Expect(resources.HasBuildQuotaReservation(buildRun)).To(BeTrue())
+			Expect(buildRun.Annotations[buildv1beta1.AnnotationBuildQuotaReservation]).To(Equal(`{"cpu":"600m","memory":"1Gi"}`))
+			Expect(buildRun.ResourceVersion).To(Equal("2"))
+		})
+	})
+
+	Context("BuildRunsWaitingBefore", func() {
+		It("counts the waiting BuildRuns that were created earlier or at the same time with a lower name", func() {
+			now := time.Now()
+			buildRun := func(name string, created time.Time, waiting bool) buildv1beta1.BuildRun {
+				buildRun := buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+				if waiting {
+					buildRun.Status.Conditions = buildv1beta1.Conditions{{
+						Type:   buildv1beta1.Succeeded,
+						Status: corev1.ConditionUnknown,
+						Reason: resources.WaitingForBuildQuota,
+					}}
+				}
+				return buildRun
+			}
+
+			client := &fakes.FakeClient{}
+			client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+				list.(*buildv1beta1.BuildRunList).Items = []buildv1beta1.BuildRun{
+					buildRun("older", now.Add(-time.Minute), true),
+					buildRun("older-not-waiting", now.Add(-time.Minute), false),
+					buildRun("a-same-time", now, true),
+					buildRun("c-same-time", now, true),
+					buildRun("newer", now.Add(time.Minute), true),
+				}
+				return nil
+			})
+
+			current := buildRun("b-same-time", now, true)
+			waiting, err := resources.BuildRunsWaitingBefore(context.TODO(), client, &current)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(waiting).To(Equal(2))
+		})
+	})
+
+	Context("CheckBuildQuota", func() {
+		var quota *buildv1beta1.BuildQuota
+		var usage *buildv1beta1.BuildQuotaStatus
+
+		BeforeEach(func() {
+			quota = &buildv1beta1.BuildQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "a-quota"},
+				Spec: buildv1beta1.BuildQuotaSpec{
+					MaxConcurrentBuildRuns: ptr.To[int32](2),
+					MaxResources:           corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+					MaxTimeout:             &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			}
+			usage = &buildv1beta1.BuildQuotaStatus{
+				ConcurrentBuildRuns: 1,
+				Resources:           corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}
+		})
+
+		It("passes a BuildRun within the quota and sets the maximum timeout", func() {
+			generatedTaskRun := taskRun("500m", "1Gi", false)
+			Expect(resources.CheckBuildQuota(quota, usage, &generatedTaskRun)).To(Succeed())
+			Expect(generatedTaskRun.Spec.Timeout.Duration).To(Equal(30 * time.Minute))
+		})
+
+		It("fails a BuildRun with a timeout above the maximum", func() {
+			generatedTaskRun := taskRun("500m", "1Gi", false)
+			generatedTaskRun.Spec.Timeout = &metav1.Duration{Duration: time.Hour}
+			err := resources.CheckBuildQuota(quota, usage, &generatedTaskRun)
+			Expect(resources.IsBuildQuotaExceededError(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("the timeout of 1h0m0s exceeds the maximum of 30m0s"))
+		})
+
+		It("fails a BuildRun that requests more than the maximum on its own", func() {
+			generatedTaskRun := taskRun("3", "1Gi", false)
+			err := resources.CheckBuildQuota(quota, usage, &generatedTaskRun)
+			Expect(resources.IsBuildQuotaExceededError(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("the cpu request of 3100m exceeds the maximum of 2"))
+		})
+
+		It("lets a BuildRun wait when the maximum of concurrent BuildRuns is reached", func() {
+			usage.ConcurrentBuildRuns = 2
+			generatedTaskRun := taskRun("500m", "1Gi", false)
+			err := resources.CheckBuildQuota(quota, usage, &generatedTaskRun)
+			Expect(resources.IsBuildQuotaWaitError(err)).To(BeTrue())
+		})
+
+		It("lets a BuildRun wait when the running BuildRuns leave too little resources", func() {
+			generatedTaskRun := taskRun("1500m", "1Gi", false)
+			err := resources.CheckBuildQuota(quota, usage, &generatedTaskRun)
+			Expect(resources.IsBuildQuotaWaitError(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("the running BuildRuns request 1 of cpu"))
+		})
+	})
+
+	Context("PruneBuildRuns", func() {
+		It("deletes the oldest completed BuildRuns above the maximum", func() {
+			now := time.Now()
+			buildRun := func(name string, completed *time.Time) buildv1beta1.BuildRun {
+				buildRun := buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Name: name}}
+				if completed != nil {
+					buildRun.Status.CompletionTime = &metav1.Time{Time: *completed}
+				}
+				return buildRun
+			}
+
+			client := &fakes.FakeClient{}
+			client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+				list.(*buildv1beta1.BuildRunList).Items = []buildv1beta1.BuildRun{
+					buildRun("newer", ptr.To(now.Add(-time.Minute))),
+					buildRun("running", nil),
+					buildRun("oldest", ptr.To(now.Add(-time.Hour))),
+					buildRun("newest", ptr.To(now)),
+				}
+				return nil
+			})
+
+			Expect(resources.PruneBuildRuns(context.TODO(), client, "a-namespace", 2)).To(Succeed())
+			Expect(client.DeleteCallCount()).To(Equal(2))
+			_, first, _ := client.DeleteArgsForCall(0)
+			_, second, _ := client.DeleteArgsForCall(1)
+			Expect(first.GetName()).To(Equal("oldest"))
+			Expect(second.GetName()).To(Equal("newer"))
+		})
+	})
+})
@@ -0,0 +1,236 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+const (
+	// DefaultCacheSize is the size of a cache PersistentVolumeClaim if the strategy does not define one
+	DefaultCacheSize = "1Gi"
+
+	// DefaultCacheTTL is the duration after which an unused cache is deleted if the strategy does not define one
+	DefaultCacheTTL = 7 * 24 * time.Hour
+)
+
+// CacheClaimName returns the name of the PersistentVolumeClaim that holds the cache of a
+// strategy volume for a Build. Names that would exceed the limit are shortened with a hash.
+func CacheClaimName(buildName string, volumeName string) string {
+	claimName := fmt.Sprintf("%s-cache-%s", buildName, volumeName)
+	if len(claimName) <= 63 {
+		return claimName
+	}
+
+	hash := sha256.Sum256([]byte(claimName))
+	return fmt.Sprintf("%s-%s", claimName[:54], hex.EncodeToString(hash[:])[:8])
+}
+
+// AcquireCacheVolumes replaces the emptyDir volume of every strategy volume that declares a
+// cache with the cache PersistentVolumeClaim of the Build, provided that no other BuildRun
+// is using it. Otherwise, and for embedded Builds, the emptyDir volume is retained, so that
+// the BuildRun runs without cache instead of waiting for it.
+func AcquireCacheVolumes(ctx context.Context, c client.Client, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun, strategyVolumes []buildv1beta1.BuildStrategyVolume, taskRun *pipelineapi.TaskRun) error {
+	if build.Name == "" || build.UID == "" {
+		return nil
+	}
+
+	for _, strategyVolume := range strategyVolumes {
+		if strategyVolume.Cache == nil || isVolumeOverridden(strategyVolume.Name, build.Spec.Volumes, buildRun.Spec.Volumes) {
+			continue
+		}
+
+		var volume *corev1.Volume
+		for i := range taskRun.Spec.TaskSpec.Volumes {
+			if taskRun.Spec.TaskSpec.Volumes[i].Name == strategyVolume.Name {
+				volume = &taskRun.Spec.TaskSpec.Volumes[i]
+				break
+			}
+		}
+
+		if volume == nil {
+			continue
+		}
+
+		claimName := CacheClaimName(build.Name, strategyVolume.Name)
+		acquired, err := acquireCacheClaim(ctx, c, build, buildRun, strategyVolume, claimName)
+		if err != nil {
+			return err
+		}
+
+		if !acquired {
+			ctxlog.Info(ctx, "cache is in use by another BuildRun, using an emptyDir volume", namespace, buildRun.Namespace, name, buildRun.Name, "volume", strategyVolume.Name)
+			continue
+		}
+
+		volume.VolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claimName,
+			},
+		}
+	}
+
+	return nil
+}
+
+// acquireCacheClaim creates the cache PersistentVolumeClaim, or locks the existing one for the
+// BuildRun. It returns false if the claim is locked by another BuildRun that is still running.
+func acquireCacheClaim(ctx context.Context, c client.Client, build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun, strategyVolume buildv1beta1.BuildStrategyVolume, claimName string) (bool, error) {
+	ttl := DefaultCacheTTL
+	if strategyVolume.Cache.TTL != nil {
+		ttl = strategyVolume.Cache.TTL.Duration
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: buildRun.Namespace, Name: claimName}, claim)
+	switch {
+	case apierrors.IsNotFound(err):
+		size := resource.MustParse(DefaultCacheSize)
+		if strategyVolume.Cache.Size != nil {
+			size = *strategyVolume.Cache.Size
+		}
+
+		claim = &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      claimName,
+				Namespace: buildRun.Namespace,
+				Labels: map[string]string{
+					buildv1beta1.LabelBuild:       build.Name,
+					buildv1beta1.LabelCacheVolume: strategyVolume.Name,
+				},
+				Annotations: map[string]string{
+					buildv1beta1.AnnotationCacheLockedBy: buildRun.Name,
+					buildv1beta1.AnnotationCacheTTL:      ttl.String(),
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(build, buildv1beta1.SchemeGroupVersion.WithKind("Build")),
+				},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				StorageClassName: strategyVolume.Cache.StorageClassName,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: size,
+					},
+				},
+			},
+		}
+
+		if err := c.Create(ctx, claim); err != nil {
+			// another BuildRun created the claim in the meantime
+			if apierrors.IsAlreadyExists(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		ctxlog.Info(ctx, "created cache PersistentVolumeClaim", namespace, buildRun.Namespace, name, buildRun.Name, "PersistentVolumeClaim", claimName)
+		return true, nil
+
+	case err != nil:
+		return false, err
+	}
+
+	if claim.DeletionTimestamp != nil {
+		return false, nil
+	}
+
+	if lockedBy := claim.Annotations[buildv1beta1.AnnotationCacheLockedBy]; lockedBy != "" && lockedBy != buildRun.Name {
+		running, err := isBuildRunRunning(ctx, c, buildRun.Namespace, lockedBy)
+		if err != nil || running {
+			return false, err
+		}
+	}
+
+	if claim.Annotations == nil {
+		claim.Annotations = map[string]string{}
+	}
+
+	claim.Annotations[buildv1beta1.AnnotationCacheLockedBy] = buildRun.Name
+	claim.Annotations[buildv1beta1.AnnotationCacheTTL] = ttl.String()
+
+	// the update fails with a conflict if another BuildRun locked the claim concurrently
+	if err := c.Update(ctx, claim); err != nil {
+		if apierrors.IsConflict(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ReleaseCacheVolumes removes the lock of the BuildRun from the cache PersistentVolumeClaims
+// of its Build, and records the time of the last use for the cache expiration.
+func ReleaseCacheVolumes(ctx context.Context, c client.Client, buildRun *buildv1beta1.BuildRun) error {
+	buildName, ok := buildRun.Labels[buildv1beta1.LabelBuild]
+	if !ok || buildName == "" {
+		return nil
+	}
+
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := c.List(ctx, claims, client.InNamespace(buildRun.Namespace), client.MatchingLabels{buildv1beta1.LabelBuild: buildName}, client.HasLabels{buildv1beta1.LabelCacheVolume}); err != nil {
+		return err
+	}
+
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		if claim.Annotations[buildv1beta1.AnnotationCacheLockedBy] != buildRun.Name {
+			continue
+		}
+
+		delete(claim.Annotations, buildv1beta1.AnnotationCacheLockedBy)
+		claim.Annotations[buildv1beta1.AnnotationCacheLastUsed] = time.Now().UTC().Format(time.RFC3339)
+
+		if err := c.Update(ctx, claim); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isBuildRunRunning returns whether the BuildRun exists and did not complete yet
+func isBuildRunRunning(ctx context.Context, c client.Client, namespace string, name string) (bool, error) {
+	buildRun := &buildv1beta1.BuildRun{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, buildRun); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return buildRun.Status.CompletionTime == nil, nil
+}
+
+func isVolumeOverridden(volumeName string, buildVolumes []buildv1beta1.BuildVolume, buildRunVolumes []buildv1beta1.BuildVolume) bool {
+	for _, volume := range append(append([]buildv1beta1.BuildVolume{}, buildVolumes...), buildRunVolumes...) {
+		if volume.Name == volumeName {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,216 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Cache volumes", func() {
+	var (
+		client          *fakes.FakeClient
+		build           *buildv1beta1.Build
+		buildRun        *buildv1beta1.BuildRun
+		strategyVolumes []buildv1beta1.BuildStrategyVolume
+		taskRun         *pipelineapi.TaskRun
+	)
+
+	BeforeEach(func() {
+		client = &fakes.FakeClient{}
+		build = &buildv1beta1.Build{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobuild", Namespace: "foo", UID: "some-uid"},
+		}
+		buildRun = &buildv1beta1.BuildRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foobuildrun",
+				Namespace: "foo",
+				Labels:    map[string]string{buildv1beta1.LabelBuild: "foobuild"},
+			},
+		}
+		strategyVolumes = []buildv1beta1.BuildStrategyVolume{{
+			Name: "layers",
+			Cache: &buildv1beta1.BuildStrategyVolumeCache{
+				Size: resource.NewQuantity(5*1024*1024*1024, resource.BinarySI),
+			},
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}}
+		taskRun = &pipelineapi.TaskRun{
+			Spec: pipelineapi.TaskRunSpec{
+				TaskSpec: &pipelineapi.TaskSpec{
+					Volumes: []corev1.Volume{{
+						Name:         "layers",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		}
+	})
+
+	// stub client GET calls and return the claim and BuildRun if they are provided
+	var generateGetStub = func(claim *corev1.PersistentVolumeClaim, lockingBuildRun *buildv1beta1.BuildRun) func(context context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+		return func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			switch object := object.(type) {
+			case *corev1.PersistentVolumeClaim:
+				if claim != nil {
+					claim.DeepCopyInto(object)
+					return nil
+				}
+			case *buildv1beta1.BuildRun:
+				if lockingBuildRun != nil {
+					lockingBuildRun.DeepCopyInto(object)
+					return nil
+				}
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+		}
+	}
+
+	Context("naming the cache claim", func() {
+		It("combines the Build and volume name", func() {
+			Expect(resources.CacheClaimName("foobuild", "layers")).To(Equal("foobuild-cache-layers"))
+		})
+
+		It("shortens long names", func() {
+			claimName := resources.CacheClaimName(strings.Repeat("a", 60), "layers")
+			Expect(claimName).To(HaveLen(63))
+			Expect(claimName).To(HavePrefix(strings.Repeat("a", 54) + "-"))
+			Expect(resources.CacheClaimName(strings.Repeat("a", 60), "layers")).To(Equal(claimName))
+		})
+	})
+
+	Context("acquiring the cache volumes", func() {
+		It("creates the claim and uses it for the volume", func() {
+			client.GetCalls(generateGetStub(nil, nil))
+
+			Expect(resources.AcquireCacheVolumes(context.TODO(), client, build, buildRun, strategyVolumes, taskRun)).To(Succeed())
+
+			Expect(client.CreateCallCount()).To(Equal(1))
+			_, object, _ := client.CreateArgsForCall(0)
+			claim, ok := object.(*corev1.PersistentVolumeClaim)
+			Expect(ok).To(BeTrue())
+			Expect(claim.Name).To(Equal("foobuild-cache-layers"))
+			Expect(claim.Labels).To(HaveKeyWithValue(buildv1beta1.LabelCacheVolume, "layers"))
+			Expect(claim.Annotations).To(HaveKeyWithValue(buildv1beta1.AnnotationCacheLockedBy, "foobuildrun"))
+			Expect(claim.Annotations).To(HaveKeyWithValue(buildv1beta1.AnnotationCacheTTL, "168h0m0s"))
+			Expect(claim.OwnerReferences).To(HaveLen(1))
+			Expect(claim.Spec.Resources.Requests.Storage().String()).To(Equal("5Gi"))
+
+			Expect(taskRun.Spec.TaskSpec.Volumes[0].EmptyDir).To(BeNil())
+			Expect(taskRun.Spec.TaskSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("foobuild-cache-layers"))
+		})
+
+		It("locks an existing claim that was released", func() {
+			client.GetCalls(generateGetStub(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foobuild-cache-layers",
+					Namespace:   "foo",
+					Annotations: map[string]string{buildv1beta1.AnnotationCacheLastUsed: "2024-01-01T00:00:00Z"},
+				},
+			}, nil))
+
+			Expect(resources.AcquireCacheVolumes(context.TODO(), client, build, buildRun, strategyVolumes, taskRun)).To(Succeed())
+
+			Expect(client.UpdateCallCount()).To(Equal(1))
+			_, object, _ := client.UpdateArgsForCall(0)
+			Expect(object.GetAnnotations()).To(HaveKeyWithValue(buildv1beta1.AnnotationCacheLockedBy, "foobuildrun"))
+			Expect(taskRun.Spec.TaskSpec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil())
+		})
+
+		It("uses an emptyDir volume if the claim is locked by a running BuildRun", func() {
+			client.GetCalls(generateGetStub(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foobuild-cache-layers",
+					Namespace:   "foo",
+					Annotations: map[string]string{buildv1beta1.AnnotationCacheLockedBy: "otherbuildrun"},
+				},
+			}, &buildv1beta1.BuildRun{ObjectMeta: metav1.ObjectMeta{Name: "otherbuildrun", Namespace: "foo"}}))
+
+			Expect(resources.AcquireCacheVolumes(context.TODO(), client, build, buildRun, strategyVolumes, taskRun)).To(Succeed())
+
+			Expect(client.UpdateCallCount()).To(Equal(0))
+			Expect(taskRun.Spec.TaskSpec.Volumes[0].EmptyDir).ToNot(BeNil())
+		})
+
+		It("takes over a claim that is locked by a completed BuildRun", func() {
+			client.GetCalls(generateGetStub(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "foobuild-cache-layers",
+					Namespace:   "foo",
+					Annotations: map[string]string{buildv1beta1.AnnotationCacheLockedBy: "otherbuildrun"},
+				},
+			}, &buildv1beta1.BuildRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "otherbuildrun", Namespace: "foo"},
+				Status:     buildv1beta1.BuildRunStatus{CompletionTime: &metav1.Time{}},
+			}))
+
+			Expect(resources.AcquireCacheVolumes(context.TODO(), client, build, buildRun, strategyVolumes, taskRun)).To(Succeed())
+
+			Expect(client.UpdateCallCount()).To(Equal(1))
+			Expect(taskRun.Spec.TaskSpec.Volumes[0].PersistentVolumeClaim).ToNot(BeNil())
+		})
+
+		It("does not use a cache for an embedded Build", func() {
+			build.Name, build.UID = "", ""
+
+			Expect(resources.AcquireCacheVolumes(context.TODO(), client, build, buildRun, strategyVolumes, taskRun)).To(Succeed())
+
+			Expect(client.GetCallCount()).To(Equal(0))
+			Expect(taskRun.Spec.TaskSpec.Volumes[0].EmptyDir).ToNot(BeNil())
+		})
+
+		It("does not use a cache for a volume that is overridden", func() {
+			buildRun.Spec.Volumes = []buildv1beta1.BuildVolume{{
+				Name:         "layers",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}}
+
+			Expect(resources.AcquireCacheVolumes(context.TODO(), client, build, buildRun, strategyVolumes, taskRun)).To(Succeed())
+
+			Expect(client.GetCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("releasing the cache volumes", func() {
+		It("removes the lock of the BuildRun", func() {
+			client.ListCalls(func(_ context.Context, list crc.ObjectList, _ ...crc.ListOption) error {
+				list.(*corev1.PersistentVolumeClaimList).Items = []corev1.PersistentVolumeClaim{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "foobuild-cache-layers",
+						Annotations: map[string]string{buildv1beta1.AnnotationCacheLockedBy: "foobuildrun"},
+					},
+				}, {
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "foobuild-cache-other",
+						Annotations: map[string]string{buildv1beta1.AnnotationCacheLockedBy: "otherbuildrun"},
+					},
+				}}
+				return nil
+			})
+
+			Expect(resources.ReleaseCacheVolumes(context.TODO(), client, buildRun)).To(Succeed())
+
+			Expect(client.UpdateCallCount()).To(Equal(1))
+			_, object, _ := client.UpdateArgsForCall(0)
+			Expect(object.GetName()).To(Equal("foobuild-cache-layers"))
+			Expect(object.GetAnnotations()).ToNot(HaveKey(buildv1beta1.AnnotationCacheLockedBy))
+			Expect(object.GetAnnotations()).To(HaveKey(buildv1beta1.AnnotationCacheLastUsed))
+		})
+	})
+})
@@ -26,6 +26,13 @@ const (
 	ConditionUnknownStrategyKind                     string = "UnknownStrategyKind"
 	ClusterBuildStrategyNotFound                     string = "ClusterBuildStrategyNotFound"
 	BuildStrategyNotFound                            string = "BuildStrategyNotFound"
+	ClusterBuildStrategyRevisionNotFound             string = "ClusterBuildStrategyRevisionNotFound"
+	BuildStrategyRevisionNotFound                    string = "BuildStrategyRevisionNotFound"
+	StrategyFragmentNotFound                         string = "StrategyFragmentNotFound"
+	StrategyFragmentConflict                         string = "StrategyFragmentConflict"
+	ClusterBuildStrategyNotAllowed                   string = "ClusterBuildStrategyNotAllowed"
+	BuildQuotaExceeded                               string = "BuildQuotaExceeded"
+	WaitingForBuildQuota                             string = "WaitingForBuildQuota"
 	ConditionSetOwnerReferenceFailed                 string = "SetOwnerReferenceFailed"
 	ConditionFailed                                  string = "Failed"
 	ConditionTaskRunIsMissing                        string = "TaskRunIsMissing"
@@ -41,6 +48,12 @@ const (
 	ConditionEmptyArrayItemParameterValues           string = "EmptyArrayItemParameterValues"
 	ConditionIncompleteConfigMapValueParameterValues string = "IncompleteConfigMapValueParameterValues"
 	ConditionIncompleteSecretValueParameterValues    string = "IncompleteSecretValueParameterValues"
+	ConditionMalformedParameterValues                string = "MalformedParameterValues"
+	ConditionDisallowedParameterValues               string = "DisallowedParameterValues"
+	ConditionPatternMismatchParameterValues          string = "PatternMismatchParameterValues"
+	ConditionOutOfRangeParameterValues               string = "OutOfRangeParameterValues"
+	ConditionMissingObjectPropertyParameterValues    string = "MissingObjectPropertyParameterValues"
+	ConditionInvalidObjectPropertyParameterValues    string = "InvalidObjectPropertyParameterValues"
 	BuildRunNameInvalid                              string = "BuildRunNameInvalid"
 	BuildRunNoRefOrSpec                              string = "BuildRunNoRefOrSpec"
 	BuildRunAmbiguousBuild                           string = "BuildRunAmbiguousBuild"
@@ -164,6 +177,27 @@ func UpdateBuildRunUsingTaskRunCondition(ctx context.Context, client client.Clie
 	return nil
 }
 
+// UpdateConditionWithWaitingStatus marks the BuildRun as waiting, unless it is already waiting for the same reason
+func UpdateConditionWithWaitingStatus(ctx context.Context, client client.Client, buildRun *buildv1beta1.BuildRun, message string, reason string) error {
+	if condition := buildRun.Status.GetCondition(buildv1beta1.Succeeded); condition != nil && condition.Reason == reason && condition.Message == message {
+		return nil
+	}
+
+	buildRun.Status.SetCondition(&buildv1beta1.Condition{
+		LastTransitionTime: metav1.Now(),
+		Type:               buildv1beta1.Succeeded,
+		Status:             corev1.ConditionUnknown,
+		Reason:             reason,
+		Message:            message,
+	})
+	ctxlog.Debug(ctx, "updating buildRun status", namespace, buildRun.Namespace, name, buildRun.Name, "reason", reason)
+	if err := client.Status().Update(ctx, buildRun); err != nil {
+		return &ClientStatusUpdateError{err}
+	}
+
+	return nil
+}
+
 // UpdateConditionWithFalseStatus sets the Succeeded condition fields and mark
 // the condition as Status False. It also updates the object in the cluster by
 // calling client Status Update
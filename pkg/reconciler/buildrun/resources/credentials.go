@@ -31,6 +31,11 @@ func ApplyCredentials(ctx context.Context, build *buildv1beta1.Build, buildRun *
 		}
 	}
 
+	// the cache credentials are needed to pull and push the cache image
+	if cache := effectiveCache(build, buildRun); cache != nil && cache.PushSecret != nil {
+		modified = updateServiceAccountIfSecretNotLinked(ctx, *cache.PushSecret, serviceAccount) || modified
+	}
+
 	return modified
 }
 
@@ -79,6 +79,40 @@ var _ = Describe("Credentials", func() {
 		})
 	})
 
+	Context("when a cache with a secret is referenced", func() {
+		BeforeEach(func() {
+			build = &buildv1beta1.Build{
+				Spec: buildv1beta1.BuildSpec{
+					Output: buildv1beta1.Image{
+						Image: "quay.io/namespace/image",
+					},
+					Cache: &buildv1beta1.ImageCache{
+						Image:      "quay.io/namespace/cache",
+						PushSecret: ptr.To("secret_cache"),
+					},
+				},
+			}
+
+			buildRun = &buildv1beta1.BuildRun{}
+
+			expectedAfterServiceAccount = &corev1.ServiceAccount{
+				Secrets: []corev1.ObjectReference{
+					{Name: "secret_b"},
+					{Name: "secret_c"},
+					{Name: "secret_cache"},
+				},
+			}
+		})
+
+		It("adds the cache credentials to the service account", func() {
+			afterServiceAccount := beforeServiceAccount.DeepCopy()
+			modified := resources.ApplyCredentials(context.TODO(), build, buildRun, afterServiceAccount)
+
+			Expect(modified).To(BeTrue())
+			Expect(afterServiceAccount).To(Equal(expectedAfterServiceAccount))
+		})
+	})
+
 	Context("when secrets were already in the service account", func() {
 		BeforeEach(func() {
 			build = &buildv1beta1.Build{
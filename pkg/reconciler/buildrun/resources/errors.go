@@ -5,6 +5,7 @@
 package resources
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -36,6 +37,74 @@ func IsClientStatusUpdateError(err error) bool {
 	return false
 }
 
+// StrategyFragmentConflictError is an error that occurs when a ClusterStrategyFragment defines a step, parameter,
+// or volume differently than the strategy or another fragment of the strategy
+type StrategyFragmentConflictError struct {
+	fragment string
+	kind     string
+	name     string
+}
+
+func (e StrategyFragmentConflictError) Error() string {
+	return fmt.Sprintf("the %s %q of the ClusterStrategyFragment %q conflicts with a different definition of the same name", e.kind, e.name, e.fragment)
+}
+
+// IsStrategyFragmentConflictError checks whether the given error is of type StrategyFragmentConflictError
+func IsStrategyFragmentConflictError(err error) bool {
+	var conflictError StrategyFragmentConflictError
+	return errors.As(err, &conflictError)
+}
+
+// ClusterBuildStrategyNotAllowedError is an error that occurs when a namespace is not allowed to use a ClusterBuildStrategy
+type ClusterBuildStrategyNotAllowedError struct {
+	strategy  string
+	namespace string
+	reason    string
+}
+
+func (e ClusterBuildStrategyNotAllowedError) Error() string {
+	return fmt.Sprintf("namespace %s is not allowed to use clusterBuildStrategy %s, %s", e.namespace, e.strategy, e.reason)
+}
+
+// IsClusterBuildStrategyNotAllowedError checks whether the given error is of type ClusterBuildStrategyNotAllowedError
+func IsClusterBuildStrategyNotAllowedError(err error) bool {
+	var notAllowedError ClusterBuildStrategyNotAllowedError
+	return errors.As(err, &notAllowedError)
+}
+
+// BuildQuotaExceededError is an error that occurs when a BuildRun on its own exceeds a limit of a BuildQuota
+type BuildQuotaExceededError struct {
+	quota   string
+	message string
+}
+
+func (e BuildQuotaExceededError) Error() string {
+	return fmt.Sprintf("the BuildRun exceeds the BuildQuota %s, %s", e.quota, e.message)
+}
+
+// IsBuildQuotaExceededError checks whether the given error is of type BuildQuotaExceededError
+func IsBuildQuotaExceededError(err error) bool {
+	var exceededError BuildQuotaExceededError
+	return errors.As(err, &exceededError)
+}
+
+// BuildQuotaWaitError is an error that occurs when a BuildRun together with the running BuildRuns exceeds a limit
+// of a BuildQuota, the BuildRun can run once enough running BuildRuns completed
+type BuildQuotaWaitError struct {
+	quota   string
+	message string
+}
+
+func (e BuildQuotaWaitError) Error() string {
+	return fmt.Sprintf("the BuildRun waits for the BuildQuota %s, %s", e.quota, e.message)
+}
+
+// IsBuildQuotaWaitError checks whether the given error is of type BuildQuotaWaitError
+func IsBuildQuotaWaitError(err error) bool {
+	var waitError BuildQuotaWaitError
+	return errors.As(err, &waitError)
+}
+
 // Errors allows you to wrap multiple errors
 // in a single struct. Useful when wrapping multiple
 // errors with a single message.
@@ -7,7 +7,9 @@ package resources
 import (
 	"crypto/rand"
 	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -99,7 +101,7 @@ func HandleTaskRunParam(taskRun *pipelineapi.TaskRun, parameterDefinition *build
 	switch parameterDefinition.Type {
 	case "": // string is default
 		fallthrough
-	case buildv1beta1.ParameterTypeString:
+	case buildv1beta1.ParameterTypeString, buildv1beta1.ParameterTypeBoolean, buildv1beta1.ParameterTypeInteger:
 		taskRunParam.Value.Type = pipelineapi.ParamTypeString
 
 		switch {
@@ -198,6 +200,80 @@ func HandleTaskRunParam(taskRun *pipelineapi.TaskRun, parameterDefinition *build
 				}
 			}
 		}
+
+	case buildv1beta1.ParameterTypeObject:
+		taskRunParam.Value.Type = pipelineapi.ParamTypeObject
+
+		if paramValue.Properties == nil {
+			if parameterDefinition.DefaultProperties == nil {
+				// this error should never happen because we validate this upfront in ValidateBuildRunParameters
+				return fmt.Errorf("unexpected parameter without any value: %s", parameterDefinition.Name)
+			}
+
+			// we tolerate this for optional parameters, same as for string and array parameters
+			return nil
+		}
+
+		// the properties are merged with the default properties
+		taskRunParam.Value.ObjectVal = map[string]string{}
+		if parameterDefinition.DefaultProperties != nil {
+			for property, value := range *parameterDefinition.DefaultProperties {
+				taskRunParam.Value.ObjectVal[property] = value
+			}
+		}
+
+		for _, property := range slices.Sorted(maps.Keys(paramValue.Properties)) {
+			value := paramValue.Properties[property]
+			switch {
+			case value.ConfigMapValue != nil:
+				envVarName, err := addConfigMapEnvVar(taskRun, paramValue.Name, value.ConfigMapValue.Name, value.ConfigMapValue.Key)
+				if err != nil {
+					return err
+				}
+
+				envVarExpression := fmt.Sprintf("$(%s)", envVarName)
+				if value.ConfigMapValue.Format != nil {
+					taskRunParam.Value.ObjectVal[property] = strings.ReplaceAll(*value.ConfigMapValue.Format, "${CONFIGMAP_VALUE}", envVarExpression)
+				} else {
+					taskRunParam.Value.ObjectVal[property] = envVarExpression
+				}
+
+			case value.SecretValue != nil:
+				envVarName, err := addSecretEnvVar(taskRun, paramValue.Name, value.SecretValue.Name, value.SecretValue.Key)
+				if err != nil {
+					return err
+				}
+
+				envVarExpression := fmt.Sprintf("$(%s)", envVarName)
+				if value.SecretValue.Format != nil {
+					taskRunParam.Value.ObjectVal[property] = strings.ReplaceAll(*value.SecretValue.Format, "${SECRET_VALUE}", envVarExpression)
+				} else {
+					taskRunParam.Value.ObjectVal[property] = envVarExpression
+				}
+
+			case value.Value != nil:
+				taskRunParam.Value.ObjectVal[property] = *value.Value
+
+			default:
+				// this error should never happen because we validate this upfront in ValidateBuildRunParameters
+				return fmt.Errorf("unexpected parameter without any value: %s.%s", parameterDefinition.Name, property)
+			}
+		}
+
+		// the task spec must declare all properties of the value
+		for i := range taskRun.Spec.TaskSpec.Params {
+			paramSpec := &taskRun.Spec.TaskSpec.Params[i]
+			if paramSpec.Name != parameterDefinition.Name {
+				continue
+			}
+
+			if paramSpec.Properties == nil {
+				paramSpec.Properties = map[string]pipelineapi.PropertySpec{}
+			}
+			for property := range taskRunParam.Value.ObjectVal {
+				paramSpec.Properties[property] = pipelineapi.PropertySpec{Type: pipelineapi.ParamTypeString}
+			}
+		}
 	}
 
 	taskRun.Spec.Params = append(taskRun.Spec.Params, taskRunParam)
@@ -205,6 +281,48 @@ func HandleTaskRunParam(taskRun *pipelineapi.TaskRun, parameterDefinition *build
 	return nil
 }
 
+// ExpandObjectParams replaces every command and argument of the TaskRun steps that references a complete
+// object parameter, $(params.name[*]), with one KEY=VALUE argument per property of the object, sorted by key
+func ExpandObjectParams(taskRun *pipelineapi.TaskRun) {
+	for _, paramSpec := range taskRun.Spec.TaskSpec.Params {
+		if paramSpec.Type != pipelineapi.ParamTypeObject {
+			continue
+		}
+
+		references := []string{
+			fmt.Sprintf("$(params.%s[*])", paramSpec.Name),
+			fmt.Sprintf("$(params['%s'][*])", paramSpec.Name),
+			fmt.Sprintf("$(params[%q][*])", paramSpec.Name),
+		}
+
+		expanded := []string{}
+		for _, property := range slices.Sorted(maps.Keys(paramSpec.Properties)) {
+			expanded = append(expanded, fmt.Sprintf("%s=$(params.%s.%s)", property, paramSpec.Name, property))
+		}
+
+		expand := func(values []string) []string {
+			if !slices.ContainsFunc(values, func(value string) bool { return slices.Contains(references, value) }) {
+				return values
+			}
+
+			result := []string{}
+			for _, value := range values {
+				if slices.Contains(references, value) {
+					result = append(result, expanded...)
+				} else {
+					result = append(result, value)
+				}
+			}
+			return result
+		}
+
+		for i := range taskRun.Spec.TaskSpec.Steps {
+			taskRun.Spec.TaskSpec.Steps[i].Command = expand(taskRun.Spec.TaskSpec.Steps[i].Command)
+			taskRun.Spec.TaskSpec.Steps[i].Args = expand(taskRun.Spec.TaskSpec.Steps[i].Args)
+		}
+	}
+}
+
 // generateEnvVarName adds a random suffix of five characters or digits to a given prefix
 func generateEnvVarName(prefix string) (string, error) {
 	result := prefix
@@ -574,6 +574,34 @@ var _ = Describe("HandleTaskRunParam", func() {
 		})
 	})
 
+	Context("for an integer parameter", func() {
+
+		parameterDefinition := &buildv1beta1.Parameter{
+			Name: "integer-parameter",
+			Type: buildv1beta1.ParameterTypeInteger,
+		}
+
+		It("adds the value as string", func() {
+			err := HandleTaskRunParam(taskRun, parameterDefinition, buildv1beta1.ParamValue{
+				Name: "integer-parameter",
+				SingleValue: &buildv1beta1.SingleValue{
+					Value: ptr.To("42"),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(taskRun.Spec.Params).To(BeEquivalentTo([]pipelineapi.Param{
+				{
+					Name: "integer-parameter",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "42",
+					},
+				},
+			}))
+		})
+	})
+
 	Context("for an array parameter", func() {
 
 		parameterDefinition := &buildv1beta1.Parameter{
@@ -748,4 +776,93 @@ var _ = Describe("HandleTaskRunParam", func() {
 			}))
 		})
 	})
+
+	Context("for an object parameter", func() {
+
+		parameterDefinition := &buildv1beta1.Parameter{
+			Name:              "object-parameter",
+			Type:              buildv1beta1.ParameterTypeObject,
+			Properties:        []string{"required-key"},
+			DefaultProperties: &map[string]string{"required-key": "default", "other-key": "default"},
+		}
+
+		BeforeEach(func() {
+			taskRun.Spec.TaskSpec.Params = []pipelineapi.ParamSpec{{
+				Name: "object-parameter",
+				Type: pipelineapi.ParamTypeObject,
+				Properties: map[string]pipelineapi.PropertySpec{
+					"required-key": {Type: pipelineapi.ParamTypeString},
+					"other-key":    {Type: pipelineapi.ParamTypeString},
+				},
+			}}
+			taskRun.Spec.TaskSpec.Steps = append(taskRun.Spec.TaskSpec.Steps, pipelineapi.Step{
+				Name: "third-container",
+				Args: []string{"$(params.object-parameter[*])"},
+			})
+		})
+
+		It("merges the properties with the default properties and adds them to the task spec", func() {
+			err := HandleTaskRunParam(taskRun, parameterDefinition, buildv1beta1.ParamValue{
+				Name: "object-parameter",
+				Properties: map[string]buildv1beta1.SingleValue{
+					"required-key": {
+						Value: ptr.To("a value"),
+					},
+					"secret-key": {
+						SecretValue: &buildv1beta1.ObjectKeyRef{
+							Name:   "secret-name",
+							Key:    "secret-key",
+							Format: ptr.To("token=${SECRET_VALUE}"),
+						},
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Verify the environment variable that is only added to the third step
+			Expect(len(taskRun.Spec.TaskSpec.Steps[0].Env)).To(Equal(0))
+			Expect(len(taskRun.Spec.TaskSpec.Steps[1].Env)).To(Equal(0))
+			Expect(len(taskRun.Spec.TaskSpec.Steps[2].Env)).To(Equal(1))
+			envVarName := taskRun.Spec.TaskSpec.Steps[2].Env[0].Name
+			Expect(envVarName).To(HavePrefix("SHP_SECRET_PARAM_"))
+
+			// Verify the parameters
+			Expect(taskRun.Spec.Params).To(BeEquivalentTo([]pipelineapi.Param{
+				{
+					Name: "object-parameter",
+					Value: pipelineapi.ParamValue{
+						Type: pipelineapi.ParamTypeObject,
+						ObjectVal: map[string]string{
+							"required-key": "a value",
+							"other-key":    "default",
+							"secret-key":   fmt.Sprintf("token=$(%s)", envVarName),
+						},
+					},
+				},
+			}))
+
+			Expect(taskRun.Spec.TaskSpec.Params[0].Properties).To(HaveKey("secret-key"))
+		})
+
+		It("expands a reference to the complete object into one argument per property", func() {
+			err := HandleTaskRunParam(taskRun, parameterDefinition, buildv1beta1.ParamValue{
+				Name: "object-parameter",
+				Properties: map[string]buildv1beta1.SingleValue{
+					"b-key": {
+						Value: ptr.To("b"),
+					},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			ExpandObjectParams(taskRun)
+
+			Expect(taskRun.Spec.TaskSpec.Steps[1].Args).To(Equal([]string{"$(params.array-parameter[*])"}))
+			Expect(taskRun.Spec.TaskSpec.Steps[2].Args).To(Equal([]string{
+				"b-key=$(params.object-parameter.b-key)",
+				"other-key=$(params.object-parameter.other-key)",
+				"required-key=$(params.object-parameter.required-key)",
+			}))
+		})
+	})
 })
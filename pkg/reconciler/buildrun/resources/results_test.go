@@ -16,6 +16,7 @@ import (
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -106,6 +107,150 @@ var _ = Describe("TaskRun results to BuildRun", func() {
 			Expect(br.Status.Source.OciArtifact.Digest).To(Equal(bundleImageDigest))
 		})
 
+		It("should surface the TaskRun results emitting from default(archive) source step", func() {
+			archiveDigest := "sha256:5f1b1a7e4b0a8f9e2c1e3d2b0d5b6e1a6e0e2c6d4c1c5e8f3b0a2d5c7e9f1a3b"
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.ArchiveType,
+					Archive: &build.Archive{
+						URL: "https://artifacts.example.com/sample-go.tar.gz",
+					},
+				},
+			}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-archive-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: archiveDigest,
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Archive.Digest).To(Equal(archiveDigest))
+		})
+
+		It("should surface the TaskRun results emitting from the upload of a local source", func() {
+			br.Spec.Source = &build.BuildRunSource{
+				Type: build.LocalType,
+				Local: &build.Local{
+					Method:       ptr.To(build.LocalUploadMethodHTTP),
+					UploadSecret: ptr.To("upload-token"),
+				},
+			}
+			br.Status.BuildSpec = &build.BuildSpec{}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-upload-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "sha256:5f1b1a7e4b0a8f9e2c1e3d2b0d5b6e1a6e0e2c6d4c1c5e8f3b0a2d5c7e9f1a3b",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-upload-size",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "1024",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Local).ToNot(BeNil())
+			Expect(br.Status.Source.Local.UploadDigest).To(Equal("sha256:5f1b1a7e4b0a8f9e2c1e3d2b0d5b6e1a6e0e2c6d4c1c5e8f3b0a2d5c7e9f1a3b"))
+			Expect(br.Status.Source.Local.UploadSize).To(Equal(int64(1024)))
+		})
+
+		It("should surface the TaskRun results describing the content of a local source", func() {
+			br.Spec.Source = &build.BuildRunSource{
+				Type:  build.LocalType,
+				Local: &build.Local{Name: "local-source"},
+			}
+			br.Status.BuildSpec = &build.BuildSpec{}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-source-digest",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "sha256:0b5bcd8a5e6b6b8f3e1fbbcd7ae0a1f5b0e2e4e0f4a1d2c3b4a5968778695a4b",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-source-file-count",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "42",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-source-size",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "73201",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Local).To(Equal(&build.LocalSourceResult{
+				Digest:    "sha256:0b5bcd8a5e6b6b8f3e1fbbcd7ae0a1f5b0e2e4e0f4a1d2c3b4a5968778695a4b",
+				FileCount: 42,
+				Size:      73201,
+			}))
+		})
+
+		It("should surface the TaskRun results emitting from additional source steps per source", func() {
+			br.Status.BuildSpec = &build.BuildSpec{
+				Source: &build.Source{
+					Type: build.GitType,
+					Git: &build.Git{
+						URL: "https://github.com/shipwright-io/sample-go",
+					},
+				},
+				AdditionalSources: []build.AdditionalSource{
+					{
+						Name: "config",
+						Type: build.GitType,
+						Git: &build.Git{
+							URL: "https://github.com/shipwright-io/build-config",
+						},
+					},
+				},
+			}
+
+			tr.Status.Results = append(tr.Status.Results,
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-default-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "0e0583421a5e4bf562ffe33f3651e16ba0c78591",
+					},
+				},
+				pipelineapi.TaskRunResult{
+					Name: "shp-source-config-commit-sha",
+					Value: pipelineapi.ParamValue{
+						Type:      pipelineapi.ParamTypeString,
+						StringVal: "e1a6e0e2c6d4c1c5e8f3b0a2d5c7e9f1a3b5f1b1",
+					},
+				})
+
+			resources.UpdateBuildRunUsingTaskResults(ctx, br, tr.Status.Results, taskRunRequest)
+
+			Expect(br.Status.Source).ToNot(BeNil())
+			Expect(br.Status.Source.Git.CommitSha).To(Equal("0e0583421a5e4bf562ffe33f3651e16ba0c78591"))
+			Expect(br.Status.AdditionalSources).To(HaveLen(1))
+			Expect(br.Status.AdditionalSources[0].Name).To(Equal("config"))
+			Expect(br.Status.AdditionalSources[0].Git.CommitSha).To(Equal("e1a6e0e2c6d4c1c5e8f3b0a2d5c7e9f1a3b5f1b1"))
+		})
+
 		It("should surface the TaskRun results emitting from output step with image vulnerabilities", func() {
 			imageDigest := "sha256:fe1b73cd25ac3f11dec752755e2"
 			tr.Status.Results = append(tr.Status.Results,
@@ -5,12 +5,6 @@
 package resources
 
 import (
-	"strconv"
-	"strings"
-	"time"
-
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
@@ -20,8 +14,6 @@ import (
 
 const defaultSourceName = "default"
 
-const sourceTimestampName = "source-timestamp"
-
 // isLocalCopyBuildSource appends all "Sources" in a single slice, and if any entry is typed
 // "LocalCopy" it returns first LocalCopy typed BuildSource found, or nil.
 func isLocalCopyBuildSource(
@@ -39,17 +31,9 @@ func isLocalCopyBuildSource(
 	return nil
 }
 
-func appendSourceTimestampResult(taskSpec *pipelineapi.TaskSpec) {
-	taskSpec.Results = append(taskSpec.Results,
-		pipelineapi.TaskResult{
-			Name:        sources.TaskResultName(defaultSourceName, sourceTimestampName),
-			Description: "The timestamp of the source.",
-		},
-	)
-}
-
 // AmendTaskSpecWithSources adds the necessary steps to either wait for user upload ("LocalCopy"), or
-// alternatively, configures the Task steps to use bundle and "git clone".
+// alternatively, configures the Task steps to use bundle, archive download and "git clone". Additional
+// sources are placed into their target directory after the source.
 func AmendTaskSpecWithSources(
 	cfg *config.Config,
 	taskSpec *pipelineapi.TaskSpec,
@@ -58,27 +42,49 @@ func AmendTaskSpecWithSources(
 ) {
 	if localCopy := isLocalCopyBuildSource(build, buildRun); localCopy != nil {
 		sources.AppendLocalCopyStep(cfg, taskSpec, localCopy.Timeout)
+		sources.AppendLocalSourceResults(taskSpec, defaultSourceName)
+		if localCopy.UsesUploadEndpoint() {
+			sources.AppendLocalUploadEndpoint(taskSpec, localCopy, defaultSourceName)
+		}
 	} else if build.Spec.Source != nil {
 
 		// create the step for spec.source, either Git or Bundle
 		switch build.Spec.Source.Type {
 		case buildv1beta1.OCIArtifactType:
 			if build.Spec.Source.OCIArtifact != nil {
-				appendSourceTimestampResult(taskSpec)
+				sources.AppendSourceTimestampResult(taskSpec, defaultSourceName)
 				sources.AppendBundleStep(cfg, taskSpec, build.Spec.Source.OCIArtifact, defaultSourceName)
 			}
 		case buildv1beta1.GitType:
 			if build.Spec.Source.Git != nil {
-				appendSourceTimestampResult(taskSpec)
+				sources.AppendSourceTimestampResult(taskSpec, defaultSourceName)
 				sources.AppendGitStep(cfg, taskSpec, *build.Spec.Source.Git, defaultSourceName)
 			}
+		case buildv1beta1.ArchiveType:
+			if build.Spec.Source.Archive != nil {
+				sources.AppendSourceTimestampResult(taskSpec, defaultSourceName)
+				sources.AppendArchiveStep(cfg, taskSpec, build.Spec.Source.Archive, defaultSourceName)
+			}
 		}
 	}
+
+	for _, additionalSource := range build.Spec.AdditionalSources {
+		sources.AppendAdditionalSourceStep(cfg, taskSpec, additionalSource)
+	}
 }
 
 func updateBuildRunStatusWithSourceResult(buildrun *buildv1beta1.BuildRun, results []pipelineapi.TaskRunResult) {
 	buildSpec := buildrun.Status.BuildSpec
 
+	for _, additionalSource := range buildSpec.AdditionalSources {
+		sources.AppendAdditionalSourceResult(buildrun, additionalSource, results)
+	}
+
+	if buildrun.Spec.Source != nil && buildrun.Spec.Source.Type == buildv1beta1.LocalType {
+		sources.AppendLocalResult(buildrun, defaultSourceName, results)
+		return
+	}
+
 	if buildSpec.Source == nil {
 		return
 	}
@@ -89,13 +95,17 @@ func updateBuildRunStatusWithSourceResult(buildrun *buildv1beta1.BuildRun, resul
 
 	case buildSpec.Source.Type == buildv1beta1.GitType && buildSpec.Source.Git != nil:
 		sources.AppendGitResult(buildrun, defaultSourceName, results)
+
+	case buildSpec.Source.Type == buildv1beta1.ArchiveType && buildSpec.Source.Archive != nil:
+		sources.AppendArchiveResult(buildrun, defaultSourceName, results)
+
+	case buildSpec.Source.Type == buildv1beta1.LocalType:
+		sources.AppendLocalResult(buildrun, defaultSourceName, results)
 	}
 
-	if sourceTimestamp := sources.FindResultValue(results, defaultSourceName, sourceTimestampName); strings.TrimSpace(sourceTimestamp) != "" {
-		if sec, err := strconv.ParseInt(sourceTimestamp, 10, 64); err == nil {
-			if buildrun.Status.Source != nil {
-				buildrun.Status.Source.Timestamp = &metav1.Time{Time: time.Unix(sec, 0)}
-			}
+	if sourceTimestamp := sources.FindSourceTimestamp(results, defaultSourceName); sourceTimestamp != nil {
+		if buildrun.Status.Source != nil {
+			buildrun.Status.Source.Timestamp = sourceTimestamp
 		}
 	}
 }
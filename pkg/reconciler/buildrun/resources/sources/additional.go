@@ -0,0 +1,102 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const sourceTimestampResult = "source-timestamp"
+
+// AppendSourceTimestampResult appends the result for the source timestamp of the named source to the TaskSpec
+func AppendSourceTimestampResult(taskSpec *pipelineapi.TaskSpec, name string) {
+	taskSpec.Results = append(taskSpec.Results,
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, sourceTimestampResult),
+			Description: "The timestamp of the source.",
+		},
+	)
+}
+
+// FindSourceTimestamp returns the source timestamp of the named source, or nil if it was not reported
+func FindSourceTimestamp(results []pipelineapi.TaskRunResult, name string) *metav1.Time {
+	if sourceTimestamp := FindResultValue(results, name, sourceTimestampResult); strings.TrimSpace(sourceTimestamp) != "" {
+		if sec, err := strconv.ParseInt(sourceTimestamp, 10, 64); err == nil {
+			return &metav1.Time{Time: time.Unix(sec, 0)}
+		}
+	}
+
+	return nil
+}
+
+// AppendAdditionalSourceStep appends the step for an additional source to the TaskSpec, the step
+// places the content of the source in its target directory inside the source root
+func AppendAdditionalSourceStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, source build.AdditionalSource) {
+	switch {
+	case source.Type == build.GitType && source.Git != nil:
+		AppendSourceTimestampResult(taskSpec, source.Name)
+		appendGitStep(cfg, taskSpec, *source.Git, source.Name, source.GetTargetDir())
+
+	case source.Type == build.OCIArtifactType && source.OCIArtifact != nil:
+		AppendSourceTimestampResult(taskSpec, source.Name)
+		appendBundleStep(cfg, taskSpec, source.OCIArtifact, source.Name, source.GetTargetDir())
+
+	case source.Type == build.ArchiveType && source.Archive != nil:
+		AppendSourceTimestampResult(taskSpec, source.Name)
+		appendArchiveStep(cfg, taskSpec, source.Archive, source.Name, source.GetTargetDir())
+	}
+}
+
+// AppendAdditionalSourceResult appends the results of an additional source to the BuildRun status
+func AppendAdditionalSourceResult(buildRun *build.BuildRun, source build.AdditionalSource, results []pipelineapi.TaskRunResult) {
+	// the result functions operate on the source result of the BuildRun status, so
+	// use a scratch BuildRun to collect the results of the additional source
+	scratch := &build.BuildRun{}
+
+	switch {
+	case source.Type == build.GitType && source.Git != nil:
+		AppendGitResult(scratch, source.Name, results)
+
+	case source.Type == build.OCIArtifactType && source.OCIArtifact != nil:
+		AppendBundleResult(scratch, source.Name, results)
+
+	case source.Type == build.ArchiveType && source.Archive != nil:
+		AppendArchiveResult(scratch, source.Name, results)
+	}
+
+	sourceResult := scratch.Status.Source
+	if timestamp := FindSourceTimestamp(results, source.Name); timestamp != nil {
+		if sourceResult == nil {
+			sourceResult = &build.SourceResult{}
+		}
+
+		sourceResult.Timestamp = timestamp
+	}
+
+	if sourceResult == nil {
+		return
+	}
+
+	sourceResult.Name = source.Name
+
+	// replace the results of a previous reconcile, if present
+	for i := range buildRun.Status.AdditionalSources {
+		if buildRun.Status.AdditionalSources[i].Name == source.Name {
+			buildRun.Status.AdditionalSources[i] = *sourceResult
+			return
+		}
+	}
+
+	buildRun.Status.AdditionalSources = append(buildRun.Status.AdditionalSources, *sourceResult)
+}
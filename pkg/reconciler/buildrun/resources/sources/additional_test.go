@@ -0,0 +1,142 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("AdditionalSources", func() {
+
+	cfg := config.NewDefaultConfig()
+
+	targetOf := func(step pipelineapi.Step) string {
+		for i, arg := range step.Args {
+			if arg == "--target" {
+				return step.Args[i+1]
+			}
+		}
+
+		return ""
+	}
+
+	Context("when adding an additional Git source", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+
+			sources.AppendAdditionalSourceStep(cfg, taskSpec, buildv1beta1.AdditionalSource{
+				Name: "config",
+				Type: buildv1beta1.GitType,
+				Git: &buildv1beta1.Git{
+					URL: "https://github.com/shipwright-io/build-config",
+				},
+			})
+		})
+
+		It("adds the results namespaced by the source name", func() {
+			Expect(taskSpec.Results).To(ContainElement(HaveField("Name", "shp-source-config-source-timestamp")))
+			Expect(taskSpec.Results).To(ContainElement(HaveField("Name", "shp-source-config-commit-sha")))
+		})
+
+		It("adds a step that clones into the subdirectory named after the source", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-config"))
+			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.GitContainerTemplate.Image))
+			Expect(targetOf(taskSpec.Steps[0])).To(Equal("$(params.shp-source-root)/config"))
+		})
+	})
+
+	Context("when adding an additional archive source with a target directory", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+
+			sources.AppendAdditionalSourceStep(cfg, taskSpec, buildv1beta1.AdditionalSource{
+				Name:      "assets",
+				Type:      buildv1beta1.ArchiveType,
+				TargetDir: ptr.To("static/assets/"),
+				Archive: &buildv1beta1.Archive{
+					URL: "https://artifacts.example.com/assets.tar.gz",
+				},
+			})
+		})
+
+		It("adds a step that unpacks into the target directory", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-assets"))
+			Expect(targetOf(taskSpec.Steps[0])).To(Equal("$(params.shp-source-root)/static/assets"))
+		})
+	})
+
+	Context("when adding an additional source without details", func() {
+		It("does not add anything", func() {
+			taskSpec := &pipelineapi.TaskSpec{}
+			sources.AppendAdditionalSourceStep(cfg, taskSpec, buildv1beta1.AdditionalSource{
+				Name: "empty",
+				Type: buildv1beta1.OCIArtifactType,
+			})
+
+			Expect(taskSpec.Steps).To(BeEmpty())
+			Expect(taskSpec.Results).To(BeEmpty())
+		})
+	})
+
+	Context("when surfacing the results of an additional source", func() {
+		source := buildv1beta1.AdditionalSource{
+			Name: "config",
+			Type: buildv1beta1.GitType,
+			Git: &buildv1beta1.Git{
+				URL: "https://github.com/shipwright-io/build-config",
+			},
+		}
+
+		results := []pipelineapi.TaskRunResult{
+			{
+				Name:  "shp-source-config-commit-sha",
+				Value: *pipelineapi.NewStructuredValues("abc123"),
+			},
+			{
+				Name:  "shp-source-config-source-timestamp",
+				Value: *pipelineapi.NewStructuredValues("1691650396"),
+			},
+			{
+				Name:  "shp-source-default-commit-sha",
+				Value: *pipelineapi.NewStructuredValues("def456"),
+			},
+		}
+
+		It("adds the results under the name of the source", func() {
+			buildRun := &buildv1beta1.BuildRun{}
+			sources.AppendAdditionalSourceResult(buildRun, source, results)
+
+			Expect(buildRun.Status.Source).To(BeNil())
+			Expect(buildRun.Status.AdditionalSources).To(HaveLen(1))
+			Expect(buildRun.Status.AdditionalSources[0].Name).To(Equal("config"))
+			Expect(buildRun.Status.AdditionalSources[0].Git.CommitSha).To(Equal("abc123"))
+			Expect(buildRun.Status.AdditionalSources[0].Timestamp.Time).To(BeTemporally("==", time.Unix(1691650396, 0)))
+		})
+
+		It("replaces the results of a previous update", func() {
+			buildRun := &buildv1beta1.BuildRun{}
+			sources.AppendAdditionalSourceResult(buildRun, source, results)
+			sources.AppendAdditionalSourceResult(buildRun, source, results)
+
+			Expect(buildRun.Status.AdditionalSources).To(HaveLen(1))
+		})
+	})
+})
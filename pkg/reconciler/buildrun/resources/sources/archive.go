@@ -0,0 +1,94 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const archiveDigestResult = "archive-digest"
+
+// AppendArchiveStep appends the archive step and results and volume if needed to the TaskSpec
+func AppendArchiveStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, archive *build.Archive, name string) {
+	appendArchiveStep(cfg, taskSpec, archive, name, "")
+}
+
+func appendArchiveStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, archive *build.Archive, name string, targetDir string) {
+	// append the result
+	taskSpec.Results = append(taskSpec.Results,
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, archiveDigestResult),
+			Description: "The digest of the downloaded archive.",
+		},
+	)
+
+	// initialize the step from the template and the build-specific arguments
+	archiveStep := pipelineapi.Step{
+		Name:            StepName(name),
+		Image:           cfg.ArchiveContainerTemplate.Image,
+		ImagePullPolicy: cfg.ArchiveContainerTemplate.ImagePullPolicy,
+		Command:         cfg.ArchiveContainerTemplate.Command,
+		Args: []string{
+			"--url", archive.URL,
+			"--target", sourceTarget(targetDir),
+			"--result-file-digest", fmt.Sprintf("$(results.%s.path)", TaskResultName(name, archiveDigestResult)),
+			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s.path)", TaskResultName(name, "source-timestamp")),
+			"--result-file-error-message", fmt.Sprintf("$(results.%s-error-message.path)", PrefixParamsResultsVolumes),
+			"--result-file-error-reason", fmt.Sprintf("$(results.%s-error-reason.path)", PrefixParamsResultsVolumes),
+		},
+		Env:              cfg.ArchiveContainerTemplate.Env,
+		ComputeResources: cfg.ArchiveContainerTemplate.Resources,
+		SecurityContext:  cfg.ArchiveContainerTemplate.SecurityContext,
+		WorkingDir:       cfg.ArchiveContainerTemplate.WorkingDir,
+	}
+
+	// verify the checksum, if provided
+	if archive.SHA256 != nil {
+		archiveStep.Args = append(archiveStep.Args, "--sha256", *archive.SHA256)
+	}
+
+	// add credentials mount, if provided
+	if archive.Secret != nil {
+		AppendSecretVolume(taskSpec, *archive.Secret)
+
+		secretMountPath := fmt.Sprintf("/workspace/%s-source-secret", PrefixParamsResultsVolumes)
+
+		// define the volume mount on the container
+		archiveStep.VolumeMounts = append(archiveStep.VolumeMounts, corev1.VolumeMount{
+			Name:      SanitizeVolumeNameForSecretName(*archive.Secret),
+			MountPath: secretMountPath,
+			ReadOnly:  true,
+		})
+
+		// append the argument
+		archiveStep.Args = append(archiveStep.Args,
+			"--secret-path", secretMountPath,
+		)
+	}
+
+	taskSpec.Steps = append(taskSpec.Steps, archiveStep)
+}
+
+// AppendArchiveResult append archive source result to build run
+func AppendArchiveResult(buildRun *build.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	digest := FindResultValue(results, name, archiveDigestResult)
+
+	if strings.TrimSpace(digest) != "" {
+		if buildRun.Status.Source == nil {
+			buildRun.Status.Source = &build.SourceResult{}
+		}
+		buildRun.Status.Source.Archive = &build.ArchiveSourceResult{
+			Digest: digest,
+		}
+	}
+}
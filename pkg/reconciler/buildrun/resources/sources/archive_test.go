@@ -0,0 +1,89 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Archive", func() {
+
+	cfg := config.NewDefaultConfig()
+
+	Context("when adding a public archive source", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		JustBeforeEach(func() {
+			sources.AppendArchiveStep(cfg, taskSpec, &buildv1beta1.Archive{
+				URL: "https://artifacts.example.com/sample-go.tar.gz",
+			}, "default")
+		})
+
+		It("adds a result for the archive digest", func() {
+			Expect(len(taskSpec.Results)).To(Equal(1))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-archive-digest"))
+		})
+
+		It("adds a step", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Name).To(Equal("source-default"))
+			Expect(taskSpec.Steps[0].Image).To(Equal(cfg.ArchiveContainerTemplate.Image))
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"--url", "https://artifacts.example.com/sample-go.tar.gz",
+				"--target", "$(params.shp-source-root)",
+				"--result-file-digest", "$(results.shp-source-default-archive-digest.path)",
+				"--result-file-source-timestamp", "$(results.shp-source-default-source-timestamp.path)",
+				"--result-file-error-message", "$(results.shp-error-message.path)",
+				"--result-file-error-reason", "$(results.shp-error-reason.path)",
+			}))
+		})
+	})
+
+	Context("when adding a private archive source with a checksum", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+		})
+
+		JustBeforeEach(func() {
+			sources.AppendArchiveStep(cfg, taskSpec, &buildv1beta1.Archive{
+				URL:    "https://artifacts.example.com/sample-go.zip",
+				SHA256: ptr.To("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+				Secret: ptr.To("artifact-server"),
+			}, "default")
+		})
+
+		It("adds a volume for the secret", func() {
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-artifact-server"))
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret).NotTo(BeNil())
+			Expect(taskSpec.Volumes[0].VolumeSource.Secret.SecretName).To(Equal("artifact-server"))
+		})
+
+		It("adds a step with the checksum and the secret mounted", func() {
+			Expect(len(taskSpec.Steps)).To(Equal(1))
+			Expect(taskSpec.Steps[0].Args).To(ContainElements(
+				"--sha256", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+				"--secret-path", "/workspace/shp-source-secret",
+			))
+			Expect(len(taskSpec.Steps[0].VolumeMounts)).To(Equal(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].Name).To(Equal("shp-artifact-server"))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].ReadOnly).To(BeTrue())
+		})
+	})
+})
@@ -17,6 +17,10 @@ import (
 
 // AppendBundleStep appends the bundle step to the TaskSpec
 func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *build.OCIArtifact, name string) {
+	appendBundleStep(cfg, taskSpec, oci, name, "")
+}
+
+func appendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *build.OCIArtifact, name string, targetDir string) {
 	// append the result
 	taskSpec.Results = append(taskSpec.Results,
 		pipelineapi.TaskResult{
@@ -27,13 +31,13 @@ func AppendBundleStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, oci *b
 
 	// initialize the step from the template and the build-specific arguments
 	bundleStep := pipelineapi.Step{
-		Name:            fmt.Sprintf("source-%s", name),
+		Name:            StepName(name),
 		Image:           cfg.BundleContainerTemplate.Image,
 		ImagePullPolicy: cfg.BundleContainerTemplate.ImagePullPolicy,
 		Command:         cfg.BundleContainerTemplate.Command,
 		Args: []string{
 			"--image", oci.Image,
-			"--target", fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot),
+			"--target", sourceTarget(targetDir),
 			"--result-file-image-digest", fmt.Sprintf("$(results.%s-source-%s-image-digest.path)", PrefixParamsResultsVolumes, name),
 			"--result-file-source-timestamp", fmt.Sprintf("$(results.%s-source-%s-source-timestamp.path)", PrefixParamsResultsVolumes, name),
 		},
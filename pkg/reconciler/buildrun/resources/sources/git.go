@@ -31,6 +31,16 @@ func AppendGitStep(
 	taskSpec *pipelineapi.TaskSpec,
 	source buildv1beta1.Git,
 	name string,
+) {
+	appendGitStep(cfg, taskSpec, source, name, "")
+}
+
+func appendGitStep(
+	cfg *config.Config,
+	taskSpec *pipelineapi.TaskSpec,
+	source buildv1beta1.Git,
+	name string,
+	targetDir string,
 ) {
 	// append the result
 	taskSpec.Results = append(taskSpec.Results,
@@ -50,13 +60,13 @@ func AppendGitStep(
 
 	// initialize the step from the template and the build-specific arguments
 	gitStep := pipelineapi.Step{
-		Name:            fmt.Sprintf("source-%s", name),
+		Name:            StepName(name),
 		Image:           cfg.GitContainerTemplate.Image,
 		ImagePullPolicy: cfg.GitContainerTemplate.ImagePullPolicy,
 		Command:         cfg.GitContainerTemplate.Command,
 		Args: []string{
 			"--url", source.URL,
-			"--target", fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot),
+			"--target", sourceTarget(targetDir),
 			"--result-file-commit-sha", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitSHAResult),
 			"--result-file-commit-author", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, commitAuthorResult),
 			"--result-file-branch-name", fmt.Sprintf("$(results.%s-source-%s-%s.path)", PrefixParamsResultsVolumes, name, branchName),
@@ -5,9 +5,13 @@ package sources
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
@@ -15,6 +19,17 @@ import (
 // WaiterContainerName name given to the container watier container.
 const WaiterContainerName = "source-local"
 
+// LocalUploadPort is the port of the upload endpoint in the waiter container
+const LocalUploadPort = 8710
+
+const (
+	uploadDigestResult    = "upload-digest"
+	uploadSizeResult      = "upload-size"
+	sourceDigestResult    = "source-digest"
+	sourceFileCountResult = "source-file-count"
+	sourceSizeResult      = "source-size"
+)
+
 // AppendLocalCopyStep defines and append a new task based on the waiter container template, passed
 // by the configuration instance.
 func AppendLocalCopyStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, timeout *metav1.Duration) {
@@ -37,3 +52,120 @@ func AppendLocalCopyStep(cfg *config.Config, taskSpec *pipelineapi.TaskSpec, tim
 	}
 	taskSpec.Steps = append(taskSpec.Steps, step)
 }
+
+// AppendLocalSourceResults adds the results that describe the content of the source
+// directory once the local source code was provided. The waiter reads its configuration
+// from the environment, so that a plain "waiter done" writes the results as well.
+func AppendLocalSourceResults(taskSpec *pipelineapi.TaskSpec, name string) {
+	step := findStep(taskSpec, WaiterContainerName)
+	if step == nil {
+		return
+	}
+
+	taskSpec.Results = append(taskSpec.Results,
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, sourceDigestResult),
+			Description: "The digest of the content of the source directory.",
+		},
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, sourceFileCountResult),
+			Description: "The number of files in the source directory.",
+		},
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, sourceSizeResult),
+			Description: "The total size of the files in the source directory in bytes.",
+		},
+	)
+
+	step.Env = append(step.Env,
+		corev1.EnvVar{Name: "WAITER_SOURCE_DIRECTORY", Value: fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot)},
+		corev1.EnvVar{Name: "WAITER_RESULT_FILE_SOURCE_DIGEST", Value: fmt.Sprintf("$(results.%s.path)", TaskResultName(name, sourceDigestResult))},
+		corev1.EnvVar{Name: "WAITER_RESULT_FILE_SOURCE_FILE_COUNT", Value: fmt.Sprintf("$(results.%s.path)", TaskResultName(name, sourceFileCountResult))},
+		corev1.EnvVar{Name: "WAITER_RESULT_FILE_SOURCE_SIZE", Value: fmt.Sprintf("$(results.%s.path)", TaskResultName(name, sourceSizeResult))},
+	)
+}
+
+// AppendLocalUploadEndpoint configures the waiter step to run the upload endpoint which
+// accepts the source code as tar stream, and adds the results for the upload
+func AppendLocalUploadEndpoint(taskSpec *pipelineapi.TaskSpec, local *build.Local, name string) {
+	step := findStep(taskSpec, WaiterContainerName)
+	if step == nil || local.UploadSecret == nil {
+		return
+	}
+
+	taskSpec.Results = append(taskSpec.Results,
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, uploadDigestResult),
+			Description: "The digest of the uploaded source code stream.",
+		},
+		pipelineapi.TaskResult{
+			Name:        TaskResultName(name, uploadSizeResult),
+			Description: "The size of the uploaded source code stream in bytes.",
+		},
+	)
+
+	AppendSecretVolume(taskSpec, *local.UploadSecret)
+
+	secretMountPath := fmt.Sprintf("/workspace/%s-upload-secret", PrefixParamsResultsVolumes)
+
+	// define the volume mount on the container
+	step.VolumeMounts = append(step.VolumeMounts, corev1.VolumeMount{
+		Name:      SanitizeVolumeNameForSecretName(*local.UploadSecret),
+		MountPath: secretMountPath,
+		ReadOnly:  true,
+	})
+
+	step.Args = append(step.Args,
+		fmt.Sprintf("--upload-listen-address=:%d", LocalUploadPort),
+		fmt.Sprintf("--upload-token-file=%s/token", secretMountPath),
+		fmt.Sprintf("--upload-target=$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot),
+		fmt.Sprintf("--result-file-upload-digest=$(results.%s.path)", TaskResultName(name, uploadDigestResult)),
+		fmt.Sprintf("--result-file-upload-size=$(results.%s.path)", TaskResultName(name, uploadSizeResult)),
+	)
+}
+
+// AppendLocalResult append local source result to build run
+func AppendLocalResult(buildRun *build.BuildRun, name string, results []pipelineapi.TaskRunResult) {
+	uploadDigest := FindResultValue(results, name, uploadDigestResult)
+	uploadSize := FindResultValue(results, name, uploadSizeResult)
+	sourceDigest := FindResultValue(results, name, sourceDigestResult)
+	sourceFileCount := FindResultValue(results, name, sourceFileCountResult)
+	sourceSize := FindResultValue(results, name, sourceSizeResult)
+
+	if strings.TrimSpace(uploadDigest+uploadSize+sourceDigest+sourceFileCount+sourceSize) == "" {
+		return
+	}
+
+	if buildRun.Status.Source == nil {
+		buildRun.Status.Source = &build.SourceResult{}
+	}
+
+	buildRun.Status.Source.Local = &build.LocalSourceResult{
+		UploadDigest: strings.TrimSpace(uploadDigest),
+		UploadSize:   parseInt(uploadSize),
+		Digest:       strings.TrimSpace(sourceDigest),
+		FileCount:    parseInt(sourceFileCount),
+		Size:         parseInt(sourceSize),
+	}
+}
+
+// findStep returns the step with the given name, or nil if there is none
+func findStep(taskSpec *pipelineapi.TaskSpec, name string) *pipelineapi.Step {
+	for i := range taskSpec.Steps {
+		if taskSpec.Steps[i].Name == name {
+			return &taskSpec.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+// parseInt parses a result value as integer, invalid values are treated as zero
+func parseInt(value string) int64 {
+	result, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return result
+}
@@ -8,10 +8,13 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/utils/ptr"
 
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
 )
@@ -36,3 +39,75 @@ var _ = Describe("LocalCopy", func() {
 		})
 	})
 })
+
+var _ = Describe("LocalUpload", func() {
+	cfg := config.NewDefaultConfig()
+
+	Context("when the HTTP upload method is used", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendLocalCopyStep(cfg, taskSpec, nil)
+			sources.AppendLocalUploadEndpoint(taskSpec, &buildv1beta1.Local{
+				Method:       ptr.To(buildv1beta1.LocalUploadMethodHTTP),
+				UploadSecret: ptr.To("upload-token"),
+			}, "default")
+		})
+
+		It("adds results for the upload", func() {
+			Expect(len(taskSpec.Results)).To(Equal(2))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-upload-digest"))
+			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-upload-size"))
+		})
+
+		It("mounts the upload secret", func() {
+			Expect(len(taskSpec.Volumes)).To(Equal(1))
+			Expect(taskSpec.Volumes[0].Name).To(Equal("shp-upload-token"))
+			Expect(taskSpec.Volumes[0].Secret.SecretName).To(Equal("upload-token"))
+			Expect(taskSpec.Steps[0].VolumeMounts).To(HaveLen(1))
+			Expect(taskSpec.Steps[0].VolumeMounts[0].MountPath).To(Equal("/workspace/shp-upload-secret"))
+		})
+
+		It("configures the waiter step to run the upload endpoint", func() {
+			Expect(taskSpec.Steps[0].Args).To(Equal([]string{
+				"start",
+				"--upload-listen-address=:8710",
+				"--upload-token-file=/workspace/shp-upload-secret/token",
+				"--upload-target=$(params.shp-source-root)",
+				"--result-file-upload-digest=$(results.shp-source-default-upload-digest.path)",
+				"--result-file-upload-size=$(results.shp-source-default-upload-size.path)",
+			}))
+		})
+	})
+})
+
+var _ = Describe("LocalSourceResults", func() {
+	cfg := config.NewDefaultConfig()
+
+	Context("when the source results are added", func() {
+		var taskSpec *pipelineapi.TaskSpec
+
+		BeforeEach(func() {
+			taskSpec = &pipelineapi.TaskSpec{}
+			sources.AppendLocalCopyStep(cfg, taskSpec, nil)
+			sources.AppendLocalSourceResults(taskSpec, "default")
+		})
+
+		It("adds results for the source directory", func() {
+			Expect(len(taskSpec.Results)).To(Equal(3))
+			Expect(taskSpec.Results[0].Name).To(Equal("shp-source-default-source-digest"))
+			Expect(taskSpec.Results[1].Name).To(Equal("shp-source-default-source-file-count"))
+			Expect(taskSpec.Results[2].Name).To(Equal("shp-source-default-source-size"))
+		})
+
+		It("configures the waiter step through environment variables", func() {
+			Expect(taskSpec.Steps[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "WAITER_SOURCE_DIRECTORY", Value: "$(params.shp-source-root)"},
+				corev1.EnvVar{Name: "WAITER_RESULT_FILE_SOURCE_DIGEST", Value: "$(results.shp-source-default-source-digest.path)"},
+				corev1.EnvVar{Name: "WAITER_RESULT_FILE_SOURCE_FILE_COUNT", Value: "$(results.shp-source-default-source-file-count.path)"},
+				corev1.EnvVar{Name: "WAITER_RESULT_FILE_SOURCE_SIZE", Value: "$(results.shp-source-default-source-size.path)"},
+			))
+		})
+	})
+})
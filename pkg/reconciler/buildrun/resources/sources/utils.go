@@ -7,6 +7,7 @@ package sources
 import (
 	"crypto/sha256"
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 
@@ -70,6 +71,22 @@ func SanitizeVolumeNameForSecretName(secretName string) string {
 	return sanitizedName
 }
 
+// StepName returns the name of the step that obtains the named source
+func StepName(sourceName string) string {
+	return fmt.Sprintf("source-%s", sourceName)
+}
+
+// sourceTarget returns the directory in which a source step places the source, which is
+// the target directory below the source root, or the source root itself
+func sourceTarget(targetDir string) string {
+	sourceRoot := fmt.Sprintf("$(params.%s-%s)", PrefixParamsResultsVolumes, paramSourceRoot)
+	if targetDir == "" {
+		return sourceRoot
+	}
+
+	return path.Join(sourceRoot, path.Clean(targetDir))
+}
+
 func TaskResultName(sourceName, resultName string) string {
 	return fmt.Sprintf("%s-source-%s-%s",
 		PrefixParamsResultsVolumes,
@@ -0,0 +1,86 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/sources"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// UpdateBuildRunUsingTaskStepStates surfaces the step states of the
+// taskRun as step status to the buildRun (mutates)
+func UpdateBuildRunUsingTaskStepStates(buildRun *buildv1beta1.BuildRun, taskRun *pipelineapi.TaskRun) {
+	if len(taskRun.Status.Steps) == 0 {
+		return
+	}
+
+	additionalSourceSteps := map[string]struct{}{}
+	if buildRun.Status.BuildSpec != nil {
+		for _, additionalSource := range buildRun.Status.BuildSpec.AdditionalSources {
+			additionalSourceSteps[sources.StepName(additionalSource.Name)] = struct{}{}
+		}
+	}
+
+	steps := make([]buildv1beta1.StepStatus, 0, len(taskRun.Status.Steps))
+	for _, stepState := range taskRun.Status.Steps {
+		step := buildv1beta1.StepStatus{
+			Name:    stepState.Name,
+			Origin:  stepOrigin(stepState.Name, additionalSourceSteps),
+			ImageID: stepState.ImageID,
+		}
+
+		switch {
+		case stepState.Terminated != nil:
+			startTime, completionTime := stepState.Terminated.StartedAt, stepState.Terminated.FinishedAt
+			if !startTime.IsZero() {
+				step.StartTime = &startTime
+			}
+			if !completionTime.IsZero() {
+				step.CompletionTime = &completionTime
+			}
+
+			exitCode := stepState.Terminated.ExitCode
+			step.ExitCode = &exitCode
+
+			// Tekton's termination reason tells about skipped or timed out steps,
+			// the container's reason is what Kubernetes reported
+			step.Reason = stepState.TerminationReason
+			if step.Reason == "" {
+				step.Reason = stepState.Terminated.Reason
+			}
+
+		case stepState.Running != nil:
+			startTime := stepState.Running.StartedAt
+			if !startTime.IsZero() {
+				step.StartTime = &startTime
+			}
+		}
+
+		steps = append(steps, step)
+	}
+
+	buildRun.Status.Steps = steps
+}
+
+// stepOrigin determines the origin of a step based on the step names the
+// controller uses for the steps it adds to the strategy steps, a strategy
+// step that happens to start with "source-" remains a strategy step
+func stepOrigin(stepName string, additionalSourceSteps map[string]struct{}) buildv1beta1.StepOrigin {
+	if _, ok := additionalSourceSteps[stepName]; ok {
+		return buildv1beta1.StepOriginAdditionalSource
+	}
+
+	switch stepName {
+	case containerNameImageProcessing:
+		return buildv1beta1.StepOriginImageProcessing
+
+	case sources.StepName(defaultSourceName), sources.WaiterContainerName:
+		return buildv1beta1.StepOriginSource
+
+	default:
+		return buildv1beta1.StepOriginStrategy
+	}
+}
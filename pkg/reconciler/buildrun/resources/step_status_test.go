@@ -0,0 +1,120 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	pipelineapi "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+var _ = Describe("Surfacing step states", func() {
+	Context("resources.UpdateBuildRunUsingTaskStepStates", func() {
+		start := metav1.NewTime(time.Date(2024, 3, 12, 20, 0, 0, 0, time.UTC))
+
+		It("surfaces the steps of a TaskRun with their origin", func() {
+			taskRun := pipelineapi.TaskRun{}
+			taskRun.Status.Steps = []pipelineapi.StepState{
+				{
+					Name:    "source-default",
+					ImageID: "ghcr.io/shipwright-io/build/git@sha256:0000",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						StartedAt:  start,
+						FinishedAt: metav1.NewTime(start.Add(4 * time.Second)),
+						Reason:     "Completed",
+					}},
+				},
+				{
+					Name:    "build-and-push",
+					ImageID: "gcr.io/kaniko-project/executor@sha256:1111",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						StartedAt:  metav1.NewTime(start.Add(4 * time.Second)),
+						FinishedAt: metav1.NewTime(start.Add(90 * time.Second)),
+						ExitCode:   1,
+						Reason:     "Error",
+					}},
+				},
+				{
+					Name:              "image-processing",
+					ContainerState:    corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+					TerminationReason: "Skipped",
+				},
+			}
+
+			buildRun := buildv1beta1.BuildRun{}
+			UpdateBuildRunUsingTaskStepStates(&buildRun, &taskRun)
+
+			Expect(buildRun.Status.Steps).To(HaveLen(3))
+
+			Expect(buildRun.Status.Steps[0].Name).To(Equal("source-default"))
+			Expect(buildRun.Status.Steps[0].Origin).To(Equal(buildv1beta1.StepOriginSource))
+			Expect(buildRun.Status.Steps[0].ImageID).To(Equal("ghcr.io/shipwright-io/build/git@sha256:0000"))
+			Expect(buildRun.Status.Steps[0].StartTime.Time).To(Equal(start.Time))
+			Expect(buildRun.Status.Steps[0].CompletionTime.Time).To(Equal(start.Add(4 * time.Second)))
+			Expect(*buildRun.Status.Steps[0].ExitCode).To(BeEquivalentTo(0))
+			Expect(buildRun.Status.Steps[0].Reason).To(Equal("Completed"))
+
+			Expect(buildRun.Status.Steps[1].Origin).To(Equal(buildv1beta1.StepOriginStrategy))
+			Expect(*buildRun.Status.Steps[1].ExitCode).To(BeEquivalentTo(1))
+			Expect(buildRun.Status.Steps[1].Reason).To(Equal("Error"))
+
+			Expect(buildRun.Status.Steps[2].Origin).To(Equal(buildv1beta1.StepOriginImageProcessing))
+			Expect(buildRun.Status.Steps[2].StartTime).To(BeNil())
+			Expect(buildRun.Status.Steps[2].CompletionTime).To(BeNil())
+			Expect(buildRun.Status.Steps[2].Reason).To(Equal("Skipped"))
+		})
+
+		It("classifies steps by the names of the generated source steps", func() {
+			taskRun := pipelineapi.TaskRun{}
+			taskRun.Status.Steps = []pipelineapi.StepState{
+				{Name: "source-local"},
+				{Name: "source-config"},
+				{Name: "source-scan"},
+			}
+
+			buildRun := buildv1beta1.BuildRun{}
+			buildRun.Status.BuildSpec = &buildv1beta1.BuildSpec{
+				AdditionalSources: []buildv1beta1.AdditionalSource{{Name: "config"}},
+			}
+			UpdateBuildRunUsingTaskStepStates(&buildRun, &taskRun)
+
+			Expect(buildRun.Status.Steps).To(HaveLen(3))
+			Expect(buildRun.Status.Steps[0].Origin).To(Equal(buildv1beta1.StepOriginSource))
+			Expect(buildRun.Status.Steps[1].Origin).To(Equal(buildv1beta1.StepOriginAdditionalSource))
+			Expect(buildRun.Status.Steps[2].Origin).To(Equal(buildv1beta1.StepOriginStrategy))
+		})
+
+		It("surfaces the start time of a running step", func() {
+			taskRun := pipelineapi.TaskRun{}
+			taskRun.Status.Steps = []pipelineapi.StepState{
+				{
+					Name:           "build",
+					ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: start}},
+				},
+			}
+
+			buildRun := buildv1beta1.BuildRun{}
+			UpdateBuildRunUsingTaskStepStates(&buildRun, &taskRun)
+
+			Expect(buildRun.Status.Steps).To(HaveLen(1))
+			Expect(buildRun.Status.Steps[0].StartTime.Time).To(Equal(start.Time))
+			Expect(buildRun.Status.Steps[0].CompletionTime).To(BeNil())
+			Expect(buildRun.Status.Steps[0].ExitCode).To(BeNil())
+		})
+
+		It("keeps the steps of the BuildRun if the TaskRun has no step states yet", func() {
+			buildRun := buildv1beta1.BuildRun{}
+			UpdateBuildRunUsingTaskStepStates(&buildRun, &pipelineapi.TaskRun{})
+
+			Expect(buildRun.Status.Steps).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,58 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package steps
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// OverrideResources returns the resources of a build strategy step with the step resources of the Build and BuildRun applied.
+// The step resources of the BuildRun replace those of the Build for the same step. The requests and limits of the step
+// resources replace those of the step for the same resource, the requests and limits of other resources are kept.
+func OverrideResources(step buildapi.Step, buildStepResources []buildapi.StepResources, buildRunStepResources []buildapi.StepResources) corev1.ResourceRequirements {
+	resources := *step.Resources.DeepCopy()
+
+	override := findStepResources(step.Name, buildRunStepResources)
+	if override == nil {
+		override = findStepResources(step.Name, buildStepResources)
+	}
+
+	if override == nil {
+		return resources
+	}
+
+	resources.Requests = mergeResourceLists(resources.Requests, override.Resources.Requests)
+	resources.Limits = mergeResourceLists(resources.Limits, override.Resources.Limits)
+	return resources
+}
+
+func findStepResources(stepName string, stepResources []buildapi.StepResources) *buildapi.StepResources {
+	index := slices.IndexFunc(stepResources, func(stepResource buildapi.StepResources) bool { return stepResource.Name == stepName })
+	if index < 0 {
+		return nil
+	}
+
+	return &stepResources[index]
+}
+
+func mergeResourceLists(resources corev1.ResourceList, overrides corev1.ResourceList) corev1.ResourceList {
+	if len(overrides) == 0 {
+		return resources
+	}
+
+	if resources == nil {
+		resources = corev1.ResourceList{}
+	}
+
+	for name, quantity := range overrides {
+		resources[name] = quantity.DeepCopy()
+	}
+
+	return resources
+}
@@ -0,0 +1,75 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package steps_test
+
+import (
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/steps"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OverrideResources", func() {
+
+	step := buildapi.Step{
+		Name: "build",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		MaxResources: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+	}
+
+	stepResources := func(name string, cpu string) []buildapi.StepResources {
+		return []buildapi.StepResources{{
+			Name: name,
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse(cpu),
+				},
+			},
+		}}
+	}
+
+	It("keeps the resources of the step without overrides", func() {
+		resources := steps.OverrideResources(step, nil, nil)
+		Expect(resources).To(Equal(step.Resources))
+	})
+
+	It("keeps the resources of the step if the overrides are for another step", func() {
+		resources := steps.OverrideResources(step, stepResources("push", "1"), stepResources("push", "2"))
+		Expect(resources).To(Equal(step.Resources))
+	})
+
+	It("applies the step resources of the Build", func() {
+		resources := steps.OverrideResources(step, stepResources("build", "1"), nil)
+		Expect(resources.Limits.Cpu().String()).To(Equal("1"))
+		Expect(resources.Limits.Memory().String()).To(Equal("128Mi"))
+		Expect(resources.Requests).To(Equal(step.Resources.Requests))
+	})
+
+	It("prefers the step resources of the BuildRun over those of the Build", func() {
+		resources := steps.OverrideResources(step, stepResources("build", "1"), stepResources("build", "2"))
+		Expect(resources.Limits.Cpu().String()).To(Equal("2"))
+	})
+
+	It("does not modify the step", func() {
+		steps.OverrideResources(step, stepResources("build", "1"), nil)
+		Expect(step.Resources.Limits.Cpu().String()).To(Equal("500m"))
+	})
+})
@@ -0,0 +1,76 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package steps
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// IsEnabled evaluates the when expressions of a build strategy step against the parameter values of the Build and BuildRun,
+// and the defaults of the parameter definitions. A step without when expressions is always enabled. It fails if a parameter
+// value cannot be evaluated, which is the case for values that come from a ConfigMap or Secret.
+func IsEnabled(step buildapi.Step, parameterDefinitions []buildapi.Parameter, paramValues []buildapi.ParamValue) (bool, error) {
+	for _, when := range step.When {
+		value, err := whenParameterValue(when.Parameter, parameterDefinitions, paramValues)
+		if err != nil {
+			return false, fmt.Errorf("cannot evaluate the condition of step %q: %w", step.Name, err)
+		}
+
+		var matches bool
+		switch when.Operator {
+		case buildapi.WhenOperatorEquals, buildapi.WhenOperatorIn:
+			matches = slices.Contains(when.Values, value)
+		case buildapi.WhenOperatorNotEquals, buildapi.WhenOperatorNotIn:
+			matches = !slices.Contains(when.Values, value)
+		default:
+			return false, fmt.Errorf("cannot evaluate the condition of step %q: unknown operator %q", step.Name, when.Operator)
+		}
+
+		if !matches {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// whenParameterValue returns the plain value of a parameter, or its default, boolean values are normalized to true and false
+func whenParameterValue(name string, parameterDefinitions []buildapi.Parameter, paramValues []buildapi.ParamValue) (string, error) {
+	index := slices.IndexFunc(parameterDefinitions, func(parameter buildapi.Parameter) bool { return parameter.Name == name })
+	if index < 0 {
+		return "", fmt.Errorf("parameter %q is not defined", name)
+	}
+	parameterDefinition := parameterDefinitions[index]
+
+	var value *string
+	index = slices.IndexFunc(paramValues, func(paramValue buildapi.ParamValue) bool { return paramValue.Name == name })
+	if index >= 0 && paramValues[index].SingleValue != nil {
+		singleValue := paramValues[index].SingleValue
+		if singleValue.ConfigMapValue != nil || singleValue.SecretValue != nil {
+			return "", fmt.Errorf("parameter %q uses a value from a ConfigMap or Secret", name)
+		}
+		value = singleValue.Value
+	}
+
+	if value == nil {
+		value = parameterDefinition.Default
+	}
+
+	if value == nil {
+		return "", fmt.Errorf("parameter %q has no value", name)
+	}
+
+	if parameterDefinition.Type == buildapi.ParameterTypeBoolean {
+		if parsed, err := strconv.ParseBool(*value); err == nil {
+			return strconv.FormatBool(parsed), nil
+		}
+	}
+
+	return *value, nil
+}
@@ -0,0 +1,83 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package steps_test
+
+import (
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/steps"
+
+	buildapi "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsEnabled", func() {
+
+	parameterDefinitions := []buildapi.Parameter{{
+		Name:    "mode",
+		Default: ptr.To("fast"),
+	}, {
+		Name:    "sbom",
+		Type:    buildapi.ParameterTypeBoolean,
+		Default: ptr.To("false"),
+	}, {
+		Name: "level",
+	}}
+
+	step := func(when ...buildapi.WhenExpression) buildapi.Step {
+		return buildapi.Step{Name: "a-step", When: when}
+	}
+
+	value := func(name string, value string) buildapi.ParamValue {
+		return buildapi.ParamValue{Name: name, SingleValue: &buildapi.SingleValue{Value: ptr.To(value)}}
+	}
+
+	It("enables a step without conditions", func() {
+		enabled, err := steps.IsEnabled(step(), parameterDefinitions, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+	})
+
+	It("evaluates the default if no value is provided", func() {
+		enabled, err := steps.IsEnabled(step(buildapi.WhenExpression{Parameter: "mode", Operator: buildapi.WhenOperatorEquals, Values: []string{"fast"}}), parameterDefinitions, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+	})
+
+	It("evaluates the provided value", func() {
+		enabled, err := steps.IsEnabled(step(buildapi.WhenExpression{Parameter: "mode", Operator: buildapi.WhenOperatorNotIn, Values: []string{"slow", "thorough"}}), parameterDefinitions,
+			[]buildapi.ParamValue{value("mode", "thorough")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeFalse())
+	})
+
+	It("normalizes boolean values", func() {
+		enabled, err := steps.IsEnabled(step(buildapi.WhenExpression{Parameter: "sbom", Operator: buildapi.WhenOperatorEquals, Values: []string{"true"}}), parameterDefinitions,
+			[]buildapi.ParamValue{value("sbom", "TRUE")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeTrue())
+	})
+
+	It("requires all conditions to be met", func() {
+		enabled, err := steps.IsEnabled(step(
+			buildapi.WhenExpression{Parameter: "mode", Operator: buildapi.WhenOperatorIn, Values: []string{"fast", "slow"}},
+			buildapi.WhenExpression{Parameter: "level", Operator: buildapi.WhenOperatorNotEquals, Values: []string{"debug"}},
+		), parameterDefinitions, []buildapi.ParamValue{value("level", "debug")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enabled).To(BeFalse())
+	})
+
+	It("fails for a parameter without any value", func() {
+		_, err := steps.IsEnabled(step(buildapi.WhenExpression{Parameter: "level", Operator: buildapi.WhenOperatorEquals, Values: []string{"debug"}}), parameterDefinitions, nil)
+		Expect(err).To(MatchError(ContainSubstring(`parameter "level" has no value`)))
+	})
+
+	It("fails for a value from a ConfigMap", func() {
+		_, err := steps.IsEnabled(step(buildapi.WhenExpression{Parameter: "mode", Operator: buildapi.WhenOperatorEquals, Values: []string{"fast"}}), parameterDefinitions,
+			[]buildapi.ParamValue{{Name: "mode", SingleValue: &buildapi.SingleValue{ConfigMapValue: &buildapi.ObjectKeyRef{Name: "a-config-map", Key: "a-key"}}}})
+		Expect(err).To(MatchError(ContainSubstring("ConfigMap or Secret")))
+	})
+})
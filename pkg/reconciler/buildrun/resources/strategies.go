@@ -6,11 +6,21 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/shipwright-io/build/pkg/ctxlog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -33,3 +43,257 @@ func RetrieveClusterBuildStrategy(ctx context.Context, client client.Client, bui
 	// Note: When returning the client.Get call, the clusterBuildStrategyInstance gets populated and properly returned as the first argument
 	return clusterBuildStrategyInstance, client.Get(ctx, types.NamespacedName{Name: build.Spec.Strategy.Name}, clusterBuildStrategyInstance)
 }
+
+// RetrieveBuildStrategyRevision returns the revision of the pinned version of a namespace scoped strategy
+func RetrieveBuildStrategyRevision(ctx context.Context, client client.Client, build *buildv1beta1.Build) (*buildv1beta1.BuildStrategyRevision, error) {
+	buildStrategyRevisionInstance := &buildv1beta1.BuildStrategyRevision{}
+
+	ctxlog.Debug(ctx, "retrieving BuildStrategyRevision", namespace, build.Namespace, name, build.Name)
+
+	revisionName := buildv1beta1.RevisionName(build.Spec.Strategy.Name, *build.Spec.Strategy.Version)
+	if err := client.Get(ctx, types.NamespacedName{Name: revisionName, Namespace: build.Namespace}, buildStrategyRevisionInstance); err != nil {
+		return buildStrategyRevisionInstance, err
+	}
+
+	// a revision of another strategy can have the same name
+	if !buildStrategyRevisionInstance.IsRevisionOf(build.Spec.Strategy.Name, *build.Spec.Strategy.Version) {
+		return buildStrategyRevisionInstance, apierrors.NewNotFound(buildv1beta1.Resource("buildstrategyrevisions"), revisionName)
+	}
+
+	return buildStrategyRevisionInstance, nil
+}
+
+// RetrieveClusterBuildStrategyRevision returns the revision of the pinned version of a cluster scoped strategy
+func RetrieveClusterBuildStrategyRevision(ctx context.Context, client client.Client, build *buildv1beta1.Build) (*buildv1beta1.ClusterBuildStrategyRevision, error) {
+	clusterBuildStrategyRevisionInstance := &buildv1beta1.ClusterBuildStrategyRevision{}
+
+	ctxlog.Debug(ctx, "retrieving ClusterBuildStrategyRevision", namespace, build.Namespace, name, build.Name)
+
+	revisionName := buildv1beta1.RevisionName(build.Spec.Strategy.Name, *build.Spec.Strategy.Version)
+	if err := client.Get(ctx, types.NamespacedName{Name: revisionName}, clusterBuildStrategyRevisionInstance); err != nil {
+		return clusterBuildStrategyRevisionInstance, err
+	}
+
+	// a revision of another strategy can have the same name
+	if !clusterBuildStrategyRevisionInstance.IsRevisionOf(build.Spec.Strategy.Name, *build.Spec.Strategy.Version) {
+		return clusterBuildStrategyRevisionInstance, apierrors.NewNotFound(buildv1beta1.Resource("clusterbuildstrategyrevisions"), revisionName)
+	}
+
+	return clusterBuildStrategyRevisionInstance, nil
+}
+
+// StrategySnapshotDigest returns the digest of the snapshot of a strategy in a revision
+func StrategySnapshotDigest(spec *buildv1beta1.BuildStrategySpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data)), nil
+}
+
+// CheckClusterBuildStrategyAllowed returns a ClusterBuildStrategyNotAllowedError if the namespace is not allowed to use
+// the ClusterBuildStrategy. The labels of the namespace must match the namespaceSelector of the current ClusterBuildStrategy,
+// also when a revision of it is used. If the strategy is privileged, the namespace must in addition opt in through the
+// privileged strategies label.
+func CheckClusterBuildStrategyAllowed(ctx context.Context, client client.Client, namespaceName string, strategyName string, strategy buildv1beta1.BuilderStrategy) error {
+	ctxlog.Debug(ctx, "checking ClusterBuildStrategy access", namespace, namespaceName, name, strategyName)
+
+	clusterBuildStrategy := &buildv1beta1.ClusterBuildStrategy{}
+	if err := client.Get(ctx, types.NamespacedName{Name: strategyName}, clusterBuildStrategy); err != nil {
+		return err
+	}
+
+	privileged := IsPrivilegedStrategy(clusterBuildStrategy, strategy)
+	if clusterBuildStrategy.Spec.NamespaceSelector == nil && !privileged {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := client.Get(ctx, types.NamespacedName{Name: namespaceName}, ns); err != nil {
+		return err
+	}
+
+	if clusterBuildStrategy.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(clusterBuildStrategy.Spec.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("failed to parse the namespaceSelector of clusterBuildStrategy %s: %w", strategyName, err)
+		}
+
+		if !selector.Matches(labels.Set(ns.Labels)) {
+			return ClusterBuildStrategyNotAllowedError{strategy: strategyName, namespace: namespaceName, reason: "the namespace does not match its namespaceSelector"}
+		}
+	}
+
+	if privileged && ns.Labels[buildv1beta1.LabelPrivilegedStrategies] != "true" {
+		return ClusterBuildStrategyNotAllowedError{strategy: strategyName, namespace: namespaceName,
+			reason: fmt.Sprintf("it is privileged and the namespace does not have the label %s=true", buildv1beta1.LabelPrivilegedStrategies)}
+	}
+
+	return nil
+}
+
+// IsPrivilegedStrategy returns whether a ClusterBuildStrategy requires the namespace to opt in to privileged strategies.
+// The privileged annotation of the current ClusterBuildStrategy decides if it is set. Otherwise, the strategy is privileged
+// if one of its steps runs privileged, allows a privilege escalation, adds capabilities, or runs as root, which includes
+// steps that inherit the root user from the securityContext of the strategy.
+func IsPrivilegedStrategy(clusterBuildStrategy *buildv1beta1.ClusterBuildStrategy, strategy buildv1beta1.BuilderStrategy) bool {
+	if value, ok := clusterBuildStrategy.Annotations[buildv1beta1.AnnotationPrivilegedStrategy]; ok {
+		return strings.EqualFold(value, "true")
+	}
+
+	return slices.ContainsFunc(strategy.GetBuildSteps(), func(step buildv1beta1.Step) bool {
+		return isPrivilegedStep(step, strategy.GetSecurityContext())
+	})
+}
+
+func isPrivilegedStep(step buildv1beta1.Step, strategySecurityContext *buildv1beta1.BuildStrategySecurityContext) bool {
+	securityContext := step.SecurityContext
+	if securityContext == nil {
+		return strategySecurityContext != nil && strategySecurityContext.RunAsUser == 0
+	}
+
+	switch {
+	case securityContext.Privileged != nil && *securityContext.Privileged:
+		return true
+
+	case securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation:
+		return true
+
+	case securityContext.Capabilities != nil && len(securityContext.Capabilities.Add) > 0:
+		return true
+
+	case securityContext.RunAsUser != nil:
+		return *securityContext.RunAsUser == 0
+
+	default:
+		return strategySecurityContext != nil && strategySecurityContext.RunAsUser == 0
+	}
+}
+
+// ResolveStrategySpec returns a copy of the strategy spec with the steps, parameters, and volumes of the referenced
+// ClusterStrategyFragments merged into it. The steps of a fragment are placed before or after the steps of the strategy.
+// A step, parameter, or volume that is defined more than once must be defined identically, otherwise a
+// StrategyFragmentConflictError is returned.
+func ResolveStrategySpec(ctx context.Context, client client.Client, spec *buildv1beta1.BuildStrategySpec) (*buildv1beta1.BuildStrategySpec, error) {
+	resolved := spec.DeepCopy()
+	if len(resolved.Fragments) == 0 {
+		return resolved, nil
+	}
+
+	knownSteps := indexByName(resolved.Steps, stepName)
+	knownParameters := indexByName(resolved.Parameters, parameterName)
+	knownVolumes := indexByName(resolved.Volumes, volumeName)
+
+	var stepsBefore, stepsAfter []buildv1beta1.Step
+	for _, reference := range resolved.Fragments {
+		ctxlog.Debug(ctx, "retrieving ClusterStrategyFragment", name, reference.Name)
+
+		fragment := &buildv1beta1.ClusterStrategyFragment{}
+		if err := client.Get(ctx, types.NamespacedName{Name: reference.Name}, fragment); err != nil {
+			return nil, fmt.Errorf("failed to get ClusterStrategyFragment %q: %w", reference.Name, err)
+		}
+
+		steps, err := mergeFragmentItems(knownSteps, fragment.Spec.Steps, stepName, reference.Name, "step")
+		if err != nil {
+			return nil, err
+		}
+		if reference.Position == buildv1beta1.FragmentPositionAfter {
+			stepsAfter = append(stepsAfter, steps...)
+		} else {
+			stepsBefore = append(stepsBefore, steps...)
+		}
+
+		parameters, err := mergeFragmentItems(knownParameters, fragment.Spec.Parameters, parameterName, reference.Name, "parameter")
+		if err != nil {
+			return nil, err
+		}
+		resolved.Parameters = append(resolved.Parameters, parameters...)
+
+		volumes, err := mergeFragmentItems(knownVolumes, fragment.Spec.Volumes, volumeName, reference.Name, "volume")
+		if err != nil {
+			return nil, err
+		}
+		resolved.Volumes = append(resolved.Volumes, volumes...)
+	}
+
+	resolved.Steps = append(append(stepsBefore, resolved.Steps...), stepsAfter...)
+	resolved.Fragments = nil
+
+	return resolved, nil
+}
+
+// ResolveStrategyFragments returns the strategy with the ClusterStrategyFragments that it references merged into it
+func ResolveStrategyFragments(ctx context.Context, client client.Client, strategy buildv1beta1.BuilderStrategy) (buildv1beta1.BuilderStrategy, error) {
+	if len(strategy.GetFragments()) == 0 {
+		return strategy, nil
+	}
+
+	spec, err := ResolveStrategySpec(ctx, client, &buildv1beta1.BuildStrategySpec{
+		Steps:      strategy.GetBuildSteps(),
+		Parameters: strategy.GetParameters(),
+		Volumes:    strategy.GetVolumes(),
+		Fragments:  strategy.GetFragments(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resolvedStrategy{BuilderStrategy: strategy, spec: spec}, nil
+}
+
+// resolvedStrategy is a strategy with the steps, parameters, and volumes of its fragments merged into it
+type resolvedStrategy struct {
+	buildv1beta1.BuilderStrategy
+	spec *buildv1beta1.BuildStrategySpec
+}
+
+func (s resolvedStrategy) GetBuildSteps() []buildv1beta1.Step {
+	return s.spec.Steps
+}
+
+func (s resolvedStrategy) GetParameters() []buildv1beta1.Parameter {
+	return s.spec.Parameters
+}
+
+func (s resolvedStrategy) GetVolumes() []buildv1beta1.BuildStrategyVolume {
+	return s.spec.Volumes
+}
+
+func (s resolvedStrategy) GetFragments() []buildv1beta1.StrategyFragmentReference {
+	return nil
+}
+
+// mergeFragmentItems returns the items of a fragment that are not known yet, and adds them to the known items. An item
+// that is already known must be equal to the known one.
+func mergeFragmentItems[T any](known map[string]T, items []T, nameOf func(T) string, fragment string, kind string) ([]T, error) {
+	var merged []T
+	for _, item := range items {
+		itemName := nameOf(item)
+		if knownItem, exists := known[itemName]; exists {
+			if !equality.Semantic.DeepEqual(knownItem, item) {
+				return nil, StrategyFragmentConflictError{fragment: fragment, kind: kind, name: itemName}
+			}
+			continue
+		}
+
+		known[itemName] = item
+		merged = append(merged, item)
+	}
+
+	return merged, nil
+}
+
+func indexByName[T any](items []T, nameOf func(T) string) map[string]T {
+	index := make(map[string]T, len(items))
+	for _, item := range items {
+		index[nameOf(item)] = item
+	}
+	return index
+}
+
+func stepName(step buildv1beta1.Step) string { return step.Name }
+
+func parameterName(parameter buildv1beta1.Parameter) string { return parameter.Name }
+
+func volumeName(volume buildv1beta1.BuildStrategyVolume) string { return volume.Name }
@@ -14,8 +14,10 @@ import (
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 	test "github.com/shipwright-io/build/test/v1beta1_samples"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	crc "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -66,4 +68,124 @@ var _ = Describe("Operating Build strategies", func() {
 		})
 
 	})
+
+	Context("Resolving strategy fragments", func() {
+		var fragments map[string]*buildv1beta1.ClusterStrategyFragment
+
+		BeforeEach(func() {
+			client = &fakes.FakeClient{}
+			fragments = map[string]*buildv1beta1.ClusterStrategyFragment{
+				"ca-bundle": {
+					ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle"},
+					Spec: buildv1beta1.StrategyFragmentSpec{
+						Parameters: []buildv1beta1.Parameter{{Name: "ca-bundle-configmap", Default: ptr.To("ca-bundle")}},
+						Steps:      []buildv1beta1.Step{{Name: "inject-ca-bundle", Image: "ubi-minimal"}},
+						Volumes:    []buildv1beta1.BuildStrategyVolume{{Name: "ca-bundle"}},
+					},
+				},
+				"sbom-upload": {
+					ObjectMeta: metav1.ObjectMeta{Name: "sbom-upload"},
+					Spec: buildv1beta1.StrategyFragmentSpec{
+						Parameters: []buildv1beta1.Parameter{{Name: "ca-bundle-configmap", Default: ptr.To("ca-bundle")}},
+						Steps:      []buildv1beta1.Step{{Name: "upload-sbom", Image: "syft"}},
+					},
+				},
+			}
+
+			client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+				if fragment, ok := object.(*buildv1beta1.ClusterStrategyFragment); ok {
+					if existing, exists := fragments[nn.Name]; exists {
+						existing.DeepCopyInto(fragment)
+						return nil
+					}
+				}
+				return k8serrors.NewNotFound(schema.GroupResource{}, nn.Name)
+			})
+		})
+
+		strategySpec := func(references ...buildv1beta1.StrategyFragmentReference) *buildv1beta1.BuildStrategySpec {
+			return &buildv1beta1.BuildStrategySpec{
+				Parameters: []buildv1beta1.Parameter{{Name: "dockerfile"}},
+				Steps:      []buildv1beta1.Step{{Name: "build", Image: "buildah"}},
+				Fragments:  references,
+			}
+		}
+
+		stepNames := func(steps []buildv1beta1.Step) []string {
+			names := []string{}
+			for _, step := range steps {
+				names = append(names, step.Name)
+			}
+			return names
+		}
+
+		It("returns a copy of a spec without fragments", func() {
+			spec := strategySpec()
+			resolved, err := resources.ResolveStrategySpec(context.TODO(), client, spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal(spec))
+			Expect(resolved).ToNot(BeIdenticalTo(spec))
+			Expect(client.GetCallCount()).To(BeZero())
+		})
+
+		It("places the steps of the fragments before or after the steps of the strategy", func() {
+			resolved, err := resources.ResolveStrategySpec(context.TODO(), client, strategySpec(
+				buildv1beta1.StrategyFragmentReference{Name: "sbom-upload", Position: buildv1beta1.FragmentPositionAfter},
+				buildv1beta1.StrategyFragmentReference{Name: "ca-bundle"},
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stepNames(resolved.Steps)).To(Equal([]string{"inject-ca-bundle", "build", "upload-sbom"}))
+			Expect(resolved.Volumes).To(HaveLen(1))
+			Expect(resolved.Fragments).To(BeNil())
+		})
+
+		It("adds identical parameters only once", func() {
+			resolved, err := resources.ResolveStrategySpec(context.TODO(), client, strategySpec(
+				buildv1beta1.StrategyFragmentReference{Name: "ca-bundle"},
+				buildv1beta1.StrategyFragmentReference{Name: "sbom-upload"},
+			))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved.Parameters).To(HaveLen(2))
+			Expect(resolved.Parameters[1].Name).To(Equal("ca-bundle-configmap"))
+		})
+
+		It("fails for a parameter that is defined differently", func() {
+			fragments["sbom-upload"].Spec.Parameters[0].Default = ptr.To("another-bundle")
+
+			_, err := resources.ResolveStrategySpec(context.TODO(), client, strategySpec(
+				buildv1beta1.StrategyFragmentReference{Name: "ca-bundle"},
+				buildv1beta1.StrategyFragmentReference{Name: "sbom-upload"},
+			))
+			Expect(resources.IsStrategyFragmentConflictError(err)).To(BeTrue())
+			Expect(err).To(MatchError(`the parameter "ca-bundle-configmap" of the ClusterStrategyFragment "sbom-upload" conflicts with a different definition of the same name`))
+		})
+
+		It("fails for a step that conflicts with a step of the strategy", func() {
+			fragments["ca-bundle"].Spec.Steps[0].Name = "build"
+
+			_, err := resources.ResolveStrategySpec(context.TODO(), client, strategySpec(buildv1beta1.StrategyFragmentReference{Name: "ca-bundle"}))
+			Expect(resources.IsStrategyFragmentConflictError(err)).To(BeTrue())
+		})
+
+		It("fails for a fragment that does not exist", func() {
+			_, err := resources.ResolveStrategySpec(context.TODO(), client, strategySpec(buildv1beta1.StrategyFragmentReference{Name: "proxy"}))
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("merges the fragments into a BuilderStrategy", func() {
+			strategy := &buildv1beta1.ClusterBuildStrategy{
+				ObjectMeta: metav1.ObjectMeta{Name: "buildah", Generation: 3},
+				Spec:       *strategySpec(buildv1beta1.StrategyFragmentReference{Name: "ca-bundle"}),
+			}
+
+			resolved, err := resources.ResolveStrategyFragments(context.TODO(), client, strategy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved.GetName()).To(Equal("buildah"))
+			Expect(resolved.GetGeneration()).To(Equal(int64(3)))
+			Expect(stepNames(resolved.GetBuildSteps())).To(Equal([]string{"inject-ca-bundle", "build"}))
+			Expect(resolved.GetParameters()).To(HaveLen(2))
+			Expect(resolved.GetVolumes()).To(HaveLen(1))
+			Expect(resolved.GetFragments()).To(BeEmpty())
+		})
+	})
 })
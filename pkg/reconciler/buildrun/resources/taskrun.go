@@ -28,6 +28,8 @@ const (
 
 	paramOutputImage    = "output-image"
 	paramOutputInsecure = "output-insecure"
+	paramCacheImage     = "cache-image"
+	paramCacheInsecure  = "cache-insecure"
 	paramSourceRoot     = "source-root"
 	paramSourceContext  = "source-context"
 
@@ -55,6 +57,16 @@ func GenerateTaskSpec(
 				Description: "A flag indicating that the output image is on an insecure container registry",
 				Type:        pipelineapi.ParamTypeString,
 			},
+			{
+				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramCacheImage),
+				Description: "The URL of the image that is used as a layer cache, empty if no cache is configured",
+				Type:        pipelineapi.ParamTypeString,
+			},
+			{
+				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramCacheInsecure),
+				Description: "A flag indicating that the cache image is on an insecure container registry",
+				Type:        pipelineapi.ParamTypeString,
+			},
 			{
 				Name:        fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramSourceContext),
 				Description: "The context directory inside the source directory",
@@ -90,7 +102,7 @@ func GenerateTaskSpec(
 		switch parameterDefinition.Type {
 		case "": // string is default
 			fallthrough
-		case buildv1beta1.ParameterTypeString:
+		case buildv1beta1.ParameterTypeString, buildv1beta1.ParameterTypeBoolean, buildv1beta1.ParameterTypeInteger:
 			param.Type = pipelineapi.ParamTypeString
 			if parameterDefinition.Default != nil {
 				param.Default = &pipelineapi.ParamValue{
@@ -107,6 +119,23 @@ func GenerateTaskSpec(
 					ArrayVal: *parameterDefinition.Defaults,
 				}
 			}
+
+		case buildv1beta1.ParameterTypeObject:
+			param.Type = pipelineapi.ParamTypeObject
+			param.Properties = map[string]pipelineapi.PropertySpec{}
+			for _, property := range parameterDefinition.Properties {
+				param.Properties[property] = pipelineapi.PropertySpec{Type: pipelineapi.ParamTypeString}
+			}
+			if parameterDefinition.DefaultProperties != nil {
+				param.Default = &pipelineapi.ParamValue{
+					Type:      pipelineapi.ParamTypeObject,
+					ObjectVal: map[string]string{},
+				}
+				for property, value := range *parameterDefinition.DefaultProperties {
+					param.Properties[property] = pipelineapi.PropertySpec{Type: pipelineapi.ParamTypeString}
+					param.Default.ObjectVal[property] = value
+				}
+			}
 		}
 
 		generatedTaskSpec.Params = append(generatedTaskSpec.Params, param)
@@ -124,8 +153,19 @@ func GenerateTaskSpec(
 	volumeMounts := make(map[string]bool)
 	buildStrategyVolumesMap := toVolumeMap(buildStrategyVolumes)
 
+	// the parameter values decide which of the conditional steps run
+	paramValues := OverrideParams(build.Spec.ParamValues, buildRun.Spec.ParamValues)
+
 	// define the steps coming from the build strategy
 	for _, containerValue := range buildSteps {
+		enabled, err := steps.IsEnabled(containerValue, parameterDefinitions, paramValues)
+		if err != nil {
+			return nil, err
+		}
+
+		if !enabled {
+			continue
+		}
 
 		// Any collision between the env vars in the Container step and those in the Build/BuildRun
 		// will result in an error and cause a failed TaskRun
@@ -143,7 +183,7 @@ func GenerateTaskSpec(
 			Args:             containerValue.Args,
 			SecurityContext:  containerValue.SecurityContext,
 			WorkingDir:       containerValue.WorkingDir,
-			ComputeResources: containerValue.Resources,
+			ComputeResources: steps.OverrideResources(containerValue, build.Spec.StepResources, buildRun.Spec.StepResources),
 			Env:              stepEnv,
 		}
 
@@ -193,6 +233,16 @@ func GenerateTaskRun(
 		insecure = *build.Spec.Output.Insecure
 	}
 
+	// retrieve the cache from build or buildRun, the cache image is empty if none is configured
+	var cacheImage string
+	cacheInsecure := false
+	if cache := effectiveCache(build, buildRun); cache != nil {
+		cacheImage = cache.Image
+		if cache.Insecure != nil {
+			cacheInsecure = *cache.Insecure
+		}
+	}
+
 	taskSpec, err := GenerateTaskSpec(
 		cfg,
 		build,
@@ -304,6 +354,22 @@ func GenerateTaskRun(
 				StringVal: strconv.FormatBool(insecure),
 			},
 		},
+		{
+			// shp-cache-image
+			Name: fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramCacheImage),
+			Value: pipelineapi.ParamValue{
+				Type:      pipelineapi.ParamTypeString,
+				StringVal: cacheImage,
+			},
+		},
+		{
+			// shp-cache-insecure
+			Name: fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramCacheInsecure),
+			Value: pipelineapi.ParamValue{
+				Type:      pipelineapi.ParamTypeString,
+				StringVal: strconv.FormatBool(cacheInsecure),
+			},
+		},
 		{
 			Name: fmt.Sprintf("%s-%s", prefixParamsResultsVolumes, paramSourceRoot),
 			Value: pipelineapi.ParamValue{
@@ -350,6 +416,9 @@ func GenerateTaskRun(
 		}
 	}
 
+	// Expand the references to complete object parameters into one argument per property
+	ExpandObjectParams(expectedTaskRun)
+
 	// Setup image processing, this can be a no-op if no annotations or labels need to be mutated,
 	// and if the strategy is pushing the image by not using $(params.shp-output-directory)
 	buildRunOutput := buildRun.Spec.Output
@@ -377,6 +446,15 @@ func effectiveTimeout(build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun
 	return nil
 }
 
+// effectiveCache returns the cache of the BuildRun, or the one of the Build if the BuildRun does not override it
+func effectiveCache(build *buildv1beta1.Build, buildRun *buildv1beta1.BuildRun) *buildv1beta1.ImageCache {
+	if buildRun.Spec.Cache != nil {
+		return buildRun.Spec.Cache
+	}
+
+	return build.Spec.Cache
+}
+
 // mergeTolerations merges the values for Spec.Tolerations in the given Build and BuildRun objects, with values in the BuildRun object overriding values
 // in the Build object (if present).
 func mergeTolerations(buildTolerations []corev1.Toleration, buildRunTolerations []corev1.Toleration) []corev1.Toleration {
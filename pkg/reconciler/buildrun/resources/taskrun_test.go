@@ -17,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
@@ -130,12 +131,14 @@ var _ = Describe("GenerateTaskrun", func() {
 				Expect(got.Params).To(utils.ContainNamedElement("shp-source-context"))
 				Expect(got.Params).To(utils.ContainNamedElement("shp-output-image"))
 				Expect(got.Params).To(utils.ContainNamedElement("shp-output-insecure"))
+				Expect(got.Params).To(utils.ContainNamedElement("shp-cache-image"))
+				Expect(got.Params).To(utils.ContainNamedElement("shp-cache-insecure"))
 
 				// legacy params have been removed
 				Expect(got.Params).ToNot(utils.ContainNamedElement("BUILDER_IMAGE"))
 				Expect(got.Params).ToNot(utils.ContainNamedElement("CONTEXT_DIR"))
 
-				Expect(len(got.Params)).To(Equal(5))
+				Expect(len(got.Params)).To(Equal(7))
 			})
 
 			It("should contain a step to mutate the image with single mutate args", func() {
@@ -214,6 +217,74 @@ var _ = Describe("GenerateTaskrun", func() {
 			})
 		})
 
+		Context("when the BuildStrategy has a conditional step", func() {
+			var parameterDefinitions []buildv1beta1.Parameter
+
+			BeforeEach(func() {
+				build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuildahBuild))
+				Expect(err).To(BeNil())
+
+				buildRun, err = ctl.LoadBuildRunFromBytes([]byte(test.MinimalBuildahBuildRun))
+				Expect(err).To(BeNil())
+
+				buildStrategy, err = ctl.LoadBuildStrategyFromBytes([]byte(test.MinimalBuildahBuildStrategy))
+				Expect(err).To(BeNil())
+
+				buildStrategy.Spec.Steps = append(buildStrategy.Spec.Steps, buildv1beta1.Step{
+					Name:    "run-tests",
+					Image:   "golang",
+					Command: []string{"go", "test", "./..."},
+					When: []buildv1beta1.WhenExpression{{
+						Parameter: "run-tests",
+						Operator:  buildv1beta1.WhenOperatorEquals,
+						Values:    []string{"true"},
+					}},
+				})
+
+				parameterDefinitions = []buildv1beta1.Parameter{{
+					Name:    "run-tests",
+					Type:    buildv1beta1.ParameterTypeBoolean,
+					Default: ptr.To("false"),
+				}}
+			})
+
+			stepNames := func(taskSpec *pipelineapi.TaskSpec) []string {
+				names := []string{}
+				for _, step := range taskSpec.Steps {
+					names = append(names, step.Name)
+				}
+				return names
+			}
+
+			It("removes the step when the condition is not met", func() {
+				got, err = resources.GenerateTaskSpec(config.NewDefaultConfig(), build, buildRun, buildStrategy.Spec.Steps, parameterDefinitions, buildStrategy.GetVolumes())
+				Expect(err).To(BeNil())
+				Expect(stepNames(got)).ToNot(ContainElement("run-tests"))
+			})
+
+			It("keeps the step when the condition is met by the BuildRun", func() {
+				buildRun.Spec.ParamValues = []buildv1beta1.ParamValue{{
+					Name:        "run-tests",
+					SingleValue: &buildv1beta1.SingleValue{Value: ptr.To("True")},
+				}}
+
+				got, err = resources.GenerateTaskSpec(config.NewDefaultConfig(), build, buildRun, buildStrategy.Spec.Steps, parameterDefinitions, buildStrategy.GetVolumes())
+				Expect(err).To(BeNil())
+				Expect(stepNames(got)).To(ContainElement("run-tests"))
+			})
+
+			It("fails when the parameter value comes from a Secret", func() {
+				build.Spec.ParamValues = []buildv1beta1.ParamValue{{
+					Name:        "run-tests",
+					SingleValue: &buildv1beta1.SingleValue{SecretValue: &buildv1beta1.ObjectKeyRef{Name: "a-secret", Key: "a-key"}},
+				}}
+
+				_, err = resources.GenerateTaskSpec(config.NewDefaultConfig(), build, buildRun, buildStrategy.Spec.Steps, parameterDefinitions, buildStrategy.GetVolumes())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`step "run-tests"`))
+			})
+		})
+
 		Context("when env vars are defined", func() {
 			BeforeEach(func() {
 				build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuildahBuild))
@@ -528,6 +599,8 @@ var _ = Describe("GenerateTaskrun", func() {
 				paramSourceContextFound := false
 				paramOutputImageFound := false
 				paramOutputInsecureFound := false
+				paramCacheImageFound := false
+				paramCacheInsecureFound := false
 
 				for _, param := range params {
 					switch param.Name {
@@ -547,6 +620,14 @@ var _ = Describe("GenerateTaskrun", func() {
 						paramOutputInsecureFound = true
 						Expect(param.Value.StringVal).To(Equal("false"))
 
+					case "shp-cache-image":
+						paramCacheImageFound = true
+						Expect(param.Value.StringVal).To(BeEmpty())
+
+					case "shp-cache-insecure":
+						paramCacheInsecureFound = true
+						Expect(param.Value.StringVal).To(Equal("false"))
+
 					default:
 						Fail(fmt.Sprintf("Unexpected param found: %s", param.Name))
 					}
@@ -556,6 +637,8 @@ var _ = Describe("GenerateTaskrun", func() {
 				Expect(paramSourceContextFound).To(BeTrue())
 				Expect(paramOutputImageFound).To(BeTrue())
 				Expect(paramOutputInsecureFound).To(BeTrue())
+				Expect(paramCacheImageFound).To(BeTrue())
+				Expect(paramCacheInsecureFound).To(BeTrue())
 			})
 
 			It("should ensure resource replacements happen when needed", func() {
@@ -633,6 +716,44 @@ var _ = Describe("GenerateTaskrun", func() {
 			})
 		})
 
+		Context("when the build and buildrun both contain a cache", func() {
+			BeforeEach(func() {
+				build, err = ctl.LoadBuildYAML([]byte(test.BuildahBuildWithOutput))
+				Expect(err).To(BeNil())
+				build.Spec.Cache = &buildv1beta1.ImageCache{
+					Image: "registry.example.com/cache/build",
+				}
+
+				buildRun, err = ctl.LoadBuildRunFromBytes([]byte(test.BuildahBuildRunWithSA))
+				Expect(err).To(BeNil())
+				buildRun.Spec.Cache = &buildv1beta1.ImageCache{
+					Image:    "registry.example.com/cache/buildrun",
+					Insecure: ptr.To(true),
+				}
+
+				buildStrategy, err = ctl.LoadBuildStrategyFromBytes([]byte(test.BuildahBuildStrategySingleStep))
+				Expect(err).To(BeNil())
+			})
+
+			JustBeforeEach(func() {
+				got, err = resources.GenerateTaskRun(config.NewDefaultConfig(), build, buildRun, serviceAccountName, buildStrategy)
+				Expect(err).To(BeNil())
+			})
+
+			It("should use the cache from the BuildRun for the params", func() {
+				Expect(got.Spec.Params).To(ContainElements(
+					pipelineapi.Param{
+						Name:  "shp-cache-image",
+						Value: pipelineapi.ParamValue{Type: pipelineapi.ParamTypeString, StringVal: "registry.example.com/cache/buildrun"},
+					},
+					pipelineapi.Param{
+						Name:  "shp-cache-insecure",
+						Value: pipelineapi.ParamValue{Type: pipelineapi.ParamTypeString, StringVal: "true"},
+					},
+				))
+			})
+		})
+
 		Context("when the build and buildrun both specify a nodeSelector", func() {
 			BeforeEach(func() {
 				build, err = ctl.LoadBuildYAML([]byte(test.MinimalBuildWithNodeSelector))
@@ -39,7 +39,7 @@ func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
 // once the ttl limit is hit
 func (r *ReconcileBuildRun) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	// Set the ctx to be Background, as the top-level context for incoming requests.
-	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
 	defer cancel()
 
 	ctxlog.Debug(ctx, "Start reconciling Buildrun-ttl", namespace, request.Namespace, name, request.Name)
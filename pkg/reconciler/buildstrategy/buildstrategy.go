@@ -7,12 +7,22 @@ package buildstrategy
 import (
 	"context"
 
-	"github.com/shipwright-io/build/pkg/config"
-	"github.com/shipwright-io/build/pkg/ctxlog"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+	"github.com/shipwright-io/build/pkg/validate"
 )
 
 // blank assignment to verify that ReconcileBuildStrategy implements reconcile.Reconciler
@@ -41,9 +51,135 @@ func NewReconciler(c *config.Config, mgr manager.Manager) reconcile.Reconciler {
 func (r *ReconcileBuildStrategy) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 
 	// Set the ctx to be Background, as the top-level context for incoming requests.
-	ctx, cancel := context.WithTimeout(ctx, r.config.CtxTimeOut)
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
 	defer cancel()
 
 	ctxlog.Info(ctx, "reconciling BuildStrategy", "namespace", request.Namespace, "name", request.Name)
-	return reconcile.Result{}, nil
+
+	strategy := &buildv1beta1.BuildStrategy{}
+	if err := r.client.Get(ctx, request.NamespacedName, strategy); err != nil {
+		if apierrors.IsNotFound(err) {
+			ctxlog.Debug(ctx, "finish reconciling BuildStrategy, not found", "namespace", request.Namespace, "name", request.Name)
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	// the strategy is validated with the steps, parameters, and volumes of its fragments merged into it
+	spec, err := resources.ResolveStrategySpec(ctx, r.client, &strategy.Spec)
+
+	var statusChanged bool
+	switch {
+	case err == nil:
+		statusChanged = validate.BuildStrategyStatus(&strategy.Status, spec, strategy.Generation)
+	case apierrors.IsNotFound(err):
+		statusChanged = validate.BuildStrategyNotReadyStatus(&strategy.Status, strategy.Generation, buildv1beta1.BuildStrategyFragmentNotFound, err.Error())
+	case resources.IsStrategyFragmentConflictError(err):
+		statusChanged = validate.BuildStrategyNotReadyStatus(&strategy.Status, strategy.Generation, buildv1beta1.BuildStrategyFragmentConflict, err.Error())
+	default:
+		return reconcile.Result{}, err
+	}
+
+	if statusChanged {
+		if condition := strategy.Status.GetCondition(buildv1beta1.Ready); condition.Status == corev1.ConditionFalse {
+			ctxlog.Info(ctx, "BuildStrategy is not valid", "namespace", request.Namespace, "name", request.Name, "reason", condition.Reason, "message", condition.Message)
+		}
+
+		if err := r.client.Status().Update(ctx, strategy); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// only valid versions become a revision that Builds can pin
+	if strategy.Spec.Version == "" || strategy.Status.GetCondition(buildv1beta1.Ready).Status != corev1.ConditionTrue {
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{}, r.createRevision(ctx, strategy, spec)
+}
+
+// createRevision creates the revision for the version of the strategy unless it exists. The revision
+// snapshots the resolved spec, which has the fragments of the strategy merged into it. Revisions are
+// immutable, changes of the strategy or its fragments that keep the version do not reach the existing revision.
+// The created revision is recorded in the status of the strategy, so that a deleted revision is only created
+// again with the same snapshot.
+func (r *ReconcileBuildStrategy) createRevision(ctx context.Context, strategy *buildv1beta1.BuildStrategy, spec *buildv1beta1.BuildStrategySpec) error {
+	revisionName := buildv1beta1.RevisionName(strategy.Name, strategy.Spec.Version)
+
+	digest, err := resources.StrategySnapshotDigest(spec)
+	if err != nil {
+		return err
+	}
+
+	revision := &buildv1beta1.BuildStrategyRevision{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: strategy.Namespace, Name: revisionName}, revision)
+	switch {
+	case err == nil && !revision.IsRevisionOf(strategy.Name, strategy.Spec.Version):
+		ctxlog.Info(ctx, "the name of the revision is used by the revision of another BuildStrategy, no revision is created", "namespace", strategy.Namespace, "name", strategy.Name, "revision", revisionName, "revisionOf", revision.Spec.Strategy)
+		return nil
+
+	case err == nil:
+		if revision.Spec.Generation != strategy.Generation && !equality.Semantic.DeepEqual(revision.Spec.Snapshot, *spec) {
+			ctxlog.Info(ctx, "BuildStrategy changed without a new version, the existing revision is kept", "namespace", strategy.Namespace, "name", strategy.Name, "revision", revisionName)
+		}
+
+		if strategy.Status.Revision == revisionName {
+			return nil
+		}
+
+		// record a revision that was created before the strategy recorded its revisions
+		if digest, err = resources.StrategySnapshotDigest(&revision.Spec.Snapshot); err != nil {
+			return err
+		}
+		return r.recordRevision(ctx, strategy, revisionName, digest)
+
+	case !apierrors.IsNotFound(err):
+		return err
+
+	case strategy.Status.Revision == revisionName && strategy.Status.RevisionDigest != digest:
+		ctxlog.Info(ctx, "the revision of the version was deleted, and the BuildStrategy changed since, the revision is not created again", "namespace", strategy.Namespace, "name", strategy.Name, "revision", revisionName)
+		return nil
+	}
+
+	revision = &buildv1beta1.BuildStrategyRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   strategy.Namespace,
+			Name:        revisionName,
+			Labels:      strategy.GetResourceLabels(),
+			Annotations: map[string]string{},
+		},
+		Spec: buildv1beta1.BuildStrategyRevisionSpec{
+			Strategy:   strategy.Name,
+			Version:    strategy.Spec.Version,
+			Generation: strategy.Generation,
+			Snapshot:   *spec,
+		},
+	}
+	revision.Labels[buildv1beta1.LabelBuildStrategyVersion] = strategy.Spec.Version
+
+	// the annotations of the strategy are propagated to the TaskRuns of BuildRuns
+	for key, value := range strategy.Annotations {
+		if key != corev1.LastAppliedConfigAnnotation {
+			revision.Annotations[key] = value
+		}
+	}
+
+	if err := controllerutil.SetControllerReference(strategy, revision, r.scheme); err != nil {
+		return err
+	}
+
+	if err := r.client.Create(ctx, revision); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	ctxlog.Info(ctx, "created revision of BuildStrategy", "namespace", strategy.Namespace, "name", strategy.Name, "revision", revisionName)
+	return r.recordRevision(ctx, strategy, revisionName, digest)
+}
+
+// recordRevision records the revision of the version in the status of the strategy
+func (r *ReconcileBuildStrategy) recordRevision(ctx context.Context, strategy *buildv1beta1.BuildStrategy, revisionName string, digest string) error {
+	strategy.Status.Revision = revisionName
+	strategy.Status.RevisionDigest = digest
+	return r.client.Status().Update(ctx, strategy)
 }
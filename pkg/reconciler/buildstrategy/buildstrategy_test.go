@@ -10,28 +10,85 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
 	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 	"github.com/shipwright-io/build/pkg/reconciler/buildstrategy"
 )
 
 var _ = Describe("Reconcile BuildStrategy", func() {
 	var (
 		manager                      *fakes.FakeManager
+		client                       *fakes.FakeClient
+		statusWriter                 *fakes.FakeStatusWriter
 		reconciler                   reconcile.Reconciler
 		request                      reconcile.Request
 		namespace, buildStrategyName string
+		strategy                     *buildv1beta1.BuildStrategy
+		revision                     *buildv1beta1.BuildStrategyRevision
+		fragment                     *buildv1beta1.ClusterStrategyFragment
 	)
 
 	BeforeEach(func() {
 		buildStrategyName = "buildah"
 		namespace = "build-examples"
 
+		strategy = &buildv1beta1.BuildStrategy{
+			ObjectMeta: metav1.ObjectMeta{Name: buildStrategyName, Namespace: namespace, Generation: 1},
+			Spec: buildv1beta1.BuildStrategySpec{
+				Parameters: []buildv1beta1.Parameter{{Name: "storage-driver"}},
+				Steps: []buildv1beta1.Step{{
+					Name:    "build",
+					Image:   "quay.io/containers/buildah",
+					Command: []string{"buildah", "--storage-driver=$(params.storage-driver)"},
+				}},
+			},
+		}
+
 		// Fake the manager and get a reconcile Request
 		manager = &fakes.FakeManager{}
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			if strategy == nil {
+				return errors.NewNotFound(schema.GroupResource{}, buildStrategyName)
+			}
+
+			switch object := object.(type) {
+			case *buildv1beta1.BuildStrategy:
+				strategy.DeepCopyInto(object)
+			case *buildv1beta1.BuildStrategyRevision:
+				if revision == nil {
+					return errors.NewNotFound(schema.GroupResource{}, "revision")
+				}
+				revision.DeepCopyInto(object)
+			case *buildv1beta1.ClusterStrategyFragment:
+				if fragment == nil {
+					return errors.NewNotFound(schema.GroupResource{}, "fragment")
+				}
+				fragment.DeepCopyInto(object)
+			}
+			return nil
+		})
+		statusWriter = &fakes.FakeStatusWriter{}
+		client.StatusCalls(func() crc.StatusWriter { return statusWriter })
+		manager.GetClientReturns(client)
+
+		scheme := runtime.NewScheme()
+		Expect(buildv1beta1.AddToScheme(scheme)).To(Succeed())
+		manager.GetSchemeReturns(scheme)
+		revision = nil
+		fragment = nil
+
 		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: buildStrategyName, Namespace: namespace}}
 	})
 
@@ -47,6 +104,221 @@ var _ = Describe("Reconcile BuildStrategy", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcile.Result{}).To(Equal(result))
 			})
+
+			It("sets the Ready condition to true for a valid strategy", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				updated := object.(*buildv1beta1.BuildStrategy)
+				Expect(updated.Status.ObservedGeneration).To(Equal(int64(1)))
+
+				condition := updated.Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(buildv1beta1.BuildStrategyValid))
+			})
+
+			It("sets the Ready condition to false for a strategy that references an undefined parameter", func() {
+				strategy.Spec.Steps[0].Args = []string{"$(params.undefined)"}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildv1beta1.BuildStrategy).Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(buildv1beta1.BuildStrategyParameterReferenceNotValid))
+				Expect(condition.Message).To(ContainSubstring(`"undefined"`))
+			})
+
+			It("does not update the status when it is up to date", func() {
+				strategy.Status.ObservedGeneration = 1
+				strategy.Status.Conditions = buildv1beta1.Conditions{{
+					Type:    buildv1beta1.Ready,
+					Status:  corev1.ConditionTrue,
+					Reason:  buildv1beta1.BuildStrategyValid,
+					Message: "all validations succeeded",
+				}}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the BuildStrategy has a version", func() {
+			BeforeEach(func() {
+				strategy.Spec.Version = "1.0.0"
+			})
+
+			It("creates a revision of the version", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+
+				_, object, _ := client.CreateArgsForCall(0)
+				created, ok := object.(*buildv1beta1.BuildStrategyRevision)
+				Expect(ok).To(BeTrue())
+				Expect(created.Name).To(Equal(buildStrategyName + "-1.0.0"))
+				Expect(created.Labels).To(HaveKeyWithValue(buildv1beta1.LabelBuildStrategyVersion, "1.0.0"))
+				Expect(created.OwnerReferences).To(HaveLen(1))
+				Expect(created.Spec.Strategy).To(Equal(buildStrategyName))
+				Expect(created.Spec.Generation).To(Equal(int64(1)))
+				Expect(created.Spec.Snapshot).To(Equal(strategy.Spec))
+			})
+
+			It("keeps an existing revision of the version", func() {
+				revision = &buildv1beta1.BuildStrategyRevision{
+					Spec: buildv1beta1.BuildStrategyRevisionSpec{Strategy: buildStrategyName, Version: "1.0.0", Generation: 1},
+				}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
+			It("records the created revision in the status", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(statusWriter.UpdateCallCount()).To(Equal(2))
+
+				digest, err := resources.StrategySnapshotDigest(&strategy.Spec)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, object, _ := statusWriter.UpdateArgsForCall(1)
+				updated := object.(*buildv1beta1.BuildStrategy)
+				Expect(updated.Status.Revision).To(Equal(buildStrategyName + "-1.0.0"))
+				Expect(updated.Status.RevisionDigest).To(Equal(digest))
+			})
+
+			It("does not use the revision of another strategy with the same name", func() {
+				revision = &buildv1beta1.BuildStrategyRevision{
+					Spec: buildv1beta1.BuildStrategyRevisionSpec{Strategy: buildStrategyName + "-1", Version: "0.0", Generation: 1},
+				}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+				Expect(statusWriter.UpdateCallCount()).To(Equal(1))
+			})
+
+			It("does not create a deleted revision again after the strategy changed", func() {
+				strategy.Status.Revision = buildStrategyName + "-1.0.0"
+				strategy.Status.RevisionDigest = "sha256:0123456789abcdef"
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+			})
+
+			It("creates a deleted revision again when the strategy did not change", func() {
+				digest, err := resources.StrategySnapshotDigest(&strategy.Spec)
+				Expect(err).ToNot(HaveOccurred())
+				strategy.Status.Revision = buildStrategyName + "-1.0.0"
+				strategy.Status.RevisionDigest = digest
+
+				_, err = reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+			})
+
+			It("does not create a revision for a version that is not valid", func() {
+				strategy.Spec.Version = "1.0.0_beta"
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(0))
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildv1beta1.BuildStrategy).Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition.Reason).To(Equal(buildv1beta1.BuildStrategyVersionNotValid))
+			})
+		})
+
+		Context("when the BuildStrategy references a fragment", func() {
+			BeforeEach(func() {
+				strategy.Spec.Fragments = []buildv1beta1.StrategyFragmentReference{{Name: "ca-bundle"}}
+				fragment = &buildv1beta1.ClusterStrategyFragment{
+					ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle"},
+					Spec: buildv1beta1.StrategyFragmentSpec{
+						Steps: []buildv1beta1.Step{{Name: "inject-ca-bundle", Image: "ubi-minimal"}},
+					},
+				}
+			})
+
+			It("sets the Ready condition to true for a valid strategy", func() {
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildv1beta1.BuildStrategy).Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+			})
+
+			It("validates the strategy with the fragment merged into it", func() {
+				fragment.Spec.Steps[0].Args = []string{"$(params.ca-bundle-configmap)"}
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildv1beta1.BuildStrategy).Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition.Reason).To(Equal(buildv1beta1.BuildStrategyParameterReferenceNotValid))
+			})
+
+			It("sets the Ready condition to false for a fragment that does not exist", func() {
+				fragment = nil
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildv1beta1.BuildStrategy).Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(buildv1beta1.BuildStrategyFragmentNotFound))
+			})
+
+			It("sets the Ready condition to false for a fragment that conflicts with the strategy", func() {
+				fragment.Spec.Steps[0].Name = "build"
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, object, _ := statusWriter.UpdateArgsForCall(0)
+				condition := object.(*buildv1beta1.BuildStrategy).Status.GetCondition(buildv1beta1.Ready)
+				Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(buildv1beta1.BuildStrategyFragmentConflict))
+				Expect(condition.Message).To(ContainSubstring(`"ca-bundle"`))
+			})
+
+			It("creates a revision with the fragment merged into it", func() {
+				strategy.Spec.Version = "1.0.0"
+
+				_, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.CreateCallCount()).To(Equal(1))
+
+				_, object, _ := client.CreateArgsForCall(0)
+				created := object.(*buildv1beta1.BuildStrategyRevision)
+				Expect(created.Spec.Snapshot.Fragments).To(BeNil())
+				Expect(created.Spec.Snapshot.Steps).To(HaveLen(2))
+				Expect(created.Spec.Snapshot.Steps[0].Name).To(Equal("inject-ca-bundle"))
+			})
+		})
+
+		Context("when the BuildStrategy was deleted", func() {
+			It("succeeds without updating a status", func() {
+				strategy = nil
+
+				result, err := reconciler.Reconcile(context.TODO(), request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reconcile.Result{}).To(Equal(result))
+				Expect(statusWriter.UpdateCallCount()).To(Equal(0))
+			})
 		})
 	})
 })
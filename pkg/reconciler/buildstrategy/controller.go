@@ -6,7 +6,10 @@ package buildstrategy
 
 import (
 	"context"
+	"slices"
 
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -15,6 +18,7 @@ import (
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
 )
 
 // Add creates a new BuildStrategy Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -40,5 +44,42 @@ func add(mgr manager.Manager, r reconcile.Reconciler, maxConcurrentReconciles in
 	}
 
 	// Watch for changes to primary resource BuildStrategy
-	return c.Watch(source.Kind(mgr.GetCache(), &buildv1beta1.BuildStrategy{}, &handler.TypedEnqueueRequestForObject[*buildv1beta1.BuildStrategy]{}))
+	if err = c.Watch(source.Kind(mgr.GetCache(), &buildv1beta1.BuildStrategy{}, &handler.TypedEnqueueRequestForObject[*buildv1beta1.BuildStrategy]{})); err != nil {
+		return err
+	}
+
+	// Watch for revisions of the BuildStrategy, so that deleted revisions of the current version are created again
+	if err = c.Watch(source.Kind(mgr.GetCache(), &buildv1beta1.BuildStrategyRevision{}, handler.TypedEnqueueRequestForOwner[*buildv1beta1.BuildStrategyRevision](mgr.GetScheme(), mgr.GetRESTMapper(), &buildv1beta1.BuildStrategy{}, handler.OnlyControllerOwner()))); err != nil {
+		return err
+	}
+
+	// Watch for changes to the fragments, so that the strategies that reference them are validated again
+	return c.Watch(source.Kind(mgr.GetCache(), &buildv1beta1.ClusterStrategyFragment{}, handler.TypedEnqueueRequestsFromMapFunc(func(ctx context.Context, fragment *buildv1beta1.ClusterStrategyFragment) []reconcile.Request {
+		return strategiesReferencingFragment(ctx, mgr.GetClient(), fragment.Name)
+	})))
+}
+
+// strategiesReferencingFragment returns a request for every BuildStrategy in all namespaces that references the fragment
+func strategiesReferencingFragment(ctx context.Context, c client.Client, fragmentName string) []reconcile.Request {
+	strategyList := &buildv1beta1.BuildStrategyList{}
+	if err := c.List(ctx, strategyList); err != nil {
+		ctxlog.Info(ctx, "unexpected error happened while listing BuildStrategies", "error", err)
+		return []reconcile.Request{}
+	}
+
+	reconcileList := []reconcile.Request{}
+	for _, strategy := range strategyList.Items {
+		if !slices.ContainsFunc(strategy.Spec.Fragments, func(reference buildv1beta1.StrategyFragmentReference) bool { return reference.Name == fragmentName }) {
+			continue
+		}
+
+		reconcileList = append(reconcileList, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: strategy.Namespace,
+				Name:      strategy.Name,
+			},
+		})
+	}
+
+	return reconcileList
 }
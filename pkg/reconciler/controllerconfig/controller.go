@@ -0,0 +1,61 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerconfig
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+)
+
+// Add creates a new controller-config Controller and adds it to the Manager. The Controller watches the
+// configuration ConfigMap in the namespace of the controller. It is not added if no namespace is configured.
+func Add(ctx context.Context, c *config.Config, mgr manager.Manager) error {
+	if c.ConfigMapNamespace == "" {
+		ctxlog.Info(ctx, "Reloading of the configuration is disabled because no namespace is configured for the ConfigMap")
+		return nil
+	}
+
+	// a dedicated cache makes sure that only the configuration ConfigMap is watched, and not
+	// all ConfigMaps of the cluster
+	configMapCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme: mgr.GetScheme(),
+		Mapper: mgr.GetRESTMapper(),
+		DefaultNamespaces: map[string]cache.Config{
+			c.ConfigMapNamespace: {},
+		},
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Field: fields.OneTermEqualSelector("metadata.name", config.ConfigMapName),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := mgr.Add(configMapCache); err != nil {
+		return err
+	}
+
+	ctrl, err := controller.New("controller-config-controller", mgr, controller.Options{
+		Reconciler: NewReconciler(c, configMapCache),
+	})
+	if err != nil {
+		return err
+	}
+
+	return ctrl.Watch(source.Kind(configMapCache, &corev1.ConfigMap{}, &handler.TypedEnqueueRequestForObject[*corev1.ConfigMap]{}))
+}
@@ -0,0 +1,74 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerconfig
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/ctxlog"
+	buildmetrics "github.com/shipwright-io/build/pkg/metrics"
+)
+
+const (
+	namespace string = "namespace"
+	name      string = "name"
+)
+
+// ReconcileConfig applies the configuration ConfigMap to the controller configuration
+type ReconcileConfig struct {
+	config *config.Config
+	client client.Reader
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(c *config.Config, reader client.Reader) reconcile.Reconciler {
+	return &ReconcileConfig{
+		config: c,
+		client: reader,
+	}
+}
+
+// Reconcile reads the configuration ConfigMap and activates the configuration that it defines. An
+// invalid configuration is logged and ignored, so that the previous configuration stays active until
+// the ConfigMap is fixed. A deleted ConfigMap restores the configuration from the environment.
+func (r *ReconcileConfig) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if request.Name != config.ConfigMapName || request.Namespace != r.config.ConfigMapNamespace {
+		return reconcile.Result{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.config.Active().CtxTimeOut)
+	defer cancel()
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, request.NamespacedName, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+
+		// nothing to restore if the ConfigMap was never applied
+		if r.config.Generation() == 0 {
+			return reconcile.Result{}, nil
+		}
+
+		configMap.Data = nil
+	}
+
+	active, err := r.config.Apply(configMap.Data)
+	if err != nil {
+		ctxlog.Error(ctx, err, "ignoring invalid configuration, the previous configuration remains active", namespace, request.Namespace, name, request.Name, "generation", r.config.Generation())
+		return reconcile.Result{}, nil
+	}
+
+	buildmetrics.UpdateConfig(active)
+	ctxlog.Info(ctx, "activated configuration", namespace, request.Namespace, name, request.Name, "generation", active.Generation())
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,102 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllerconfig_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	crc "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/shipwright-io/build/pkg/config"
+	"github.com/shipwright-io/build/pkg/controller/fakes"
+	"github.com/shipwright-io/build/pkg/reconciler/controllerconfig"
+)
+
+var _ = Describe("Reconcile controller configuration", func() {
+	var (
+		client     *fakes.FakeClient
+		cfg        *config.Config
+		reconciler reconcile.Reconciler
+		request    reconcile.Request
+		configMap  *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		cfg = config.NewDefaultConfig()
+		cfg.ConfigMapNamespace = "shipwright-build"
+
+		configMap = &corev1.ConfigMap{
+			Data: map[string]string{
+				"vulnerabilityCountLimit": "5",
+			},
+		}
+
+		client = &fakes.FakeClient{}
+		client.GetCalls(func(_ context.Context, _ types.NamespacedName, object crc.Object, _ ...crc.GetOption) error {
+			if configMap == nil {
+				return errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, config.ConfigMapName)
+			}
+
+			configMap.DeepCopyInto(object.(*corev1.ConfigMap))
+			return nil
+		})
+
+		request = reconcile.Request{NamespacedName: types.NamespacedName{Name: config.ConfigMapName, Namespace: "shipwright-build"}}
+		reconciler = controllerconfig.NewReconciler(cfg, client)
+	})
+
+	It("activates the configuration of the ConfigMap", func() {
+		result, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(reconcile.Result{}))
+
+		Expect(cfg.Generation()).To(Equal(int64(1)))
+		Expect(cfg.Active().VulnerabilityCountLimit).To(Equal(5))
+	})
+
+	It("keeps the active configuration if the ConfigMap is invalid", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap.Data["vulnerabilityCountLimit"] = "five"
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cfg.Generation()).To(Equal(int64(1)))
+		Expect(cfg.Active().VulnerabilityCountLimit).To(Equal(5))
+	})
+
+	It("restores the configuration from the environment if the ConfigMap is deleted", func() {
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap = nil
+
+		_, err = reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cfg.Generation()).To(Equal(int64(2)))
+		Expect(cfg.Active().VulnerabilityCountLimit).To(Equal(cfg.VulnerabilityCountLimit))
+	})
+
+	It("ignores other ConfigMaps", func() {
+		request.Name = "other"
+
+		_, err := reconciler.Reconcile(context.TODO(), request)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(client.GetCallCount()).To(BeZero())
+		Expect(cfg.Generation()).To(BeZero())
+	})
+})
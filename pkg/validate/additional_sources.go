@@ -0,0 +1,104 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// reservedSourceName is the name under which the results of `spec.source` are reported
+const reservedSourceName = "default"
+
+// AdditionalSourcesRef contains all required fields
+// to validate the additional sources of a Build
+type AdditionalSourcesRef struct {
+	Build *build.Build // build instance for analysis
+}
+
+func NewAdditionalSources(build *build.Build) *AdditionalSourcesRef {
+	return &AdditionalSourcesRef{build}
+}
+
+// ValidatePath implements BuildPath interface and validates
+// the `build.spec.additionalSources` entries
+func (a *AdditionalSourcesRef) ValidatePath(_ context.Context) error {
+	ok, reason, msg := BuildAdditionalSources(a.Build.Spec.AdditionalSources)
+	if !ok {
+		a.Build.Status.Reason = ptr.To(build.BuildReason(reason))
+		a.Build.Status.Message = ptr.To(msg)
+	}
+	return nil
+}
+
+// BuildAdditionalSources is used to validate the additional sources of a Build
+func BuildAdditionalSources(additionalSources []build.AdditionalSource) (bool, string, string) {
+	names := map[string]struct{}{}
+	targetDirs := map[string]struct{}{}
+
+	for _, source := range additionalSources {
+		if errs := validation.IsDNS1123Label(source.Name); len(errs) > 0 {
+			return false, string(build.AdditionalSourceNotValid), fmt.Sprintf("additional source name %q is not valid: %s", source.Name, strings.Join(errs, ", "))
+		}
+
+		if source.Name == reservedSourceName {
+			return false, string(build.AdditionalSourceNotValid), fmt.Sprintf("additional source name %q is reserved", reservedSourceName)
+		}
+
+		if _, exists := names[source.Name]; exists {
+			return false, string(build.AdditionalSourceNotValid), fmt.Sprintf("additional source name %q is used more than once", source.Name)
+		}
+		names[source.Name] = struct{}{}
+
+		if msg := additionalSourceTypeMessage(source); msg != "" {
+			return false, string(build.AdditionalSourceNotValid), fmt.Sprintf("additional source %q: %s", source.Name, msg)
+		}
+
+		targetDir := source.GetTargetDir()
+		if path.IsAbs(targetDir) || path.Clean(targetDir) == "." || path.Clean(targetDir) == ".." || strings.HasPrefix(path.Clean(targetDir), "../") {
+			return false, string(build.AdditionalSourceNotValid), fmt.Sprintf("additional source %q: target directory %q must be a subdirectory of the source directory", source.Name, targetDir)
+		}
+
+		if _, exists := targetDirs[path.Clean(targetDir)]; exists {
+			return false, string(build.AdditionalSourceNotValid), fmt.Sprintf("additional source %q: target directory %q is used more than once", source.Name, targetDir)
+		}
+		targetDirs[path.Clean(targetDir)] = struct{}{}
+	}
+
+	return true, "", ""
+}
+
+// additionalSourceTypeMessage returns the reason why the type of the additional
+// source does not match its definition, or an empty string if it does
+func additionalSourceTypeMessage(source build.AdditionalSource) string {
+	switch source.Type {
+	case build.GitType:
+		if source.Git == nil || source.OCIArtifact != nil || source.Archive != nil {
+			return "type does not match the source"
+		}
+	case build.OCIArtifactType:
+		if source.OCIArtifact == nil || source.Git != nil || source.Archive != nil {
+			return "type does not match the source"
+		}
+	case build.ArchiveType:
+		if source.Archive == nil || source.Git != nil || source.OCIArtifact != nil {
+			return "type does not match the source"
+		}
+		return archiveValidationMessage(source.Archive)
+	case "":
+		return "type definition is missing"
+	default:
+		return fmt.Sprintf("type %q is not supported, supported types are Git, OCI, and Archive", source.Type)
+	}
+
+	return ""
+}
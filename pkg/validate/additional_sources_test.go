@@ -0,0 +1,136 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	. "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("ValidateAdditionalSources", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+	})
+
+	var validate = func(build *Build) {
+		GinkgoHelper()
+
+		var validator = validate.NewAdditionalSources(build)
+		Expect(validator.ValidatePath(ctx)).To(Succeed())
+	}
+
+	var gitSource = func(name string) AdditionalSource {
+		return AdditionalSource{
+			Name: name,
+			Type: GitType,
+			Git: &Git{
+				URL: "https://github.com/shipwright-io/sample-go",
+			},
+		}
+	}
+
+	var sampleBuild = func(additionalSources ...AdditionalSource) *Build {
+		return &Build{
+			ObjectMeta: corev1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+			Spec: BuildSpec{
+				AdditionalSources: additionalSources,
+			},
+		}
+	}
+
+	Context("when additional sources are specified", func() {
+		It("should pass valid sources", func() {
+			archive := AdditionalSource{
+				Name:      "assets",
+				Type:      ArchiveType,
+				TargetDir: ptr.To("static/assets"),
+				Archive: &Archive{
+					URL: "https://artifacts.example.com/assets.tar.gz",
+				},
+			}
+
+			build := sampleBuild(gitSource("config"), archive)
+			validate(build)
+			Expect(build.Status.Reason).To(BeNil())
+			Expect(build.Status.Message).To(BeNil())
+		})
+
+		It("should fail an invalid name", func() {
+			build := sampleBuild(gitSource("Config!"))
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("name \"Config!\" is not valid"))
+		})
+
+		It("should fail the reserved name of the source", func() {
+			build := sampleBuild(gitSource("default"))
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("is reserved"))
+		})
+
+		It("should fail duplicate names", func() {
+			build := sampleBuild(gitSource("config"), gitSource("config"))
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("used more than once"))
+		})
+
+		It("should fail a type that does not match the source", func() {
+			source := gitSource("config")
+			source.Type = OCIArtifactType
+
+			build := sampleBuild(source)
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("type does not match the source"))
+		})
+
+		It("should fail a Local source", func() {
+			build := sampleBuild(AdditionalSource{
+				Name: "local",
+				Type: LocalType,
+			})
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("is not supported"))
+		})
+
+		It("should fail a target directory outside of the source directory", func() {
+			source := gitSource("config")
+			source.TargetDir = ptr.To("../config")
+
+			build := sampleBuild(source)
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("must be a subdirectory"))
+		})
+
+		It("should fail sources that share a target directory", func() {
+			first := gitSource("first")
+			first.TargetDir = ptr.To("shared")
+			second := gitSource("second")
+			second.TargetDir = ptr.To("shared/")
+
+			build := sampleBuild(first, second)
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(AdditionalSourceNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("target directory \"shared/\" is used more than once"))
+		})
+	})
+})
@@ -0,0 +1,340 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// parameterReferenceRegEx matches the Tekton parameter references $(params.name), $(params['name']),
+// and $(params["name"]), optionally followed by [*], an index, or an object property
+var parameterReferenceRegEx = regexp.MustCompile(`\$\(params(?:\.([A-Za-z0-9_-]+)|\['([^']+)'\]|\["([^"]+)"\])(\[\*\]|\[[0-9]+\]|\.[A-Za-z0-9_-]+)?\)`)
+
+// reservedStepNames are the names of the steps that Shipwright adds to the strategy steps
+var reservedStepNames = []string{"image-processing"}
+
+// reservedStepNamePrefixes are the prefixes of the names of the steps that Shipwright adds to the strategy steps
+var reservedStepNamePrefixes = []string{"source-"}
+
+// BuildStrategyStatus validates the spec of a BuildStrategy or ClusterBuildStrategy, and
+// records the result as Ready condition in the status (mutates). It returns whether the
+// status changed.
+func BuildStrategyStatus(status *build.BuildStrategyStatus, spec *build.BuildStrategySpec, generation int64) bool {
+	condition := build.Condition{
+		Type:    build.Ready,
+		Status:  corev1.ConditionTrue,
+		Reason:  build.BuildStrategyValid,
+		Message: "all validations succeeded",
+	}
+
+	if ok, reason, message := BuildStrategySpec(spec); !ok {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = message
+	}
+
+	return setBuildStrategyReadyCondition(status, condition, generation)
+}
+
+// BuildStrategyNotReadyStatus records a Ready condition with status False in the status of a
+// BuildStrategy or ClusterBuildStrategy whose spec cannot be validated (mutates), for example
+// because its fragments cannot be resolved. It returns whether the status changed.
+func BuildStrategyNotReadyStatus(status *build.BuildStrategyStatus, generation int64, reason string, message string) bool {
+	return setBuildStrategyReadyCondition(status, build.Condition{
+		Type:    build.Ready,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}, generation)
+}
+
+// setBuildStrategyReadyCondition sets the Ready condition unless the status already has it for the generation,
+// and returns whether the status changed
+func setBuildStrategyReadyCondition(status *build.BuildStrategyStatus, condition build.Condition, generation int64) bool {
+	existing := status.GetCondition(build.Ready)
+	if existing != nil && status.ObservedGeneration == generation &&
+		existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return false
+	}
+
+	condition.LastTransitionTime = metav1.Now()
+	if existing != nil && existing.Status == condition.Status {
+		condition.LastTransitionTime = existing.LastTransitionTime
+	}
+
+	status.ObservedGeneration = generation
+	status.SetCondition(&condition)
+	return true
+}
+
+// BuildStrategySpec validates the version, parameters, steps, and volume mounts of a build strategy, it
+// returns whether the strategy is valid, and if not, the reason and a message
+func BuildStrategySpec(spec *build.BuildStrategySpec) (bool, string, string) {
+	if spec.Version != "" {
+		if errs := validation.IsDNS1123Subdomain(spec.Version); len(errs) > 0 {
+			return false, build.BuildStrategyVersionNotValid, fmt.Sprintf("version %q cannot be used in the name of a revision: %s", spec.Version, strings.Join(errs, ", "))
+		}
+	}
+
+	parameters := map[string]build.Parameter{}
+	for _, parameter := range spec.Parameters {
+		if _, exists := parameters[parameter.Name]; exists {
+			return false, build.BuildStrategyParameterNotValid, fmt.Sprintf("parameter %q is defined more than once", parameter.Name)
+		}
+
+		if resources.IsSystemReservedParameter(parameter.Name) {
+			return false, build.BuildStrategyParameterNotValid, fmt.Sprintf("parameter %q uses a name that is reserved for system parameters", parameter.Name)
+		}
+
+		if msg := parameterDefinitionMessage(parameter); msg != "" {
+			return false, build.BuildStrategyParameterNotValid, msg
+		}
+
+		parameters[parameter.Name] = parameter
+	}
+
+	volumes := map[string]struct{}{}
+	for _, volume := range spec.Volumes {
+		volumes[volume.Name] = struct{}{}
+	}
+
+	stepNames := map[string]struct{}{}
+	for _, step := range spec.Steps {
+		if _, exists := stepNames[step.Name]; exists {
+			return false, build.BuildStrategyStepNameNotValid, fmt.Sprintf("step name %q is used more than once", step.Name)
+		}
+		stepNames[step.Name] = struct{}{}
+
+		if isReservedStepName(step.Name) {
+			return false, build.BuildStrategyStepNameNotValid, fmt.Sprintf("step name %q collides with the steps that Shipwright adds, names starting with %s and the names %s are reserved",
+				step.Name, strings.Join(reservedStepNamePrefixes, ", "), strings.Join(reservedStepNames, ", "))
+		}
+
+		if msg := stepParameterReferencesMessage(step, parameters); msg != "" {
+			return false, build.BuildStrategyParameterReferenceNotValid, msg
+		}
+
+		if msg := stepConditionMessage(step, parameters); msg != "" {
+			return false, build.BuildStrategyStepConditionNotValid, msg
+		}
+
+		for _, volumeMount := range step.VolumeMounts {
+			if _, exists := volumes[volumeMount.Name]; !exists {
+				return false, build.BuildStrategyVolumeMountNotValid, fmt.Sprintf("step %q mounts volume %q that is not defined in the strategy volumes", step.Name, volumeMount.Name)
+			}
+		}
+	}
+
+	return true, "", ""
+}
+
+// parameterDefinitionMessage returns why the constraints or defaults of a parameter
+// are not valid, or an empty string if they are valid
+func parameterDefinitionMessage(parameter build.Parameter) string {
+	if parameter.Pattern != nil {
+		if _, err := regexp.Compile(*parameter.Pattern); err != nil {
+			return fmt.Sprintf("parameter %q has a pattern that is not a valid regular expression: %v", parameter.Name, err)
+		}
+	}
+
+	if (parameter.Minimum != nil || parameter.Maximum != nil) && parameter.Type != build.ParameterTypeInteger {
+		return fmt.Sprintf("parameter %q defines a minimum or maximum, but is not of type integer", parameter.Name)
+	}
+
+	if parameter.Minimum != nil && parameter.Maximum != nil && *parameter.Minimum > *parameter.Maximum {
+		return fmt.Sprintf("parameter %q has a minimum that is greater than its maximum", parameter.Name)
+	}
+
+	if (parameter.Properties != nil || parameter.DefaultProperties != nil) && parameter.Type != build.ParameterTypeObject {
+		return fmt.Sprintf("parameter %q defines properties, but is not of type object", parameter.Name)
+	}
+
+	for _, property := range parameter.Properties {
+		if !objectPropertyRegEx.MatchString(property) {
+			return fmt.Sprintf("parameter %q has a property %q with a name that is not valid", parameter.Name, property)
+		}
+
+		if parameter.DefaultProperties != nil {
+			if _, exists := (*parameter.DefaultProperties)[property]; !exists {
+				return fmt.Sprintf("parameter %q has default properties without its property %q", parameter.Name, property)
+			}
+		}
+	}
+
+	// the enum values must satisfy the other constraints, without being checked against the enum itself
+	withoutEnum := parameter
+	withoutEnum.Enum = nil
+	for _, value := range parameter.Enum {
+		if violatedConstraint(withoutEnum, value) != "" {
+			return fmt.Sprintf("parameter %q has an enum value %q that is not valid for the parameter", parameter.Name, value)
+		}
+	}
+
+	var defaults []string
+	switch {
+	case parameter.Type == build.ParameterTypeArray && parameter.Defaults != nil:
+		defaults = *parameter.Defaults
+	case parameter.Type == build.ParameterTypeObject && parameter.DefaultProperties != nil:
+		for _, property := range slices.Sorted(maps.Keys(*parameter.DefaultProperties)) {
+			if !objectPropertyRegEx.MatchString(property) {
+				return fmt.Sprintf("parameter %q has a default property %q with a name that is not valid", parameter.Name, property)
+			}
+			defaults = append(defaults, (*parameter.DefaultProperties)[property])
+		}
+	case parameter.Type != build.ParameterTypeArray && parameter.Type != build.ParameterTypeObject && parameter.Default != nil:
+		defaults = []string{*parameter.Default}
+	}
+
+	for _, value := range defaults {
+		if violatedConstraint(parameter, value) != "" {
+			return fmt.Sprintf("parameter %q has a default value %q that is not valid for the parameter", parameter.Name, value)
+		}
+	}
+
+	return ""
+}
+
+func isReservedStepName(stepName string) bool {
+	for _, reserved := range reservedStepNames {
+		if stepName == reserved {
+			return true
+		}
+	}
+
+	for _, prefix := range reservedStepNamePrefixes {
+		if strings.HasPrefix(stepName, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stepConditionMessage returns why the when expressions of a step are not valid, or an
+// empty string if they are valid
+func stepConditionMessage(step build.Step, parameters map[string]build.Parameter) string {
+	for _, when := range step.When {
+		parameter, defined := parameters[when.Parameter]
+		if !defined {
+			return fmt.Sprintf("step %q has a condition on parameter %q that is not defined", step.Name, when.Parameter)
+		}
+
+		if parameter.Type == build.ParameterTypeArray || parameter.Type == build.ParameterTypeObject {
+			return fmt.Sprintf("step %q has a condition on %s parameter %q, only string, boolean, and integer parameters are supported", step.Name, parameter.Type, when.Parameter)
+		}
+
+		switch when.Operator {
+		case build.WhenOperatorEquals, build.WhenOperatorNotEquals:
+			if len(when.Values) != 1 {
+				return fmt.Sprintf("step %q has a condition with operator %q that does not have exactly one value", step.Name, when.Operator)
+			}
+
+		case build.WhenOperatorIn, build.WhenOperatorNotIn:
+			if len(when.Values) == 0 {
+				return fmt.Sprintf("step %q has a condition with operator %q that has no values", step.Name, when.Operator)
+			}
+
+		default:
+			return fmt.Sprintf("step %q has a condition with operator %q that is not supported", step.Name, when.Operator)
+		}
+	}
+
+	return ""
+}
+
+// stepParameterReferencesMessage returns why the parameter references of a step are not
+// valid, or an empty string if they are valid
+func stepParameterReferencesMessage(step build.Step, parameters map[string]build.Parameter) string {
+	// array parameters can only be used as complete command or argument
+	for _, value := range append(append([]string{}, step.Command...), step.Args...) {
+		if msg := parameterReferencesMessage(step.Name, value, parameters, true); msg != "" {
+			return msg
+		}
+	}
+
+	values := []string{step.Image, step.WorkingDir}
+	for _, env := range step.Env {
+		values = append(values, env.Value)
+	}
+
+	for _, value := range values {
+		if msg := parameterReferencesMessage(step.Name, value, parameters, false); msg != "" {
+			return msg
+		}
+	}
+
+	return ""
+}
+
+func parameterReferencesMessage(stepName string, value string, parameters map[string]build.Parameter, arrayAllowed bool) string {
+	for _, match := range parameterReferenceRegEx.FindAllStringSubmatch(value, -1) {
+		name := match[1] + match[2] + match[3]
+		suffix := match[4]
+
+		if resources.IsSystemReservedParameter(name) {
+			if suffix != "" {
+				return fmt.Sprintf("step %q references system parameter %q as array", stepName, name)
+			}
+			continue
+		}
+
+		parameter, defined := parameters[name]
+		if !defined {
+			return fmt.Sprintf("step %q references parameter %q that is not defined", stepName, name)
+		}
+
+		property, isPropertyReference := strings.CutPrefix(suffix, ".")
+
+		switch parameter.Type {
+		case build.ParameterTypeArray:
+			switch {
+			case suffix == "[*]":
+				if !arrayAllowed || match[0] != value {
+					return fmt.Sprintf("step %q references array parameter %q in a string, it can only be used as a complete command or argument", stepName, name)
+				}
+
+			case suffix == "":
+				return fmt.Sprintf("step %q references array parameter %q as string, use $(params.%s[*])", stepName, name, name)
+
+			case isPropertyReference:
+				return fmt.Sprintf("step %q references array parameter %q as object", stepName, name)
+			}
+
+		case build.ParameterTypeObject:
+			switch {
+			case suffix == "[*]":
+				if !arrayAllowed || match[0] != value {
+					return fmt.Sprintf("step %q references object parameter %q in a string, it can only be expanded as a complete command or argument", stepName, name)
+				}
+
+			case isPropertyReference:
+				if !slices.Contains(parameter.Properties, property) {
+					return fmt.Sprintf("step %q references property %q of object parameter %q that is not listed in its properties", stepName, property, name)
+				}
+
+			default:
+				return fmt.Sprintf("step %q references object parameter %q without a property, use $(params.%s.<property>) or $(params.%s[*])", stepName, name, name, name)
+			}
+
+		default:
+			if suffix != "" {
+				return fmt.Sprintf("step %q references string parameter %q as array or object", stepName, name)
+			}
+		}
+	}
+
+	return ""
+}
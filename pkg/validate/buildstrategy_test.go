@@ -0,0 +1,310 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	. "github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("BuildStrategySpec", func() {
+	var spec *build.BuildStrategySpec
+
+	BeforeEach(func() {
+		spec = &build.BuildStrategySpec{
+			Parameters: []build.Parameter{
+				{Name: "dockerfile"},
+				{Name: "build-args", Type: build.ParameterTypeArray},
+			},
+			Steps: []build.Step{{
+				Name:       "build",
+				Image:      "quay.io/containers/buildah",
+				WorkingDir: "$(params.shp-source-context)",
+				Command:    []string{"buildah", "bud", "--file=$(params.dockerfile)"},
+				Args:       []string{"--", "$(params['build-args'][*])"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "storage",
+					MountPath: "/var/lib/containers",
+				}},
+			}},
+			Volumes: []build.BuildStrategyVolume{{Name: "storage"}},
+		}
+	})
+
+	It("passes for a valid strategy", func() {
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeTrue())
+		Expect(reason).To(BeEmpty())
+		Expect(msg).To(BeEmpty())
+	})
+
+	It("fails when a parameter is defined twice", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{Name: "dockerfile"})
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+		Expect(msg).To(ContainSubstring("more than once"))
+	})
+
+	It("fails when a parameter uses the name of a system parameter", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{Name: "shp-output-image"})
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+	})
+
+	It("passes for typed parameters with valid constraints and defaults", func() {
+		spec.Parameters = append(spec.Parameters,
+			build.Parameter{Name: "verbose", Type: build.ParameterTypeBoolean, Default: ptr.To("false")},
+			build.Parameter{Name: "jobs", Type: build.ParameterTypeInteger, Default: ptr.To("2"), Minimum: ptr.To[int64](1), Maximum: ptr.To[int64](8)},
+			build.Parameter{Name: "level", Default: ptr.To("info"), Enum: []string{"info", "debug"}, Pattern: ptr.To("^[a-z]+$")},
+		)
+
+		valid, _, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeTrue(), msg)
+	})
+
+	It("fails when a parameter pattern is not a valid regular expression", func() {
+		spec.Parameters[0].Pattern = ptr.To("[a-z")
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+		Expect(msg).To(ContainSubstring("not a valid regular expression"))
+	})
+
+	It("fails when a non-integer parameter defines a range", func() {
+		spec.Parameters[0].Minimum = ptr.To[int64](1)
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+	})
+
+	It("fails when the minimum of a parameter is greater than its maximum", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{Name: "jobs", Type: build.ParameterTypeInteger, Minimum: ptr.To[int64](4), Maximum: ptr.To[int64](2)})
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+	})
+
+	It("fails when an enum value is not valid for the parameter type", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{Name: "verbose", Type: build.ParameterTypeBoolean, Enum: []string{"true", "maybe"}})
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+		Expect(msg).To(ContainSubstring(`"maybe"`))
+	})
+
+	It("fails when a default violates the constraints of the parameter", func() {
+		spec.Parameters[1].Defaults = &[]string{"--pull", "pull"}
+		spec.Parameters[1].Pattern = ptr.To("^--")
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+		Expect(msg).To(ContainSubstring(`default value "pull"`))
+	})
+
+	It("passes for object parameters that are referenced by property or expanded", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{
+			Name:              "labels",
+			Type:              build.ParameterTypeObject,
+			Properties:        []string{"team"},
+			DefaultProperties: &map[string]string{"team": "build"},
+		})
+		spec.Steps[0].Args = append(spec.Steps[0].Args, "$(params.labels[*])")
+		spec.Steps[0].Env = []corev1.EnvVar{{Name: "TEAM", Value: "$(params.labels.team)"}}
+
+		valid, _, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeTrue(), msg)
+	})
+
+	It("fails when a step references an object property that is not listed", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{Name: "labels", Type: build.ParameterTypeObject})
+		spec.Steps[0].Env = []corev1.EnvVar{{Name: "TEAM", Value: "$(params.labels.team)"}}
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+		Expect(msg).To(ContainSubstring(`property "team"`))
+	})
+
+	It("fails when an object parameter is referenced without a property", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{Name: "labels", Type: build.ParameterTypeObject})
+		spec.Steps[0].Env = []corev1.EnvVar{{Name: "LABELS", Value: "$(params.labels)"}}
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+	})
+
+	It("fails when the default properties miss a listed property", func() {
+		spec.Parameters = append(spec.Parameters, build.Parameter{
+			Name:              "labels",
+			Type:              build.ParameterTypeObject,
+			Properties:        []string{"team"},
+			DefaultProperties: &map[string]string{"owner": "build"},
+		})
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterNotValid))
+	})
+
+	It("passes for a step with a condition on a string parameter", func() {
+		spec.Steps[0].When = []build.WhenExpression{{Parameter: "dockerfile", Operator: build.WhenOperatorIn, Values: []string{"Dockerfile", "Containerfile"}}}
+
+		valid, _, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeTrue(), msg)
+	})
+
+	It("fails when a step has a condition on a parameter that is not defined", func() {
+		spec.Steps[0].When = []build.WhenExpression{{Parameter: "run-tests", Operator: build.WhenOperatorEquals, Values: []string{"true"}}}
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyStepConditionNotValid))
+		Expect(msg).To(ContainSubstring(`"run-tests"`))
+	})
+
+	It("fails when a step has a condition on an array parameter", func() {
+		spec.Steps[0].When = []build.WhenExpression{{Parameter: "build-args", Operator: build.WhenOperatorIn, Values: []string{"A=B"}}}
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyStepConditionNotValid))
+	})
+
+	It("fails when a step has an equals condition with more than one value", func() {
+		spec.Steps[0].When = []build.WhenExpression{{Parameter: "dockerfile", Operator: build.WhenOperatorEquals, Values: []string{"Dockerfile", "Containerfile"}}}
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyStepConditionNotValid))
+	})
+
+	It("fails when two steps have the same name", func() {
+		spec.Steps = append(spec.Steps, build.Step{Name: "build", Image: "busybox"})
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyStepNameNotValid))
+	})
+
+	It("fails when a step uses a name that is reserved", func() {
+		spec.Steps = append(spec.Steps, build.Step{Name: "source-default", Image: "busybox"})
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyStepNameNotValid))
+	})
+
+	It("fails when a step references a parameter that is not defined", func() {
+		spec.Steps[0].Env = []corev1.EnvVar{{Name: "TARGET", Value: "$(params.target)"}}
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+		Expect(msg).To(ContainSubstring(`"target"`))
+	})
+
+	It("fails when an array parameter is referenced as string", func() {
+		spec.Steps[0].Args = []string{"$(params.build-args)"}
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+	})
+
+	It("fails when an array parameter is used within a string", func() {
+		spec.Steps[0].Args = []string{"--build-arg=$(params.build-args[*])"}
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+	})
+
+	It("fails when an array parameter is used in the image", func() {
+		spec.Steps[0].Image = "$(params.build-args[*])"
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+	})
+
+	It("fails when a string parameter is referenced as array", func() {
+		spec.Steps[0].Args = []string{"$(params.dockerfile[*])"}
+
+		valid, reason, _ := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyParameterReferenceNotValid))
+	})
+
+	It("fails when a step mounts a volume that is not defined", func() {
+		spec.Volumes = nil
+
+		valid, reason, msg := BuildStrategySpec(spec)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(build.BuildStrategyVolumeMountNotValid))
+		Expect(msg).To(ContainSubstring(`"storage"`))
+	})
+
+	It("passes for all sample strategies", func() {
+		files, err := filepath.Glob(filepath.Join("..", "..", "samples", "v1beta1", "buildstrategy", "*", "*.yaml"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(files).ToNot(BeEmpty())
+
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, document := range strings.Split(string(content), "\n---\n") {
+				var strategy build.BuildStrategy
+				Expect(yaml.Unmarshal([]byte(document), &strategy)).To(Succeed(), file)
+				if strategy.Kind != "BuildStrategy" && strategy.Kind != "ClusterBuildStrategy" {
+					continue
+				}
+
+				valid, _, msg := BuildStrategySpec(&strategy.Spec)
+				Expect(valid).To(BeTrue(), "%s: %s", file, msg)
+			}
+		}
+	})
+})
+
+var _ = Describe("BuildStrategyStatus", func() {
+	It("records the Ready condition and the generation", func() {
+		status := &build.BuildStrategyStatus{}
+		spec := &build.BuildStrategySpec{Steps: []build.Step{{Name: "build", Image: "busybox"}}}
+
+		Expect(BuildStrategyStatus(status, spec, 2)).To(BeTrue())
+		Expect(status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(status.GetCondition(build.Ready).Status).To(Equal(corev1.ConditionTrue))
+
+		// nothing changes when the same generation is validated again
+		Expect(BuildStrategyStatus(status, spec, 2)).To(BeFalse())
+
+		spec.Steps = append(spec.Steps, build.Step{Name: "build", Image: "busybox"})
+		Expect(BuildStrategyStatus(status, spec, 3)).To(BeTrue())
+		Expect(status.GetCondition(build.Ready).Status).To(Equal(corev1.ConditionFalse))
+		Expect(status.GetCondition(build.Ready).Reason).To(Equal(build.BuildStrategyStepNameNotValid))
+	})
+})
@@ -0,0 +1,65 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+)
+
+// NotificationsRef contains all required fields
+// to validate the notifications of a Build
+type NotificationsRef struct {
+	Build *build.Build // build instance for analysis
+}
+
+func NewNotifications(build *build.Build) *NotificationsRef {
+	return &NotificationsRef{build}
+}
+
+// ValidatePath implements BuildPath interface and validates
+// the `build.spec.notifications` entries
+func (n *NotificationsRef) ValidatePath(_ context.Context) error {
+	ok, reason, msg := BuildNotifications(n.Build.Spec.Notifications)
+	if !ok {
+		n.Build.Status.Reason = ptr.To(build.BuildReason(reason))
+		n.Build.Status.Message = ptr.To(msg)
+	}
+	return nil
+}
+
+// BuildNotifications is used to validate the notifications of a Build
+func BuildNotifications(notifications []build.Notification) (bool, string, string) {
+	names := map[string]struct{}{}
+
+	for _, notification := range notifications {
+		if errs := validation.IsDNS1123Label(notification.Name); len(errs) > 0 {
+			return false, string(build.NotificationNotValid), fmt.Sprintf("notification name %q is not valid: %s", notification.Name, strings.Join(errs, ", "))
+		}
+
+		if _, exists := names[notification.Name]; exists {
+			return false, string(build.NotificationNotValid), fmt.Sprintf("notification name %q is used more than once", notification.Name)
+		}
+		names[notification.Name] = struct{}{}
+
+		endpoint, err := url.Parse(notification.URL)
+		if err != nil {
+			return false, string(build.NotificationNotValid), fmt.Sprintf("notification %q: url is not valid: %v", notification.Name, err)
+		}
+
+		if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return false, string(build.NotificationNotValid), fmt.Sprintf("notification %q: url %q must be an HTTP or HTTPS URL", notification.Name, notification.URL)
+		}
+	}
+
+	return true, "", ""
+}
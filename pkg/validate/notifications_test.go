@@ -0,0 +1,89 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("ValidateNotifications", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+	})
+
+	var validate = func(build *Build) {
+		GinkgoHelper()
+
+		var validator = validate.NewNotifications(build)
+		Expect(validator.ValidatePath(ctx)).To(Succeed())
+	}
+
+	var sampleBuild = func(notifications ...Notification) *Build {
+		return &Build{
+			ObjectMeta: corev1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "bar",
+			},
+			Spec: BuildSpec{
+				Notifications: notifications,
+			},
+		}
+	}
+
+	Context("when notifications are specified", func() {
+		It("should pass valid notifications", func() {
+			build := sampleBuild(
+				Notification{Name: "chat", URL: "https://chat.example.com/hooks/builds"},
+				Notification{Name: "deploy", URL: "http://deployer.deployer.svc:8080", Events: []NotificationEvent{NotificationEventSucceeded}},
+			)
+
+			validate(build)
+			Expect(build.Status.Reason).To(BeNil())
+		})
+
+		It("should fail when a name is used more than once", func() {
+			build := sampleBuild(
+				Notification{Name: "chat", URL: "https://chat.example.com/a"},
+				Notification{Name: "chat", URL: "https://chat.example.com/b"},
+			)
+
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(NotificationNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("used more than once"))
+		})
+
+		It("should fail when the name is not valid", func() {
+			build := sampleBuild(Notification{Name: "Chat_Bot", URL: "https://chat.example.com"})
+
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(NotificationNotValid))
+		})
+
+		It("should fail when the URL is not an HTTP URL", func() {
+			build := sampleBuild(Notification{Name: "chat", URL: "ftp://chat.example.com"})
+
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(NotificationNotValid))
+			Expect(*build.Status.Message).To(ContainSubstring("must be an HTTP or HTTPS URL"))
+		})
+
+		It("should fail when the URL has no host", func() {
+			build := sampleBuild(Notification{Name: "chat", URL: "https:///hooks"})
+
+			validate(build)
+			Expect(*build.Status.Reason).To(Equal(NotificationNotValid))
+		})
+	})
+})
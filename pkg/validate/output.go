@@ -52,5 +52,5 @@ func (b *BuildSpecOutputValidator) ValidatePath(_ context.Context) error {
 
 func (b *BuildSpecOutputValidator) isEmptySource() bool {
 	return b.Build.Spec.Source == nil ||
-		b.Build.Spec.Source.Git == nil && b.Build.Spec.Source.OCIArtifact == nil && b.Build.Spec.Source.Local == nil
+		b.Build.Spec.Source.Git == nil && b.Build.Spec.Source.OCIArtifact == nil && b.Build.Spec.Source.Local == nil && b.Build.Spec.Source.Archive == nil
 }
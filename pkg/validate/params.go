@@ -6,12 +6,19 @@ package validate
 
 import (
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 )
 
+// objectPropertyRegEx matches the property names that can be referenced in the strategy steps as $(params.name.property)
+var objectPropertyRegEx = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 // BuildParameters validates that the parameter values specified in Build are suitable for what is defined in the BuildStrategy
 func BuildParameters(parameterDefinitions []buildv1beta1.Parameter, buildParamValues []buildv1beta1.ParamValue) (bool, buildv1beta1.BuildReason, string) {
 	valid, reason, message := validateParameters(parameterDefinitions, buildParamValues, true)
@@ -69,6 +76,15 @@ func validateParameters(parameterDefinitions []buildv1beta1.Parameter, paramValu
 	// list of params that have incomplete Secret values
 	incompleteSecretValueParameters := []string{}
 
+	// list of object properties that have an invalid name or no value
+	invalidObjectPropertyParameters := []string{}
+
+	// list of object properties that the strategy requires, but that have no value
+	missingObjectPropertyParameters := []string{}
+
+	// list of params that violate a constraint of their definition, by reason
+	constraintViolations := map[string][]string{}
+
 	// second loop is through the strategy parameters to determine those with missing or incorrect values
 	for _, parameterDefinition := range parameterDefinitions {
 		paramValue := resources.FindParamValueByName(paramValues, parameterDefinition.Name)
@@ -76,10 +92,10 @@ func validateParameters(parameterDefinitions []buildv1beta1.Parameter, paramValu
 		switch parameterDefinition.Type {
 		case "": // string is default
 			fallthrough
-		case buildv1beta1.ParameterTypeString:
+		case buildv1beta1.ParameterTypeString, buildv1beta1.ParameterTypeBoolean, buildv1beta1.ParameterTypeInteger:
 			if paramValue != nil {
-				// check if a string value contains array values
-				if paramValue.Values != nil {
+				// check if a string value contains array values or properties
+				if paramValue.Values != nil || paramValue.Properties != nil {
 					wrongValueTypeParameters = append(wrongValueTypeParameters, parameterDefinition.Name)
 				}
 
@@ -98,19 +114,26 @@ func validateParameters(parameterDefinitions []buildv1beta1.Parameter, paramValu
 					if hasIncompleteSecretValue(*paramValue.SingleValue) {
 						incompleteSecretValueParameters = append(incompleteSecretValueParameters, parameterDefinition.Name)
 					}
+
+					// check if a plain value violates a constraint of the parameter
+					if paramValue.SingleValue.Value != nil {
+						if reason := violatedConstraint(parameterDefinition, *paramValue.SingleValue.Value); reason != "" {
+							constraintViolations[reason] = append(constraintViolations[reason], parameterDefinition.Name)
+						}
+					}
 				}
 			}
 
-			// check if a string parameter without default has no value
-			if parameterDefinition.Default == nil && (paramValue == nil || paramValue.SingleValue == nil || hasNoValue(*paramValue.SingleValue)) {
+			// check if a required parameter has no value
+			if parameterDefinition.IsRequired() && (paramValue == nil || paramValue.SingleValue == nil || hasNoValue(*paramValue.SingleValue)) {
 				missingParameters = append(missingParameters, parameterDefinition.Name)
 				continue
 			}
 
 		case buildv1beta1.ParameterTypeArray:
 			if paramValue != nil {
-				// check if an array value contains a single value
-				if paramValue.SingleValue != nil {
+				// check if an array value contains a single value or properties
+				if paramValue.SingleValue != nil || paramValue.Properties != nil {
 					wrongValueTypeParameters = append(wrongValueTypeParameters, parameterDefinition.Name)
 				}
 
@@ -143,12 +166,84 @@ func validateParameters(parameterDefinitions []buildv1beta1.Parameter, paramValu
 						incompleteSecretValueParameters = append(incompleteSecretValueParameters, parameterDefinition.Name)
 					}
 				}
+
+				// check whether any plain array item violates a constraint of the parameter
+				for _, arrayItemParamValue := range paramValue.Values {
+					if arrayItemParamValue.Value == nil {
+						continue
+					}
+
+					if reason := violatedConstraint(parameterDefinition, *arrayItemParamValue.Value); reason != "" {
+						constraintViolations[reason] = append(constraintViolations[reason], parameterDefinition.Name)
+						break
+					}
+				}
 			}
 
-			// check if an array parameter without defaults has no values
-			if parameterDefinition.Defaults == nil && (paramValue == nil || paramValue.Values == nil) {
+			// check if a required array parameter has no values
+			if parameterDefinition.IsRequired() && (paramValue == nil || paramValue.Values == nil) {
 				missingParameters = append(missingParameters, parameterDefinition.Name)
 			}
+
+		case buildv1beta1.ParameterTypeObject:
+			if paramValue != nil {
+				// check if an object value contains a single value or array values
+				if paramValue.SingleValue != nil || paramValue.Values != nil {
+					wrongValueTypeParameters = append(wrongValueTypeParameters, parameterDefinition.Name)
+				}
+
+				for _, property := range slices.Sorted(maps.Keys(paramValue.Properties)) {
+					propertyParamValue := paramValue.Properties[property]
+					name := fmt.Sprintf("%s.%s", parameterDefinition.Name, property)
+
+					// check whether the property name can be referenced, and whether the property has a value
+					if !objectPropertyRegEx.MatchString(property) || hasNoValue(propertyParamValue) {
+						invalidObjectPropertyParameters = append(invalidObjectPropertyParameters, name)
+						continue
+					}
+
+					if hasMoreThanOneValue(propertyParamValue) {
+						multiValueParams = append(multiValueParams, name)
+					}
+
+					if hasIncompleteConfigMapValue(propertyParamValue) {
+						incompleteConfigMapValueParameters = append(incompleteConfigMapValueParameters, name)
+					}
+
+					if hasIncompleteSecretValue(propertyParamValue) {
+						incompleteSecretValueParameters = append(incompleteSecretValueParameters, name)
+					}
+
+					if propertyParamValue.Value != nil {
+						if reason := violatedConstraint(parameterDefinition, *propertyParamValue.Value); reason != "" {
+							constraintViolations[reason] = append(constraintViolations[reason], name)
+						}
+					}
+				}
+			}
+
+			// check if a required object parameter has no properties
+			if parameterDefinition.IsRequired() && (paramValue == nil || paramValue.Properties == nil) {
+				missingParameters = append(missingParameters, parameterDefinition.Name)
+				continue
+			}
+
+			// check if a property that the strategy requires is neither in the value nor in the defaults
+			for _, property := range parameterDefinition.Properties {
+				if paramValue != nil && paramValue.Properties != nil {
+					if _, exists := paramValue.Properties[property]; exists {
+						continue
+					}
+				}
+
+				if parameterDefinition.DefaultProperties != nil {
+					if _, exists := (*parameterDefinition.DefaultProperties)[property]; exists {
+						continue
+					}
+				}
+
+				missingObjectPropertyParameters = append(missingObjectPropertyParameters, fmt.Sprintf("%s.%s", parameterDefinition.Name, property))
+			}
 		}
 	}
 
@@ -160,6 +255,14 @@ func validateParameters(parameterDefinitions []buildv1beta1.Parameter, paramValu
 		return false, resources.ConditionMissingParameterValues, fmt.Sprintf("The following parameters are required but no value has been provided: %s", strings.Join(missingParameters, ", "))
 	}
 
+	if !ignoreMissingParameters && len(missingObjectPropertyParameters) > 0 {
+		return false, resources.ConditionMissingObjectPropertyParameterValues, fmt.Sprintf("The following object parameter properties are required but no value has been provided: %s", strings.Join(missingObjectPropertyParameters, ", "))
+	}
+
+	if len(invalidObjectPropertyParameters) > 0 {
+		return false, resources.ConditionInvalidObjectPropertyParameterValues, fmt.Sprintf("The following object parameter properties have a name that is not valid, or none of 'configMapValue', 'secretValue', and 'value' set: %s", strings.Join(invalidObjectPropertyParameters, ", "))
+	}
+
 	if len(multiValueParams) > 0 {
 		return false, resources.ConditionInconsistentParameterValues, fmt.Sprintf("The following parameters have more than one of 'configMapValue', 'secretValue', and 'value' set: %s", strings.Join(multiValueParams, ", "))
 	}
@@ -176,9 +279,61 @@ func validateParameters(parameterDefinitions []buildv1beta1.Parameter, paramValu
 		return false, resources.ConditionIncompleteSecretValueParameterValues, fmt.Sprintf("The values for the following parameters are containing a 'secretValue' with an empty 'name' or 'key': %s", strings.Join(incompleteSecretValueParameters, ", "))
 	}
 
+	if names := constraintViolations[resources.ConditionMalformedParameterValues]; len(names) > 0 {
+		return false, resources.ConditionMalformedParameterValues, fmt.Sprintf("The values for the following parameters are not valid for the type of the parameter: %s", strings.Join(names, ", "))
+	}
+
+	if names := constraintViolations[resources.ConditionDisallowedParameterValues]; len(names) > 0 {
+		return false, resources.ConditionDisallowedParameterValues, fmt.Sprintf("The values for the following parameters are not one of the allowed values: %s", strings.Join(names, ", "))
+	}
+
+	if names := constraintViolations[resources.ConditionPatternMismatchParameterValues]; len(names) > 0 {
+		return false, resources.ConditionPatternMismatchParameterValues, fmt.Sprintf("The values for the following parameters do not match the pattern of the parameter: %s", strings.Join(names, ", "))
+	}
+
+	if names := constraintViolations[resources.ConditionOutOfRangeParameterValues]; len(names) > 0 {
+		return false, resources.ConditionOutOfRangeParameterValues, fmt.Sprintf("The values for the following parameters are outside of the allowed range: %s", strings.Join(names, ", "))
+	}
+
 	return true, "", ""
 }
 
+// violatedConstraint checks a plain value against the type, enum, pattern, and range of the
+// parameter definition and returns the reason for the first violated constraint, or an empty string
+func violatedConstraint(parameterDefinition buildv1beta1.Parameter, value string) string {
+	switch parameterDefinition.Type {
+	case buildv1beta1.ParameterTypeBoolean:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return resources.ConditionMalformedParameterValues
+		}
+
+	case buildv1beta1.ParameterTypeInteger:
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return resources.ConditionMalformedParameterValues
+		}
+
+		if (parameterDefinition.Minimum != nil && number < *parameterDefinition.Minimum) ||
+			(parameterDefinition.Maximum != nil && number > *parameterDefinition.Maximum) {
+			return resources.ConditionOutOfRangeParameterValues
+		}
+	}
+
+	if len(parameterDefinition.Enum) > 0 && !slices.Contains(parameterDefinition.Enum, value) {
+		return resources.ConditionDisallowedParameterValues
+	}
+
+	if parameterDefinition.Pattern != nil {
+		// an invalid pattern is reported on the strategy, values cannot match it
+		pattern, err := regexp.Compile(*parameterDefinition.Pattern)
+		if err != nil || !pattern.MatchString(value) {
+			return resources.ConditionPatternMismatchParameterValues
+		}
+	}
+
+	return ""
+}
+
 // hasMoreThanOneValue checks if a SingleValue has more than one value set (plain text, secret, and config map key reference)
 func hasMoreThanOneValue(singleValue buildv1beta1.SingleValue) bool {
 	if singleValue.Value != nil && (singleValue.ConfigMapValue != nil || singleValue.SecretValue != nil) {
@@ -411,4 +411,192 @@ var _ = Describe("ValidateBuildRunParameters", func() {
 			})
 		})
 	})
+
+	Context("for a set of typed parameter definitions", func() {
+		parameterDefinitions := []buildv1beta1.Parameter{
+			{
+				Name:    "boolean-param",
+				Type:    buildv1beta1.ParameterTypeBoolean,
+				Default: ptr.To("false"),
+			},
+			{
+				Name:    "integer-param",
+				Type:    buildv1beta1.ParameterTypeInteger,
+				Default: ptr.To("3"),
+				Minimum: ptr.To[int64](1),
+				Maximum: ptr.To[int64](5),
+			},
+			{
+				Name:    "enum-param",
+				Default: ptr.To("info"),
+				Enum:    []string{"debug", "info", "warn"},
+			},
+			{
+				Name:     "pattern-param",
+				Type:     buildv1beta1.ParameterTypeArray,
+				Defaults: &[]string{},
+				Pattern:  ptr.To("^--[a-z-]+$"),
+			},
+			{
+				Name:     "required-param",
+				Default:  ptr.To("a default"),
+				Required: ptr.To(true),
+			},
+		}
+
+		requiredParamValue := buildv1beta1.ParamValue{
+			Name: "required-param",
+			SingleValue: &buildv1beta1.SingleValue{
+				Value: ptr.To("a value"),
+			},
+		}
+
+		singleValue := func(name string, value string) buildv1beta1.ParamValue {
+			return buildv1beta1.ParamValue{
+				Name: name,
+				SingleValue: &buildv1beta1.SingleValue{
+					Value: ptr.To(value),
+				},
+			}
+		}
+
+		It("validates values that satisfy all constraints without an error", func() {
+			valid, _, _ := validate.BuildRunParameters(parameterDefinitions, []buildv1beta1.ParamValue{
+				singleValue("boolean-param", "true"),
+				singleValue("integer-param", "5"),
+				singleValue("enum-param", "debug"),
+				{
+					Name: "pattern-param",
+					Values: []buildv1beta1.SingleValue{
+						{Value: ptr.To("--verbose")},
+						{ConfigMapValue: &buildv1beta1.ObjectKeyRef{Name: "a-config-map", Key: "a-key"}},
+					},
+				},
+			}, []buildv1beta1.ParamValue{requiredParamValue})
+			Expect(valid).To(BeTrue())
+		})
+
+		It("reports a required parameter with a default as missing", func() {
+			valid, reason, message := validate.BuildRunParameters(parameterDefinitions, nil, nil)
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal("MissingParameterValues"))
+			Expect(message).To(ContainSubstring("required-param"))
+		})
+
+		It("does not report a required parameter as missing for a Build", func() {
+			valid, _, _ := validate.BuildParameters(parameterDefinitions, nil)
+			Expect(valid).To(BeTrue())
+		})
+
+		It("reports values that are not valid for the type", func() {
+			valid, reason, message := validate.BuildParameters(parameterDefinitions, []buildv1beta1.ParamValue{
+				singleValue("boolean-param", "yes"),
+				singleValue("integer-param", "3.5"),
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal(buildv1beta1.MalformedParameterValues))
+			Expect(message).To(HavePrefix("The values for the following parameters are not valid for the type of the parameter:"))
+			Expect(message).To(ContainSubstring("boolean-param"))
+			Expect(message).To(ContainSubstring("integer-param"))
+		})
+
+		It("reports values that are not part of the enum", func() {
+			valid, reason, message := validate.BuildParameters(parameterDefinitions, []buildv1beta1.ParamValue{
+				singleValue("enum-param", "trace"),
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal(buildv1beta1.DisallowedParameterValues))
+			Expect(message).To(ContainSubstring("enum-param"))
+		})
+
+		It("reports array items that do not match the pattern", func() {
+			valid, reason, message := validate.BuildRunParameters(parameterDefinitions, nil, []buildv1beta1.ParamValue{
+				requiredParamValue,
+				{
+					Name: "pattern-param",
+					Values: []buildv1beta1.SingleValue{
+						{Value: ptr.To("--verbose")},
+						{Value: ptr.To("-v")},
+					},
+				},
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal("PatternMismatchParameterValues"))
+			Expect(message).To(ContainSubstring("pattern-param"))
+		})
+
+		It("reports integer values outside of the range", func() {
+			valid, reason, message := validate.BuildRunParameters(parameterDefinitions, nil, []buildv1beta1.ParamValue{
+				requiredParamValue,
+				singleValue("integer-param", "0"),
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal("OutOfRangeParameterValues"))
+			Expect(message).To(ContainSubstring("integer-param"))
+		})
+	})
+
+	Context("for an object parameter definition", func() {
+		parameterDefinitions := []buildv1beta1.Parameter{
+			{
+				Name:       "build-args",
+				Type:       buildv1beta1.ParameterTypeObject,
+				Properties: []string{"GO_VERSION"},
+			},
+		}
+
+		It("validates properties from different sources without an error", func() {
+			valid, _, _ := validate.BuildRunParameters(parameterDefinitions, []buildv1beta1.ParamValue{
+				{
+					Name: "build-args",
+					Properties: map[string]buildv1beta1.SingleValue{
+						"GO_VERSION": {Value: ptr.To("1.22")},
+						"TOKEN":      {SecretValue: &buildv1beta1.ObjectKeyRef{Name: "a-secret", Key: "token"}},
+					},
+				},
+			}, nil)
+			Expect(valid).To(BeTrue())
+		})
+
+		It("reports a value of the wrong type", func() {
+			valid, reason, _ := validate.BuildParameters(parameterDefinitions, []buildv1beta1.ParamValue{
+				{
+					Name:   "build-args",
+					Values: []buildv1beta1.SingleValue{{Value: ptr.To("GO_VERSION=1.22")}},
+				},
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal(buildv1beta1.WrongParameterValueType))
+		})
+
+		It("reports a property that the strategy requires but that has no value", func() {
+			valid, reason, message := validate.BuildRunParameters(parameterDefinitions, nil, []buildv1beta1.ParamValue{
+				{
+					Name: "build-args",
+					Properties: map[string]buildv1beta1.SingleValue{
+						"OTHER": {Value: ptr.To("value")},
+					},
+				},
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal("MissingObjectPropertyParameterValues"))
+			Expect(message).To(ContainSubstring("build-args.GO_VERSION"))
+		})
+
+		It("reports properties with an invalid name or without a value", func() {
+			valid, reason, message := validate.BuildParameters(parameterDefinitions, []buildv1beta1.ParamValue{
+				{
+					Name: "build-args",
+					Properties: map[string]buildv1beta1.SingleValue{
+						"GO_VERSION": {},
+						"with.a.dot": {Value: ptr.To("value")},
+					},
+				},
+			})
+			Expect(valid).To(BeFalse())
+			Expect(reason).To(Equal(buildv1beta1.InvalidObjectPropertyParameterValues))
+			Expect(message).To(ContainSubstring("build-args.GO_VERSION"))
+			Expect(message).To(ContainSubstring("build-args.with.a.dot"))
+		})
+	})
 })
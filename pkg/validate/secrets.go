@@ -58,6 +58,43 @@ func (s Credentials) ValidatePath(ctx context.Context) error {
 	return nil
 }
 
+// BuildRunCacheSecret validates that the push secret of the cache override of
+// a BuildRun exists, like it is done for the cache of a Build
+func BuildRunCacheSecret(ctx context.Context, c client.Client, buildRun *build.BuildRun) (bool, string, string, error) {
+	if buildRun.Spec.Cache == nil || buildRun.Spec.Cache.PushSecret == nil {
+		return true, "", "", nil
+	}
+
+	secretName := *buildRun.Spec.Cache.PushSecret
+	if err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: buildRun.Namespace}, &corev1.Secret{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, string(build.SpecCacheSecretRefNotFound), fmt.Sprintf("referenced secret %s not found", secretName), nil
+		}
+
+		return false, "", "", err
+	}
+
+	return true, "", "", nil
+}
+
+// BuildRunUploadSecret validates that the upload secret of the local source of the BuildRun exists
+func BuildRunUploadSecret(ctx context.Context, c client.Client, buildRun *build.BuildRun) (bool, string, string, error) {
+	if buildRun.Spec.Source == nil || buildRun.Spec.Source.Local == nil || buildRun.Spec.Source.Local.UploadSecret == nil {
+		return true, "", "", nil
+	}
+
+	secretName := *buildRun.Spec.Source.Local.UploadSecret
+	if err := c.Get(ctx, types.NamespacedName{Name: secretName, Namespace: buildRun.Namespace}, &corev1.Secret{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, string(build.SpecSourceSecretRefNotFound), fmt.Sprintf("referenced secret %s not found", secretName), nil
+		}
+
+		return false, "", "", err
+	}
+
+	return true, "", "", nil
+}
+
 func (s Credentials) buildCredentialReferences() map[string]build.BuildReason {
 	// Validate if the referenced secrets exist in the namespace
 	secretRefMap := map[string]build.BuildReason{}
@@ -65,8 +102,22 @@ func (s Credentials) buildCredentialReferences() map[string]build.BuildReason {
 		secretRefMap[*s.Build.Spec.Output.PushSecret] = build.SpecOutputSecretRefNotFound
 	}
 
+	if s.Build.Spec.Cache != nil && s.Build.Spec.Cache.PushSecret != nil {
+		secretRefMap[*s.Build.Spec.Cache.PushSecret] = build.SpecCacheSecretRefNotFound
+	}
+
 	if s.Build.GetSourceCredentials() != nil {
 		secretRefMap[*s.Build.GetSourceCredentials()] = build.SpecSourceSecretRefNotFound
 	}
+
+	if s.Build.Spec.Source != nil && s.Build.Spec.Source.Git != nil && s.Build.Spec.Source.Git.CommitStatus != nil && s.Build.Spec.Source.Git.CommitStatus.Secret != "" {
+		secretRefMap[s.Build.Spec.Source.Git.CommitStatus.Secret] = build.SpecCommitStatusSecretRefNotFound
+	}
+
+	for _, additionalSource := range s.Build.Spec.AdditionalSources {
+		if additionalSource.GetCredentials() != nil {
+			secretRefMap[*additionalSource.GetCredentials()] = build.SpecSourceSecretRefNotFound
+		}
+	}
 	return secretRefMap
 }
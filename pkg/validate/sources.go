@@ -7,10 +7,17 @@ package validate
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
+
+	"k8s.io/utils/ptr"
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/commitstatus"
 )
 
+var sha256RegEx = regexp.MustCompile(`^[a-fA-F0-9]{64}$`)
+
 // SourcesRef implements RuntimeRef interface to add validations for `build.spec.source`.
 type SourceRef struct {
 	Build *build.Build // build instance for analysis
@@ -30,29 +37,93 @@ func (s *SourceRef) validateSourceEntry(source *build.Source) error {
 
 	// dont bail out if the Source object is empty, we preserve the old behaviour as in v1alpha1
 	if source.Type == "" && source.Git == nil &&
-		source.OCIArtifact == nil && source.Local == nil {
+		source.OCIArtifact == nil && source.Local == nil && source.Archive == nil {
 		return nil
 	}
 
 	switch source.Type {
 	case build.GitType:
-		if source.Git == nil || source.OCIArtifact != nil || source.Local != nil {
+		if source.Git == nil || source.OCIArtifact != nil || source.Local != nil || source.Archive != nil {
 			return fmt.Errorf("type does not match the source")
 		}
+		if msg := commitStatusValidationMessage(source.Git); msg != "" {
+			s.Build.Status.Reason = ptr.To(build.CommitStatusNotValid)
+			s.Build.Status.Message = ptr.To(msg)
+		}
 	case build.OCIArtifactType:
-		if source.OCIArtifact == nil || source.Git != nil || source.Local != nil {
+		if source.OCIArtifact == nil || source.Git != nil || source.Local != nil || source.Archive != nil {
 			return fmt.Errorf("type does not match the source")
 		}
 	case build.LocalType:
-		if source.Local == nil || source.OCIArtifact != nil || source.Git != nil {
+		if source.Local == nil || source.OCIArtifact != nil || source.Git != nil || source.Archive != nil {
 			return fmt.Errorf("type does not match the source")
 		}
+		if msg := localValidationMessage(source.Local); msg != "" {
+			s.Build.Status.Reason = ptr.To(build.LocalSourceNotValid)
+			s.Build.Status.Message = ptr.To(msg)
+		}
+	case build.ArchiveType:
+		if source.Archive == nil || source.OCIArtifact != nil || source.Git != nil || source.Local != nil {
+			return fmt.Errorf("type does not match the source")
+		}
+		s.validateArchive(source.Archive)
 	case "":
 		return fmt.Errorf("type definition is missing")
 	}
 	return nil
 }
 
+// validateArchive checks that the archive is downloaded via HTTP(S) and that
+// the checksum, if provided, is a hex-encoded SHA-256 checksum
+func (s *SourceRef) validateArchive(archive *build.Archive) {
+	if msg := archiveValidationMessage(archive); msg != "" {
+		s.Build.Status.Reason = ptr.To(build.ArchiveSourceNotValid)
+		s.Build.Status.Message = ptr.To(msg)
+	}
+}
+
+// archiveValidationMessage returns the reason why the archive is not valid, or
+// an empty string if it is valid
+func archiveValidationMessage(archive *build.Archive) string {
+	if archiveURL, err := url.Parse(archive.URL); err != nil || (archiveURL.Scheme != "http" && archiveURL.Scheme != "https") || archiveURL.Host == "" {
+		return fmt.Sprintf("archive URL %q is not a valid HTTP or HTTPS URL", archive.URL)
+	}
+
+	if archive.SHA256 != nil && !sha256RegEx.MatchString(*archive.SHA256) {
+		return "archive checksum must be a hex-encoded SHA-256 checksum"
+	}
+
+	return ""
+}
+
+// commitStatusValidationMessage returns the reason why the commit status reporting
+// of the Git source is not valid, or an empty string if it is valid
+func commitStatusValidationMessage(git *build.Git) string {
+	if git.CommitStatus == nil {
+		return ""
+	}
+
+	if git.CommitStatus.Secret == "" {
+		return "commit status reporting requires a secret"
+	}
+
+	if _, err := commitstatus.ParseRepository(git.URL, git.CommitStatus.Forge); err != nil {
+		return fmt.Sprintf("commit status cannot be reported for Git URL %q: %v", git.URL, err)
+	}
+
+	return ""
+}
+
+// localValidationMessage returns the reason why the local source is not valid,
+// or an empty string if it is valid
+func localValidationMessage(local *build.Local) string {
+	if local.UsesUploadEndpoint() && (local.UploadSecret == nil || *local.UploadSecret == "") {
+		return fmt.Sprintf("local source with method %s requires an uploadSecret", build.LocalUploadMethodHTTP)
+	}
+
+	return ""
+}
+
 // NewSourcesRef instantiate a new SourcesRef passing the build object pointer along.
 func NewSourceRef(b *build.Build) *SourceRef {
 	return &SourceRef{Build: b}
@@ -10,6 +10,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"k8s.io/utils/ptr"
+
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/validate"
 )
@@ -59,5 +61,136 @@ var _ = Describe("SourcesRef", func() {
 
 			Expect(srcRef.ValidatePath(context.TODO())).To(HaveOccurred())
 		})
+
+		It("should successfully validate a build with an archive source", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.ArchiveType,
+						Archive: &build.Archive{
+							URL:    "https://artifacts.example.com/sample-go.tar.gz",
+							SHA256: ptr.To("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+						},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(BeNil())
+		})
+
+		It("should fail to validate if the type does not match the archive source", func() {
+			srcRef := validate.NewSourceRef(&build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type:    build.GitType,
+						Archive: &build.Archive{URL: "https://artifacts.example.com/sample-go.tar.gz"},
+					},
+				},
+			})
+
+			Expect(srcRef.ValidatePath(context.TODO())).To(HaveOccurred())
+		})
+
+		It("should mark the build as invalid if the archive is not downloaded via HTTP(S)", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type:    build.ArchiveType,
+						Archive: &build.Archive{URL: "file:///etc/passwd"},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(Equal(ptr.To(build.ArchiveSourceNotValid)))
+		})
+
+		It("should mark the build as invalid if the archive checksum is malformed", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.ArchiveType,
+						Archive: &build.Archive{
+							URL:    "https://artifacts.example.com/sample-go.tar.gz",
+							SHA256: ptr.To("not-a-checksum"),
+						},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(Equal(ptr.To(build.ArchiveSourceNotValid)))
+		})
+
+		It("should mark the build as invalid if the HTTP upload method is used without upload secret", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.LocalType,
+						Local: &build.Local{
+							Method: ptr.To(build.LocalUploadMethodHTTP),
+						},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(Equal(ptr.To(build.LocalSourceNotValid)))
+		})
+
+		It("should pass if the HTTP upload method is used with upload secret", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.LocalType,
+						Local: &build.Local{
+							Method:       ptr.To(build.LocalUploadMethodHTTP),
+							UploadSecret: ptr.To("upload-token"),
+						},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(BeNil())
+		})
+
+		It("should mark the build as invalid if the forge of a commit status cannot be detected", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.GitType,
+						Git: &build.Git{
+							URL:          "https://git.example.com/shipwright-io/sample-go",
+							CommitStatus: &build.GitCommitStatus{Secret: "forge-token"},
+						},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(Equal(ptr.To(build.CommitStatusNotValid)))
+		})
+
+		It("should pass if the forge of a commit status is configured", func() {
+			b := &build.Build{
+				Spec: build.BuildSpec{
+					Source: &build.Source{
+						Type: build.GitType,
+						Git: &build.Git{
+							URL: "https://git.example.com/shipwright-io/sample-go",
+							CommitStatus: &build.GitCommitStatus{
+								Secret: "forge-token",
+								Forge:  ptr.To(build.GitForgeGitLab),
+							},
+						},
+					},
+				},
+			}
+
+			Expect(validate.NewSourceRef(b).ValidatePath(context.TODO())).To(BeNil())
+			Expect(b.Status.Reason).To(BeNil())
+		})
 	})
 })
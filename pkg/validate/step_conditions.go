@@ -0,0 +1,53 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
+)
+
+// BuildStepConditions is used to validate that the parameters that the when expressions of the strategy steps
+// reference do not get their value from a ConfigMap or Secret in the Build object
+func BuildStepConditions(strategySteps []buildv1beta1.Step, buildParamValues []buildv1beta1.ParamValue) (bool, buildv1beta1.BuildReason, string) {
+	return validateStepConditions(strategySteps, buildParamValues)
+}
+
+// BuildRunStepConditions is used to validate that the parameters that the when expressions of the strategy steps
+// reference do not get their value from a ConfigMap or Secret in the Build and BuildRun objects
+func BuildRunStepConditions(strategySteps []buildv1beta1.Step, buildParamValues []buildv1beta1.ParamValue, buildRunParamValues []buildv1beta1.ParamValue) (bool, string, string) {
+	valid, reason, msg := validateStepConditions(strategySteps, resources.OverrideParams(buildParamValues, buildRunParamValues))
+	return valid, string(reason), msg
+}
+
+// validateStepConditions validates that the when expressions of the strategy steps can be evaluated when the
+// TaskRun is created, which is not the case for values that come from a ConfigMap or Secret
+func validateStepConditions(strategySteps []buildv1beta1.Step, paramValues []buildv1beta1.ParamValue) (bool, buildv1beta1.BuildReason, string) {
+	var parameters []string
+	for _, step := range strategySteps {
+		for _, when := range step.When {
+			paramValue := resources.FindParamValueByName(paramValues, when.Parameter)
+			if paramValue == nil || paramValue.SingleValue == nil {
+				continue
+			}
+
+			if paramValue.SingleValue.ConfigMapValue != nil || paramValue.SingleValue.SecretValue != nil {
+				if !slices.Contains(parameters, when.Parameter) {
+					parameters = append(parameters, when.Parameter)
+				}
+			}
+		}
+	}
+
+	if len(parameters) > 0 {
+		return false, buildv1beta1.StepConditionValueNotSupported, fmt.Sprintf("The following parameters are used in when expressions of the build strategy steps and cannot get their value from a ConfigMap or Secret: %s", strings.Join(parameters, ", "))
+	}
+
+	return true, "", ""
+}
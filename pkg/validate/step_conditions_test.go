@@ -0,0 +1,75 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/utils/ptr"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("ValidateStepConditions", func() {
+
+	strategySteps := []buildv1beta1.Step{{
+		Name: "build",
+	}, {
+		Name: "scan",
+		When: []buildv1beta1.WhenExpression{{
+			Parameter: "scan",
+			Operator:  buildv1beta1.WhenOperatorIn,
+			Values:    []string{"true"},
+		}},
+	}}
+
+	It("passes for a when expression on a parameter with a direct value", func() {
+		valid, reason, msg := validate.BuildStepConditions(strategySteps, []buildv1beta1.ParamValue{{
+			Name:        "scan",
+			SingleValue: &buildv1beta1.SingleValue{Value: ptr.To("true")},
+		}})
+		Expect(valid).To(BeTrue())
+		Expect(reason).To(BeEmpty())
+		Expect(msg).To(BeEmpty())
+	})
+
+	It("passes for parameters from a ConfigMap that no when expression references", func() {
+		valid, _, _ := validate.BuildStepConditions(strategySteps, []buildv1beta1.ParamValue{{
+			Name:        "other",
+			SingleValue: &buildv1beta1.SingleValue{ConfigMapValue: &buildv1beta1.ObjectKeyRef{Name: "a-configmap", Key: "a-key"}},
+		}})
+		Expect(valid).To(BeTrue())
+	})
+
+	It("fails for a when expression on a parameter with a value from a Secret", func() {
+		valid, reason, msg := validate.BuildStepConditions(strategySteps, []buildv1beta1.ParamValue{{
+			Name:        "scan",
+			SingleValue: &buildv1beta1.SingleValue{SecretValue: &buildv1beta1.ObjectKeyRef{Name: "a-secret", Key: "a-key"}},
+		}})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildv1beta1.StepConditionValueNotSupported))
+		Expect(msg).To(ContainSubstring("scan"))
+	})
+
+	It("uses the value of the BuildRun over the one of the Build", func() {
+		buildParamValues := []buildv1beta1.ParamValue{{
+			Name:        "scan",
+			SingleValue: &buildv1beta1.SingleValue{Value: ptr.To("true")},
+		}}
+		buildRunParamValues := []buildv1beta1.ParamValue{{
+			Name:        "scan",
+			SingleValue: &buildv1beta1.SingleValue{ConfigMapValue: &buildv1beta1.ObjectKeyRef{Name: "a-configmap", Key: "a-key"}},
+		}}
+
+		valid, reason, _ := validate.BuildRunStepConditions(strategySteps, buildParamValues, buildRunParamValues)
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(string(buildv1beta1.StepConditionValueNotSupported)))
+
+		valid, _, _ = validate.BuildRunStepConditions(strategySteps, buildRunParamValues, buildParamValues)
+		Expect(valid).To(BeTrue())
+	})
+})
@@ -0,0 +1,86 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources/steps"
+)
+
+// BuildStepResources is used to validate the step resources in the Build object
+func BuildStepResources(strategySteps []buildv1beta1.Step, stepResources []buildv1beta1.StepResources) (bool, buildv1beta1.BuildReason, string) {
+	return validateStepResources(strategySteps, stepResources)
+}
+
+// BuildRunStepResources is used to validate the step resources in the BuildRun object
+func BuildRunStepResources(strategySteps []buildv1beta1.Step, stepResources []buildv1beta1.StepResources) (bool, string, string) {
+	valid, reason, msg := validateStepResources(strategySteps, stepResources)
+	return valid, string(reason), msg
+}
+
+// validateStepResources validates that the step resources only override the resources of strategy steps that
+// define maxResources, that they stay within those maxResources, and that no request exceeds its limit after
+// the override is applied
+func validateStepResources(strategySteps []buildv1beta1.Step, stepResources []buildv1beta1.StepResources) (bool, buildv1beta1.BuildReason, string) {
+	names := map[string]struct{}{}
+	for _, stepResource := range stepResources {
+		if _, exists := names[stepResource.Name]; exists {
+			return false, buildv1beta1.StepResourcesNotValid, fmt.Sprintf("resources for step %q are defined more than once", stepResource.Name)
+		}
+		names[stepResource.Name] = struct{}{}
+
+		index := slices.IndexFunc(strategySteps, func(step buildv1beta1.Step) bool { return step.Name == stepResource.Name })
+		if index < 0 {
+			return false, buildv1beta1.StepResourcesNotValid, fmt.Sprintf("step %q is not defined in the strategy", stepResource.Name)
+		}
+		step := strategySteps[index]
+
+		if len(step.MaxResources) == 0 {
+			return false, buildv1beta1.StepResourcesNotValid, fmt.Sprintf("step %q does not allow to override its resources, the strategy defines no maxResources for it", step.Name)
+		}
+
+		if msg := exceededMaxResourcesMessage(step, "request", stepResource.Resources.Requests); msg != "" {
+			return false, buildv1beta1.StepResourcesNotValid, msg
+		}
+
+		if msg := exceededMaxResourcesMessage(step, "limit", stepResource.Resources.Limits); msg != "" {
+			return false, buildv1beta1.StepResourcesNotValid, msg
+		}
+
+		resources := steps.OverrideResources(step, []buildv1beta1.StepResources{stepResource}, nil)
+		for _, name := range slices.Sorted(maps.Keys(resources.Requests)) {
+			request := resources.Requests[name]
+			if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+				return false, buildv1beta1.StepResourcesNotValid, fmt.Sprintf("the %s request of %s for step %q exceeds its limit of %s", name, request.String(), step.Name, limit.String())
+			}
+		}
+	}
+
+	return true, "", ""
+}
+
+// exceededMaxResourcesMessage returns a message for the first resource that is not listed in, or exceeds, the maxResources of the step
+func exceededMaxResourcesMessage(step buildv1beta1.Step, kind string, resourceList corev1.ResourceList) string {
+	for _, name := range slices.Sorted(maps.Keys(resourceList)) {
+		quantity := resourceList[name]
+
+		maximum, ok := step.MaxResources[name]
+		if !ok {
+			return fmt.Sprintf("the %s %s for step %q cannot be overridden, the strategy defines no maximum for it", name, kind, step.Name)
+		}
+
+		if quantity.Cmp(maximum) > 0 {
+			return fmt.Sprintf("the %s %s of %s for step %q exceeds the maximum of %s", name, kind, quantity.String(), step.Name, maximum.String())
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,110 @@
+// Copyright The Shipwright Contributors
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
+	"github.com/shipwright-io/build/pkg/validate"
+)
+
+var _ = Describe("ValidateStepResources", func() {
+
+	strategySteps := []buildv1beta1.Step{{
+		Name: "build",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+		MaxResources: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}, {
+		Name: "push",
+	}}
+
+	stepResources := func(name string, requests corev1.ResourceList, limits corev1.ResourceList) buildv1beta1.StepResources {
+		return buildv1beta1.StepResources{
+			Name: name,
+			Resources: corev1.ResourceRequirements{
+				Requests: requests,
+				Limits:   limits,
+			},
+		}
+	}
+
+	It("passes without step resources", func() {
+		valid, reason, msg := validate.BuildStepResources(strategySteps, nil)
+		Expect(valid).To(BeTrue())
+		Expect(reason).To(BeEmpty())
+		Expect(msg).To(BeEmpty())
+	})
+
+	It("passes for step resources within the maxResources", func() {
+		valid, _, _ := validate.BuildStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("build", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}),
+		})
+		Expect(valid).To(BeTrue())
+	})
+
+	It("fails for a step that is defined more than once", func() {
+		valid, reason, msg := validate.BuildStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("build", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, nil),
+			stepResources("build", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}, nil),
+		})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildv1beta1.StepResourcesNotValid))
+		Expect(msg).To(ContainSubstring("defined more than once"))
+	})
+
+	It("fails for a step that is not defined in the strategy", func() {
+		valid, reason, msg := validate.BuildStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("unknown", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, nil),
+		})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(buildv1beta1.StepResourcesNotValid))
+		Expect(msg).To(Equal(`step "unknown" is not defined in the strategy`))
+	})
+
+	It("fails for a step without maxResources", func() {
+		valid, _, msg := validate.BuildStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("push", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}, nil),
+		})
+		Expect(valid).To(BeFalse())
+		Expect(msg).To(ContainSubstring("defines no maxResources"))
+	})
+
+	It("fails for a resource that has no maximum", func() {
+		valid, _, msg := validate.BuildStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("build", nil, corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")}),
+		})
+		Expect(valid).To(BeFalse())
+		Expect(msg).To(ContainSubstring("the ephemeral-storage limit for step \"build\" cannot be overridden"))
+	})
+
+	It("fails for a resource that exceeds its maximum", func() {
+		valid, _, msg := validate.BuildStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("build", nil, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}),
+		})
+		Expect(valid).To(BeFalse())
+		Expect(msg).To(Equal(`the cpu limit of 3 for step "build" exceeds the maximum of 2`))
+	})
+
+	It("fails for a request that exceeds its limit after the override", func() {
+		valid, reason, msg := validate.BuildRunStepResources(strategySteps, []buildv1beta1.StepResources{
+			stepResources("build", nil, corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")}),
+		})
+		Expect(valid).To(BeFalse())
+		Expect(reason).To(Equal(string(buildv1beta1.StepResourcesNotValid)))
+		Expect(msg).To(Equal(`the memory request of 1Gi for step "build" exceeds its limit of 512Mi`))
+	})
+})
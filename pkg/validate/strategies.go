@@ -15,6 +15,7 @@ import (
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 	"github.com/shipwright-io/build/pkg/ctxlog"
+	"github.com/shipwright-io/build/pkg/reconciler/buildrun/resources"
 )
 
 // Strategy contains all required fields
@@ -62,9 +63,11 @@ func (s Strategy) validateBuildStrategy(ctx context.Context, strategyName string
 	buildStrategy := &build.BuildStrategy{}
 	err := s.Client.Get(ctx, types.NamespacedName{Name: strategyName, Namespace: s.Build.Namespace}, buildStrategy)
 	if err == nil {
-		s.validateBuildParams(buildStrategy.GetParameters())
-		s.validateBuildVolumes(buildStrategy.GetVolumes())
-		return nil
+		if s.Build.Spec.Strategy.Version != nil {
+			return s.validateBuildStrategyRevision(ctx, strategyName, *s.Build.Spec.Strategy.Version)
+		}
+
+		return s.validateResolvedStrategy(ctx, buildStrategy)
 	}
 
 	if apierrors.IsNotFound(err) {
@@ -80,9 +83,11 @@ func (s Strategy) validateClusterBuildStrategy(ctx context.Context, strategyName
 	clusterBuildStrategy := &build.ClusterBuildStrategy{}
 	err := s.Client.Get(ctx, types.NamespacedName{Name: strategyName}, clusterBuildStrategy)
 	if err == nil {
-		s.validateBuildParams(clusterBuildStrategy.GetParameters())
-		s.validateBuildVolumes(clusterBuildStrategy.GetVolumes())
-		return nil
+		if s.Build.Spec.Strategy.Version != nil {
+			return s.validateClusterBuildStrategyRevision(ctx, strategyName, *s.Build.Spec.Strategy.Version)
+		}
+
+		return s.validateResolvedStrategy(ctx, clusterBuildStrategy)
 	}
 
 	if apierrors.IsNotFound(err) {
@@ -94,6 +99,48 @@ func (s Strategy) validateClusterBuildStrategy(ctx context.Context, strategyName
 	return err
 }
 
+func (s Strategy) validateBuildStrategyRevision(ctx context.Context, strategyName string, version string) error {
+	revision := &build.BuildStrategyRevision{}
+	err := s.Client.Get(ctx, types.NamespacedName{Name: build.RevisionName(strategyName, version), Namespace: s.Build.Namespace}, revision)
+	if err == nil && !revision.IsRevisionOf(strategyName, version) {
+		// a revision of another strategy can have the same name
+		err = apierrors.NewNotFound(build.Resource("buildstrategyrevisions"), revision.Name)
+	}
+
+	if err == nil {
+		return s.validateResolvedStrategy(ctx, revision)
+	}
+
+	if apierrors.IsNotFound(err) {
+		s.Build.Status.Reason = ptr.To[build.BuildReason](build.BuildStrategyRevisionNotFound)
+		s.Build.Status.Message = ptr.To(fmt.Sprintf("buildStrategy %s has no revision for version %s in namespace %s", strategyName, version, s.Build.Namespace))
+		return nil
+	}
+
+	return err
+}
+
+func (s Strategy) validateClusterBuildStrategyRevision(ctx context.Context, strategyName string, version string) error {
+	revision := &build.ClusterBuildStrategyRevision{}
+	err := s.Client.Get(ctx, types.NamespacedName{Name: build.RevisionName(strategyName, version)}, revision)
+	if err == nil && !revision.IsRevisionOf(strategyName, version) {
+		// a revision of another strategy can have the same name
+		err = apierrors.NewNotFound(build.Resource("clusterbuildstrategyrevisions"), revision.Name)
+	}
+
+	if err == nil {
+		return s.validateResolvedStrategy(ctx, revision)
+	}
+
+	if apierrors.IsNotFound(err) {
+		s.Build.Status.Reason = ptr.To[build.BuildReason](build.ClusterBuildStrategyRevisionNotFound)
+		s.Build.Status.Message = ptr.To(fmt.Sprintf("clusterBuildStrategy %s has no revision for version %s", strategyName, version))
+		return nil
+	}
+
+	return err
+}
+
 func (s Strategy) validateBuildParams(parameterDefinitions []build.Parameter) {
 	valid, reason, message := BuildParameters(parameterDefinitions, s.Build.Spec.ParamValues)
 	if !valid {
@@ -109,3 +156,72 @@ func (s Strategy) validateBuildVolumes(strategyVolumes []build.BuildStrategyVolu
 		s.Build.Status.Message = ptr.To(message)
 	}
 }
+
+func (s Strategy) validateBuildStepConditions(strategySteps []build.Step) {
+	valid, reason, message := BuildStepConditions(strategySteps, s.Build.Spec.ParamValues)
+	if !valid {
+		s.Build.Status.Reason = ptr.To[build.BuildReason](reason)
+		s.Build.Status.Message = ptr.To(message)
+	}
+}
+
+func (s Strategy) validateBuildStepResources(strategySteps []build.Step) {
+	valid, reason, message := BuildStepResources(strategySteps, s.Build.Spec.StepResources)
+	if !valid {
+		s.Build.Status.Reason = ptr.To[build.BuildReason](reason)
+		s.Build.Status.Message = ptr.To(message)
+	}
+}
+
+// validateClusterBuildStrategyAllowed validates that the namespace of the Build is allowed to use the ClusterBuildStrategy
+func (s Strategy) validateClusterBuildStrategyAllowed(ctx context.Context, strategy build.BuilderStrategy) (bool, error) {
+	err := resources.CheckClusterBuildStrategyAllowed(ctx, s.Client, s.Build.Namespace, s.Build.Spec.Strategy.Name, strategy)
+	switch {
+	case err == nil:
+		return true, nil
+
+	case resources.IsClusterBuildStrategyNotAllowedError(err):
+		s.Build.Status.Reason = ptr.To[build.BuildReason](build.ClusterBuildStrategyNotAllowed)
+		s.Build.Status.Message = ptr.To(err.Error())
+		return false, nil
+
+	case apierrors.IsNotFound(err):
+		s.Build.Status.Reason = ptr.To[build.BuildReason](build.ClusterBuildStrategyNotFound)
+		s.Build.Status.Message = ptr.To(fmt.Sprintf("clusterBuildStrategy %s does not exist", s.Build.Spec.Strategy.Name))
+		return false, nil
+	}
+
+	return false, err
+}
+
+// validateResolvedStrategy validates the parameters, volumes, and step resources of the Build against the strategy
+// with the ClusterStrategyFragments that it references merged into it
+func (s Strategy) validateResolvedStrategy(ctx context.Context, strategy build.BuilderStrategy) error {
+	resolved, err := resources.ResolveStrategyFragments(ctx, s.Client, strategy)
+	switch {
+	case err == nil:
+		if s.kind(ctx) == build.ClusterBuildStrategyKind {
+			if allowed, err := s.validateClusterBuildStrategyAllowed(ctx, resolved); !allowed {
+				return err
+			}
+		}
+
+		s.validateBuildParams(resolved.GetParameters())
+		s.validateBuildVolumes(resolved.GetVolumes())
+		s.validateBuildStepResources(resolved.GetBuildSteps())
+		s.validateBuildStepConditions(resolved.GetBuildSteps())
+		return nil
+
+	case apierrors.IsNotFound(err):
+		s.Build.Status.Reason = ptr.To[build.BuildReason](build.StrategyFragmentNotFound)
+		s.Build.Status.Message = ptr.To(err.Error())
+		return nil
+
+	case resources.IsStrategyFragmentConflictError(err):
+		s.Build.Status.Reason = ptr.To[build.BuildReason](build.StrategyFragmentConflict)
+		s.Build.Status.Message = ptr.To(err.Error())
+		return nil
+	}
+
+	return err
+}
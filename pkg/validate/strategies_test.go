@@ -16,10 +16,12 @@ import (
 
 	build "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	crc "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -125,6 +127,250 @@ var _ = Describe("BuildStrategy", func() {
 		})
 	})
 
+	Context("a version of the build strategy is pinned", func() {
+		var revision *build.ClusterBuildStrategyRevision
+
+		BeforeEach(func() {
+			revision = nil
+			client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+				switch object := object.(type) {
+				case *build.ClusterBuildStrategy:
+					(&build.ClusterBuildStrategy{ObjectMeta: metav1.ObjectMeta{Name: nn.Name}}).DeepCopyInto(object)
+					return nil
+
+				case *build.ClusterBuildStrategyRevision:
+					if revision != nil && nn.Name == revision.Name {
+						revision.DeepCopyInto(object)
+						return nil
+					}
+				}
+
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			})
+		})
+
+		It("should pass when the revision of the version exists", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			sample.Spec.Strategy.Version = ptr.To("1.0.0")
+			revision = &build.ClusterBuildStrategyRevision{
+				ObjectMeta: metav1.ObjectMeta{Name: "buildkit-1.0.0"},
+				Spec:       build.BuildStrategyRevisionSpec{Strategy: "buildkit", Version: "1.0.0"},
+			}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(sample.Status.Reason).To(BeNil())
+		})
+
+		It("should fail when the revision of the version does not exist", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			sample.Spec.Strategy.Version = ptr.To("2.0.0")
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyRevisionNotFound))
+		})
+
+		It("should fail when the revision with the name belongs to another strategy", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			sample.Spec.Strategy.Version = ptr.To("1.0.0")
+			revision = &build.ClusterBuildStrategyRevision{
+				ObjectMeta: metav1.ObjectMeta{Name: "buildkit-1.0.0"},
+				Spec:       build.BuildStrategyRevisionSpec{Strategy: "buildkit-1", Version: "0.0"},
+			}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyRevisionNotFound))
+		})
+
+		It("should validate the parameters against the revision", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			sample.Spec.Strategy.Version = ptr.To("1.0.0")
+			sample.Spec.ParamValues = []build.ParamValue{{Name: "removed", SingleValue: &build.SingleValue{Value: ptr.To("value")}}}
+			revision = &build.ClusterBuildStrategyRevision{
+				ObjectMeta: metav1.ObjectMeta{Name: "buildkit-1.0.0"},
+				Spec:       build.BuildStrategyRevisionSpec{Strategy: "buildkit", Version: "1.0.0"},
+			}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.UndefinedParameter))
+		})
+	})
+
+	Context("the build strategy references fragments", func() {
+		var fragment *build.ClusterStrategyFragment
+
+		BeforeEach(func() {
+			fragment = &build.ClusterStrategyFragment{
+				ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle"},
+				Spec: build.StrategyFragmentSpec{
+					Parameters: []build.Parameter{{Name: "ca-bundle-configmap", Default: ptr.To("ca-bundle")}},
+				},
+			}
+			client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+				switch object := object.(type) {
+				case *build.ClusterBuildStrategy:
+					(&build.ClusterBuildStrategy{
+						ObjectMeta: metav1.ObjectMeta{Name: nn.Name},
+						Spec: build.BuildStrategySpec{
+							Parameters: []build.Parameter{{Name: "dockerfile", Default: ptr.To("Dockerfile")}},
+							Fragments:  []build.StrategyFragmentReference{{Name: "ca-bundle"}},
+						},
+					}).DeepCopyInto(object)
+					return nil
+
+				case *build.ClusterStrategyFragment:
+					if fragment != nil && nn.Name == fragment.Name {
+						fragment.DeepCopyInto(object)
+						return nil
+					}
+				}
+
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			})
+		})
+
+		It("should validate the parameters against the strategy with its fragments", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			sample.Spec.ParamValues = []build.ParamValue{{Name: "ca-bundle-configmap", SingleValue: &build.SingleValue{Value: ptr.To("corporate-ca")}}}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(sample.Status.Reason).To(BeNil())
+		})
+
+		It("should fail when a fragment does not exist", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			fragment = nil
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.StrategyFragmentNotFound))
+		})
+
+		It("should fail when a fragment conflicts with the strategy", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildkit")
+			fragment.Spec.Parameters[0].Name = "dockerfile"
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.StrategyFragmentConflict))
+		})
+	})
+
+	Context("the cluster build strategy is restricted to namespaces", func() {
+		var strategySpec build.BuildStrategySpec
+		var namespaceLabels map[string]string
+		var strategyAnnotations map[string]string
+
+		BeforeEach(func() {
+			strategyAnnotations = nil
+			strategySpec = build.BuildStrategySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant-tier": "trusted"}},
+				Steps:             []build.Step{{Name: "build"}},
+			}
+			namespaceLabels = map[string]string{"tenant-tier": "trusted"}
+			client.GetCalls(func(_ context.Context, nn types.NamespacedName, object crc.Object, getOptions ...crc.GetOption) error {
+				switch object := object.(type) {
+				case *build.ClusterBuildStrategy:
+					(&build.ClusterBuildStrategy{
+						ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Annotations: strategyAnnotations},
+						Spec:       strategySpec,
+					}).DeepCopyInto(object)
+					return nil
+
+				case *corev1.Namespace:
+					(&corev1.Namespace{
+						ObjectMeta: metav1.ObjectMeta{Name: nn.Name, Labels: namespaceLabels},
+					}).DeepCopyInto(object)
+					return nil
+				}
+
+				return errors.NewNotFound(schema.GroupResource{}, "schema not found")
+			})
+		})
+
+		It("should pass when the namespace matches the namespaceSelector", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(sample.Status.Reason).To(BeNil())
+		})
+
+		It("should fail when the namespace does not match the namespaceSelector", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			namespaceLabels = nil
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyNotAllowed))
+			Expect(*sample.Status.Message).To(ContainSubstring("does not match its namespaceSelector"))
+		})
+
+		It("should fail for a privileged strategy when the namespace did not opt in", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategySpec.Steps[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyNotAllowed))
+			Expect(*sample.Status.Message).To(ContainSubstring(build.LabelPrivilegedStrategies))
+		})
+
+		It("should pass for a privileged strategy when the namespace opted in", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategySpec.Steps[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+			namespaceLabels[build.LabelPrivilegedStrategies] = "true"
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(sample.Status.Reason).To(BeNil())
+		})
+
+		It("should fail for a strategy with a step that adds capabilities when the namespace did not opt in", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategySpec.Steps[0].SecurityContext = &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SETFCAP"}},
+			}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyNotAllowed))
+		})
+
+		It("should fail for a strategy that runs its steps as root when the namespace did not opt in", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategySpec.SecurityContext = &build.BuildStrategySecurityContext{RunAsUser: 0, RunAsGroup: 0}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyNotAllowed))
+		})
+
+		It("should pass for a strategy whose step runs as a non-root user despite the root user of the strategy", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategySpec.SecurityContext = &build.BuildStrategySecurityContext{RunAsUser: 0, RunAsGroup: 0}
+			strategySpec.Steps[0].SecurityContext = &corev1.SecurityContext{RunAsUser: ptr.To[int64](1000)}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(sample.Status.Reason).To(BeNil())
+		})
+
+		It("should follow the privileged annotation of the strategy over its steps", func() {
+			sample := sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategyAnnotations = map[string]string{build.AnnotationPrivilegedStrategy: "true"}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(*sample.Status.Reason).To(Equal(build.ClusterBuildStrategyNotAllowed))
+
+			sample = sampleBuild(build.ClusterBuildStrategyKind, "buildah")
+			sample.Namespace = "tenant-a"
+			strategyAnnotations = map[string]string{build.AnnotationPrivilegedStrategy: "false"}
+			strategySpec.Steps[0].SecurityContext = &corev1.SecurityContext{Privileged: ptr.To(true)}
+
+			Expect(NewStrategies(client, sample).ValidatePath(ctx)).To(Succeed())
+			Expect(sample.Status.Reason).To(BeNil())
+		})
+	})
+
 	Context("edge cases", func() {
 		It("should default to namespace build strategy when kind is nil", func() {
 			sample := &build.Build{
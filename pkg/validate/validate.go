@@ -24,6 +24,8 @@ const (
 	SourceURL = "sourceurl"
 	// Sources for validating `spec.sources` entries
 	Source = "source"
+	// AdditionalSources for validating `spec.additionalSources` entries
+	AdditionalSources = "additionalsources"
 	// Output for validating `spec.output` entry
 	Output = "output"
 	// BuildName for validating `metadata.name` entry
@@ -41,6 +43,8 @@ const (
 	Tolerations = "tolerations"
 	// SchedulerName for validating `spec.schedulerName` entry
 	SchedulerName = "schedulername"
+	// Notifications for validating `spec.notifications` entries
+	Notifications = "notifications"
 )
 
 const (
@@ -73,6 +77,8 @@ func NewValidation(
 		return &OwnerRef{Build: build, Client: client, Scheme: scheme}, nil
 	case Source:
 		return &SourceRef{Build: build}, nil
+	case AdditionalSources:
+		return &AdditionalSourcesRef{Build: build}, nil
 	case Output:
 		return &BuildSpecOutputValidator{Build: build}, nil
 	case BuildName:
@@ -87,6 +93,8 @@ func NewValidation(
 		return &TolerationsRef{Build: build}, nil
 	case SchedulerName:
 		return &SchedulerNameRef{Build: build}, nil
+	case Notifications:
+		return &NotificationsRef{Build: build}, nil
 	default:
 		return nil, fmt.Errorf("unknown validation type")
 	}
@@ -111,6 +119,12 @@ func BuildRunFields(buildRun *build.BuildRun) (string, string) {
 			"no build referenced or specified, either 'buildRef' or 'buildSpec' has to be set"
 	}
 
+	if buildRun.Spec.Source != nil && buildRun.Spec.Source.Type == build.LocalType && buildRun.Spec.Source.Local != nil {
+		if msg := localValidationMessage(buildRun.Spec.Source.Local); msg != "" {
+			return string(build.LocalSourceNotValid), msg
+		}
+	}
+
 	if buildRun.Spec.Build.Spec != nil {
 		if buildRun.Spec.Build.Name != nil {
 			return resources.BuildRunAmbiguousBuild,
@@ -122,6 +136,11 @@ func BuildRunFields(buildRun *build.BuildRun) (string, string) {
 				"cannot use 'output' override and 'buildSpec' simultaneously"
 		}
 
+		if buildRun.Spec.Cache != nil {
+			return resources.BuildRunBuildFieldOverrideForbidden,
+				"cannot use 'cache' override and 'buildSpec' simultaneously"
+		}
+
 		if len(buildRun.Spec.ParamValues) > 0 {
 			return resources.BuildRunBuildFieldOverrideForbidden,
 				"cannot use 'paramValues' override and 'buildSpec' simultaneously"
@@ -156,6 +175,11 @@ func BuildRunFields(buildRun *build.BuildRun) (string, string) {
 			return resources.BuildRunBuildFieldOverrideForbidden,
 				"cannot use 'schedulerName' override and 'buildSpec' simultaneously"
 		}
+
+		if len(buildRun.Spec.StepResources) > 0 {
+			return resources.BuildRunBuildFieldOverrideForbidden,
+				"cannot use 'stepResources' override and 'buildSpec' simultaneously"
+		}
 	}
 
 	return "", ""
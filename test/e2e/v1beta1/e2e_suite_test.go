@@ -44,6 +44,10 @@ var (
 		// create the container registry secret
 		Logf("Creating the container registry secret")
 		createContainerRegistrySecret(testBuild)
+
+		// allow the privileged cluster build strategies in the test namespace
+		Logf("Labeling the test namespace for privileged strategies")
+		allowPrivilegedStrategies(testBuild)
 	})
 
 	_ = AfterSuite(func() {
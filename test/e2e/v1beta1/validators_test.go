@@ -77,6 +77,16 @@ func createPipelineServiceAccount(testBuild *utils.TestBuild) {
 	Expect(err).ToNot(HaveOccurred(), "Error creating service account")
 }
 
+// allowPrivilegedStrategies labels the test namespace so that its Builds can use the privileged
+// cluster build strategies
+func allowPrivilegedStrategies(testBuild *utils.TestBuild) {
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:"true"}}}`, buildv1beta1.LabelPrivilegedStrategies)
+	_, err := testBuild.Clientset.CoreV1().
+		Namespaces().
+		Patch(testBuild.Context, testBuild.Namespace, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	Expect(err).ToNot(HaveOccurred(), "Error labeling the test namespace")
+}
+
 // createContainerRegistrySecret use environment variables to check for container registry
 // credentials secret, when not found a new secret is created.
 func createContainerRegistrySecret(testBuild *utils.TestBuild) {
@@ -12,6 +12,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
+
+	buildv1beta1 "github.com/shipwright-io/build/pkg/apis/build/v1beta1"
 )
 
 // This class is intended to host all CRUD calls for Namespace primitive resources
@@ -22,6 +24,8 @@ func (t *TestBuild) CreateNamespace() error {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: t.Namespace,
+			// the tests use privileged cluster build strategies
+			Labels: map[string]string{buildv1beta1.LabelPrivilegedStrategies: "true"},
 		},
 	}
 	_, err := client.Create(t.Context, ns, metav1.CreateOptions{})